@@ -1,31 +1,29 @@
 package main
 
 import (
-	"fmt"
+	"context"
+	"os"
+	"os/signal"
 
-	"github.com/devfile/parser/pkg/devfile/parser"
-	devfileParser "github.com/devfile/parser/pkg/devfile/parser"
+	"github.com/devfile/parser/pkg/cli"
 )
 
 func main() {
-	devfile, err := ParseDevfile("devfile.yaml")
-	if err != nil {
-		fmt.Println(err)
-	} else {
-		for _, component := range devfile.Data.GetAliasedComponents() {
-			if component.Dockerfile != nil {
-				fmt.Println(component.Dockerfile.DockerfileLocation)
-				break
-			}
-		}
-	}
+	// Cancel the command on interrupt, e.g. while downloading a devfile
+	ctx, cancel := context.WithCancel(context.Background())
+	interrupt := make(chan os.Signal, 1)
+	signal.Notify(interrupt, os.Interrupt)
+	go func() {
+		<-interrupt
+		cancel()
+	}()
 
-}
-
-//ParseDevfile to parse devfile from library
-func ParseDevfile(devfileLocation string) (devfileoj parser.DevfileObj, err error) {
-
-	var devfile devfileParser.DevfileObj
-	devfile, err = devfileParser.ParseAndValidate(devfileLocation)
-	return devfile, err
+	code := cli.Run(os.Args[1:], cli.Env{
+		Ctx:    ctx,
+		Stdin:  os.Stdin,
+		Stdout: os.Stdout,
+		Stderr: os.Stderr,
+	})
+	cancel()
+	os.Exit(code)
 }
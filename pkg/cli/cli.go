@@ -0,0 +1,272 @@
+package cli
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/pkg/errors"
+
+	"github.com/devfile/parser/pkg/devfile/parser"
+	"github.com/devfile/parser/pkg/devfile/validate"
+)
+
+// Exit codes of the devfile CLI, by class of failure
+const (
+	// ExitOK is returned when the command succeeds
+	ExitOK = 0
+
+	// ExitError is returned when the command fails for another reason, e.g. the output can't be written
+	ExitError = 1
+
+	// ExitUsage is returned when the command line is invalid
+	ExitUsage = 2
+
+	// ExitInvalidDevfile is returned when the devfile, or a stack of a registry, is invalid
+	ExitInvalidDevfile = 3
+
+	// ExitLoadError is returned when the devfile can't be read, downloaded or decoded
+	ExitLoadError = 4
+)
+
+// Version is the version of the devfile CLI, set at build time with
+// -ldflags "-X github.com/devfile/parser/pkg/cli.Version=<version>"
+var Version = "dev"
+
+// Env is the environment a command runs in
+type Env struct {
+	// Ctx cancels the command
+	Ctx context.Context
+
+	Stdin  io.Reader
+	Stdout io.Writer
+	Stderr io.Writer
+}
+
+// Command is a subcommand of the devfile CLI
+type Command struct {
+	// Name of the command on the command line
+	Name string
+
+	// Usage describes the arguments of the command, e.g. "[flags] <directory>"
+	Usage string
+
+	// Short is the one-line description of the command
+	Short string
+
+	// Run runs the command with the arguments following its name
+	Run func(env Env, args []string) error
+}
+
+// registry of the subcommands of the CLI, by name
+var (
+	commandsLock sync.RWMutex
+	commands     = make(map[string]*Command)
+)
+
+// RegisterCommand adds a subcommand to the CLI
+func RegisterCommand(cmd *Command) error {
+	if cmd == nil || cmd.Name == "" || cmd.Run == nil {
+		return fmt.Errorf("a command must have a name and a run function")
+	}
+	commandsLock.Lock()
+	defer commandsLock.Unlock()
+	if _, ok := commands[cmd.Name]; ok {
+		return fmt.Errorf("command '%s' is already registered", cmd.Name)
+	}
+	commands[cmd.Name] = cmd
+	return nil
+}
+
+// mustRegisterCommand registers a built-in subcommand of the CLI
+func mustRegisterCommand(cmd *Command) {
+	if err := RegisterCommand(cmd); err != nil {
+		panic(err)
+	}
+}
+
+// exitError is an error which sets the exit code of the CLI. An exitError without error has already been reported.
+type exitError struct {
+	code int
+	err  error
+}
+
+// Error returns the message of the wrapped error
+func (e *exitError) Error() string {
+	if e.err == nil {
+		return fmt.Sprintf("exit code %d", e.code)
+	}
+	return e.err.Error()
+}
+
+// usageErrorf returns an error for an invalid command line
+func usageErrorf(format string, args ...interface{}) error {
+	return &exitError{code: ExitUsage, err: fmt.Errorf(format, args...)}
+}
+
+// Run runs the CLI with the command line arguments, without the program name, and returns the exit code
+func Run(args []string, env Env) int {
+	if env.Ctx == nil {
+		env.Ctx = context.Background()
+	}
+	if env.Stdin == nil {
+		env.Stdin = strings.NewReader("")
+	}
+	if env.Stdout == nil {
+		env.Stdout = ioutil.Discard
+	}
+	if env.Stderr == nil {
+		env.Stderr = ioutil.Discard
+	}
+
+	if len(args) == 0 {
+		printUsage(env.Stderr)
+		return ExitUsage
+	}
+	if args[0] == "help" || args[0] == "-h" || args[0] == "--help" {
+		printUsage(env.Stdout)
+		return ExitOK
+	}
+
+	commandsLock.RLock()
+	cmd, ok := commands[args[0]]
+	commandsLock.RUnlock()
+	if !ok {
+		fmt.Fprintf(env.Stderr, "unknown command '%s'\n\n", args[0])
+		printUsage(env.Stderr)
+		return ExitUsage
+	}
+
+	err := cmd.Run(env, args[1:])
+	if err == flag.ErrHelp {
+		return ExitOK
+	}
+	code := exitCode(err)
+	if err != nil {
+		if e, ok := err.(*exitError); !ok || e.err != nil {
+			fmt.Fprintf(env.Stderr, "%s: %v\n", cmd.Name, err)
+		}
+	}
+	return code
+}
+
+// exitCode returns the exit code of the CLI for the error returned by a command
+func exitCode(err error) int {
+	switch e := err.(type) {
+	case nil:
+		return ExitOK
+	case *exitError:
+		return e.code
+	}
+	if _, ok := errors.Cause(err).(*validate.ValidationResult); ok {
+		return ExitInvalidDevfile
+	}
+	return ExitError
+}
+
+// printUsage prints the usage of the CLI, listing its commands
+func printUsage(w io.Writer) {
+	commandsLock.RLock()
+	defer commandsLock.RUnlock()
+
+	names := make([]string, 0, len(commands))
+	for name := range commands {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	fmt.Fprintln(w, "Usage: devfile <command> [flags] [arguments]")
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "Commands:")
+	for _, name := range names {
+		fmt.Fprintf(w, "  %-10s %s\n", name, commands[name].Short)
+	}
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "Run 'devfile <command> -h' for the flags of a command.")
+}
+
+// newFlagSet returns the flag set of a command, reporting its parsing errors on stderr
+func newFlagSet(env Env, cmd *Command) *flag.FlagSet {
+	flags := flag.NewFlagSet(cmd.Name, flag.ContinueOnError)
+	flags.SetOutput(env.Stderr)
+	flags.Usage = func() {
+		fmt.Fprintf(env.Stderr, "Usage: devfile %s %s\n\n%s\n\nFlags:\n", cmd.Name, cmd.Usage, cmd.Short)
+		flags.PrintDefaults()
+	}
+	return flags
+}
+
+// parseFlags parses the arguments of a command and returns its positional arguments, the flags being allowed
+// after them. The invalid flags are reported as already printed usage errors.
+func parseFlags(flags *flag.FlagSet, args []string) ([]string, error) {
+	var positional []string
+	for {
+		if err := flags.Parse(args); err != nil {
+			if err == flag.ErrHelp {
+				return nil, err
+			}
+			return nil, &exitError{code: ExitUsage}
+		}
+		args = flags.Args()
+		if len(args) == 0 {
+			return positional, nil
+		}
+		positional = append(positional, args[0])
+		args = args[1:]
+	}
+}
+
+// devfileFlags are the flags selecting and parsing the devfile of a command
+type devfileFlags struct {
+	path               string
+	noFlatten          bool
+	skipContainerCheck bool
+	implicitVolumes    bool
+	registryURLs       string
+}
+
+// register adds the devfile flags to the flag set
+func (f *devfileFlags) register(flags *flag.FlagSet) {
+	flags.StringVar(&f.path, "f", "devfile.yaml", "path or http(s) URL of the devfile, '-' to read it from stdin")
+	flags.BoolVar(&f.noFlatten, "no-flatten", false, "don't merge the parent and the plugins into the devfile")
+	flags.BoolVar(&f.skipContainerCheck, "skip-container-check", false, "accept devfiles without container component")
+	flags.BoolVar(&f.implicitVolumes, "implicit-volumes", false, "add a volume component for every mounted volume without volume component")
+	flags.StringVar(&f.registryURLs, "registry", "", "comma-separated registry URLs or directories resolving the parent and plugins referenced by id")
+}
+
+// parse parses the devfile with the validation level
+func (f *devfileFlags) parse(env Env, level parser.ValidationLevel) (parser.DevfileObj, error) {
+	flatten := !f.noFlatten
+	args := parser.ParserArgs{
+		Flatten:            &flatten,
+		ValidationLevel:    level,
+		SkipContainerCheck: f.skipContainerCheck,
+		ImplicitVolumes:    f.implicitVolumes,
+	}
+	if f.registryURLs != "" {
+		args.RegistryURLs = strings.Split(f.registryURLs, ",")
+	}
+	if f.path == "-" {
+		content, err := ioutil.ReadAll(env.Stdin)
+		if err != nil {
+			return parser.DevfileObj{}, &exitError{code: ExitLoadError, err: errors.Wrapf(err, "failed to read devfile from stdin")}
+		}
+		args.Data = content
+	} else {
+		args.Path = f.path
+	}
+
+	d, err := parser.ParseDevfile(env.Ctx, args)
+	if err != nil {
+		if _, ok := errors.Cause(err).(*validate.ValidationResult); ok {
+			return d, err
+		}
+		return d, &exitError{code: ExitLoadError, err: err}
+	}
+	return d, nil
+}
@@ -0,0 +1,280 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const validDevfile = `schemaVersion: 2.1.0
+metadata:
+  name: nodejs
+projects:
+  - name: nodejs-starter
+    git:
+      location: https://github.com/odo-devfiles/nodejs-ex.git
+components:
+  - container:
+      name: runtime
+      image: quay.io/nodejs
+      endpoints:
+        - name: http
+          targetPort: 3000
+          configuration: {}
+commands:
+  - exec:
+      id: run
+      component: runtime
+      commandLine: npm start
+      group:
+        kind: run
+        isDefault: true
+events:
+  postStart:
+    - run
+`
+
+const invalidDevfile = `schemaVersion: 2.1.0
+components:
+  - container:
+      name: runtime
+      image: quay.io/nodejs
+commands:
+  - exec:
+      id: run
+      component: tools
+      commandLine: npm start
+`
+
+func TestRun(t *testing.T) {
+
+	dir, err := ioutil.TempDir("", "devfile-cli")
+	if err != nil {
+		t.Fatalf("failed to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	valid := filepath.Join(dir, "devfile.yaml")
+	invalid := filepath.Join(dir, "invalid.yaml")
+	for path, content := range map[string]string{valid: validDevfile, invalid: invalidDevfile} {
+		if err := ioutil.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatalf("failed to write devfile: %v", err)
+		}
+	}
+
+	tests := []struct {
+		name       string
+		args       []string
+		stdin      string
+		wantCode   int
+		wantStdout []string
+		wantStderr string
+	}{
+		{
+			name:       "Case 1: Valid devfile",
+			args:       []string{"validate", "-f", valid},
+			wantStdout: []string{"is valid"},
+		},
+		{
+			name:       "Case 2: Invalid devfile",
+			args:       []string{"validate", "-f", invalid},
+			wantCode:   ExitInvalidDevfile,
+			wantStdout: []string{"SEVERITY", "/commands/0/exec/component", "9:7"},
+		},
+		{
+			name:       "Case 3: Invalid devfile in JSON",
+			args:       []string{"validate", "-f", invalid, "-o", "json"},
+			wantCode:   ExitInvalidDevfile,
+			wantStdout: []string{`"valid": false`, `"code": "invalid_exec_component"`},
+		},
+		{
+			name:       "Case 4: Missing devfile",
+			args:       []string{"validate", "-f", filepath.Join(dir, "missing.yaml")},
+			wantCode:   ExitLoadError,
+			wantStderr: "validate:",
+		},
+		{
+			name:       "Case 5: Components from stdin",
+			args:       []string{"show", "components", "-f", "-"},
+			stdin:      validDevfile,
+			wantStdout: []string{"NAME", "runtime", "Container", "quay.io/nodejs"},
+		},
+		{
+			name:       "Case 6: Commands in YAML",
+			args:       []string{"show", "-f", valid, "-o", "yaml", "commands"},
+			wantStdout: []string{"commandLine: npm start"},
+		},
+		{
+			name:       "Case 7: Projects",
+			args:       []string{"show", "projects", "-f", valid},
+			wantStdout: []string{"nodejs-starter", "git", "https://github.com/odo-devfiles/nodejs-ex.git"},
+		},
+		{
+			name:       "Case 8: Events",
+			args:       []string{"show", "events", "-f", valid},
+			wantStdout: []string{"postStart", "run"},
+		},
+		{
+			name:       "Case 9: Unknown section",
+			args:       []string{"show", "variables", "-f", valid},
+			wantCode:   ExitUsage,
+			wantStderr: "unknown section 'variables'",
+		},
+		{
+			name:       "Case 10: Schema",
+			args:       []string{"schema", "-version", "2.0.0"},
+			wantStdout: []string{`"description": "Devfile schema."`},
+		},
+		{
+			name:       "Case 11: Supported versions",
+			args:       []string{"schema", "-list"},
+			wantStdout: []string{"1.0.0\n2.0.0\n2.1.0\n2.2.0\n"},
+		},
+		{
+			name:       "Case 12: Unsupported schema version",
+			args:       []string{"schema", "-version", "3.0.0"},
+			wantCode:   ExitUsage,
+			wantStderr: "3.0.0",
+		},
+		{
+			name:       "Case 13: Version",
+			args:       []string{"version", "-o", "json"},
+			wantStdout: []string{`"version": "dev"`, `"2.2.0"`},
+		},
+		{
+			name:       "Case 14: Write JSON to stdout",
+			args:       []string{"write", "-f", valid, "-format", "json"},
+			wantStdout: []string{`"schemaVersion": "2.1.0"`},
+		},
+		{
+			name:       "Case 15: Write converted devfile",
+			args:       []string{"write", "-f", valid, "-to", "2.0.0"},
+			wantStdout: []string{"schemaVersion: 2.0.0"},
+		},
+		{
+			name:       "Case 16: Unknown command",
+			args:       []string{"lint"},
+			wantCode:   ExitUsage,
+			wantStderr: "unknown command 'lint'",
+		},
+		{
+			name:     "Case 17: Unknown flag",
+			args:     []string{"validate", "-strict"},
+			wantCode: ExitUsage,
+		},
+		{
+			name:       "Case 18: Unsupported output format",
+			args:       []string{"show", "components", "-f", valid, "-o", "xml"},
+			wantCode:   ExitUsage,
+			wantStderr: "unsupported output format 'xml'",
+		},
+		{
+			name:       "Case 19: Help",
+			args:       []string{"help"},
+			wantStdout: []string{"Commands:", "validate"},
+		},
+		{
+			name:       "Case 20: Tekton pipeline",
+			args:       []string{"pipeline", "-f", valid, "-namespace", "ci"},
+			wantStdout: []string{"kind: Pipeline", "name: nodejs-clone", "namespace: ci", "---\n", "git clone 'https://github.com/odo-devfiles/nodejs-ex.git'"},
+		},
+		{
+			name:       "Case 21: Tekton pipeline of a devfile without name",
+			args:       []string{"pipeline", "-f", "-"},
+			stdin:      strings.Replace(validDevfile, "metadata:\n  name: nodejs\n", "", 1),
+			wantCode:   ExitUsage,
+			wantStderr: "the pipeline name is required",
+		},
+		{
+			name:       "Case 22: Valid devfile with warnings",
+			args:       []string{"validate", "-f", "-"},
+			stdin:      strings.Replace(validDevfile, "image: quay.io/nodejs", "image: quay.io/nodejs:{{version}}", 1),
+			wantStdout: []string{"warning", "/components/0/container/image", "variable 'version' is not defined"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var stdout, stderr bytes.Buffer
+			code := Run(tt.args, Env{Stdin: strings.NewReader(tt.stdin), Stdout: &stdout, Stderr: &stderr})
+			if code != tt.wantCode {
+				t.Errorf("got exit code %d, want %d, stderr: %s", code, tt.wantCode, stderr.String())
+			}
+			for _, want := range tt.wantStdout {
+				if !strings.Contains(stdout.String(), want) {
+					t.Errorf("expected stdout to contain '%s', got:\n%s", want, stdout.String())
+				}
+			}
+			if !strings.Contains(stderr.String(), tt.wantStderr) {
+				t.Errorf("expected stderr to contain '%s', got:\n%s", tt.wantStderr, stderr.String())
+			}
+		})
+	}
+
+	t.Run("write to a file", func(t *testing.T) {
+		out := filepath.Join(dir, "out.json")
+		if code := Run([]string{"write", "-f", valid, "-out", out}, Env{}); code != ExitOK {
+			t.Fatalf("got exit code %d", code)
+		}
+		content, err := ioutil.ReadFile(out)
+		if err != nil {
+			t.Fatalf("failed to read written devfile: %v", err)
+		}
+		var m map[string]interface{}
+		if err := json.Unmarshal(content, &m); err != nil || m["schemaVersion"] != "2.1.0" {
+			t.Errorf("expected a JSON devfile, got '%s' (%v)", content, err)
+		}
+	})
+
+	t.Run("registry build", func(t *testing.T) {
+		registryDir := filepath.Join(dir, "registry")
+		if err := os.MkdirAll(filepath.Join(registryDir, "nodejs"), 0755); err != nil {
+			t.Fatalf("failed to create stack: %v", err)
+		}
+		if err := ioutil.WriteFile(filepath.Join(registryDir, "nodejs", "devfile.yaml"), []byte(validDevfile), 0644); err != nil {
+			t.Fatalf("failed to write stack: %v", err)
+		}
+
+		var stdout bytes.Buffer
+		if code := Run([]string{"registry", "build", registryDir}, Env{Stdout: &stdout}); code != ExitOK {
+			t.Fatalf("got exit code %d", code)
+		}
+		if !strings.Contains(stdout.String(), "wrote the index of 1 stack(s)") {
+			t.Errorf("unexpected output:\n%s", stdout.String())
+		}
+		if _, err := os.Stat(filepath.Join(registryDir, "index.json")); err != nil {
+			t.Errorf("expected the index to be written: %v", err)
+		}
+	})
+}
+
+func TestRegisterCommand(t *testing.T) {
+
+	if err := RegisterCommand(&Command{Name: "validate", Run: runValidate}); err == nil {
+		t.Errorf("expected an error registering a command twice")
+	}
+	if err := RegisterCommand(&Command{Name: "noop"}); err == nil {
+		t.Errorf("expected an error registering a command without run function")
+	}
+
+	if err := RegisterCommand(&Command{Name: "hello", Short: "Say hello", Run: func(env Env, args []string) error {
+		_, err := env.Stdout.Write([]byte("hello " + strings.Join(args, " ")))
+		return err
+	}}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer func() {
+		commandsLock.Lock()
+		delete(commands, "hello")
+		commandsLock.Unlock()
+	}()
+
+	var stdout bytes.Buffer
+	if code := Run([]string{"hello", "world"}, Env{Stdout: &stdout}); code != ExitOK || stdout.String() != "hello world" {
+		t.Errorf("got exit code %d and output '%s'", code, stdout.String())
+	}
+}
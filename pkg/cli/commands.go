@@ -0,0 +1,361 @@
+package cli
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/devfile/parser/pkg/devfile/parser"
+	"github.com/devfile/parser/pkg/devfile/parser/data"
+	"github.com/devfile/parser/pkg/devfile/validate"
+)
+
+var validateCommand = &Command{
+	Name:  "validate",
+	Usage: "[flags]",
+	Short: "Validate a devfile against its schema and the devfile rules",
+}
+
+var showCommand = &Command{
+	Name:  "show",
+	Usage: "[flags] components|commands|projects|events",
+	Short: "Show a section of a devfile",
+}
+
+var schemaCommand = &Command{
+	Name:  "schema",
+	Usage: "[flags]",
+	Short: "Print the JSON schema of a devfile version",
+}
+
+var versionCommand = &Command{
+	Name:  "version",
+	Usage: "[flags]",
+	Short: "Print the version of the CLI and the supported devfile versions",
+}
+
+var writeCommand = &Command{
+	Name:  "write",
+	Usage: "[flags]",
+	Short: "Write a devfile, flattened and optionally converted to another version",
+}
+
+// Registers the built-in subcommands of the CLI. Their run functions are set here as they refer to the commands.
+func init() {
+	validateCommand.Run = runValidate
+	showCommand.Run = runShow
+	schemaCommand.Run = runSchema
+	versionCommand.Run = runVersion
+	writeCommand.Run = runWrite
+	for _, cmd := range []*Command{validateCommand, showCommand, schemaCommand, versionCommand, writeCommand} {
+		mustRegisterCommand(cmd)
+	}
+}
+
+// validationReport is the output of the validate command
+type validationReport struct {
+	Valid  bool                       `json:"valid"`
+	Errors []validate.ValidationError `json:"errors,omitempty"`
+}
+
+// runValidate validates the devfile and prints the problems found
+func runValidate(env Env, args []string) error {
+	var devfile devfileFlags
+	var output string
+	flags := newFlagSet(env, validateCommand)
+	devfile.register(flags)
+	registerOutputFlag(flags, &output)
+	positional, err := parseFlags(flags, args)
+	if err != nil {
+		return err
+	}
+	if len(positional) > 0 {
+		return usageErrorf("unexpected argument '%s'", positional[0])
+	}
+	if err := checkOutputFormat(output); err != nil {
+		return err
+	}
+
+	report := validationReport{Valid: true}
+	d, err := devfile.parse(env, parser.ValidateFull)
+	if err != nil {
+		result, ok := errors.Cause(err).(*validate.ValidationResult)
+		if !ok {
+			return err
+		}
+		report = validationReport{Valid: false, Errors: result.Errors}
+	} else if d.Warnings != nil {
+		report.Errors = d.Warnings.Errors
+	}
+
+	t := &table{headers: []string{"SEVERITY", "PATH", "POSITION", "MESSAGE"}}
+	for _, e := range report.Errors {
+		position := ""
+		if e.Position != nil {
+			position = fmt.Sprintf("%d:%d", e.Position.Line, e.Position.Column)
+		}
+		t.addRow(string(e.Severity), e.Path, position, e.Message)
+	}
+	if output == OutputTable && len(report.Errors) == 0 {
+		fmt.Fprintf(env.Stdout, "devfile '%s' is valid\n", devfile.path)
+	} else if err := printOutput(env.Stdout, output, report, t); err != nil {
+		return err
+	}
+
+	if !report.Valid {
+		return &exitError{code: ExitInvalidDevfile}
+	}
+	return nil
+}
+
+// runShow prints a section of the devfile
+func runShow(env Env, args []string) error {
+	var devfile devfileFlags
+	var output string
+	flags := newFlagSet(env, showCommand)
+	devfile.register(flags)
+	registerOutputFlag(flags, &output)
+	positional, err := parseFlags(flags, args)
+	if err != nil {
+		return err
+	}
+	if err := checkOutputFormat(output); err != nil {
+		return err
+	}
+	if len(positional) != 1 {
+		return usageErrorf("expected one section to show, components, commands, projects or events")
+	}
+
+	var show func(d parser.DevfileObj) (interface{}, *table)
+	switch section := positional[0]; section {
+	case "components":
+		show = showComponents
+	case "commands":
+		show = showCommands
+	case "projects":
+		show = showProjects
+	case "events":
+		show = showEvents
+	default:
+		return usageErrorf("unknown section '%s', should be components, commands, projects or events", section)
+	}
+
+	d, err := devfile.parse(env, parser.ValidateSchema)
+	if err != nil {
+		return err
+	}
+	value, t := show(d)
+	return printOutput(env.Stdout, output, value, t)
+}
+
+// showComponents returns the components of the devfile and their table
+func showComponents(d parser.DevfileObj) (interface{}, *table) {
+	components := d.Data.GetComponents()
+	t := &table{headers: []string{"NAME", "TYPE", "DETAILS"}}
+	for _, c := range components {
+		var details string
+		switch {
+		case c.Container != nil:
+			details = c.Container.Image
+		case c.Image != nil:
+			details = c.Image.ImageName
+		case c.Dockerfile != nil:
+			details = c.Dockerfile.DockerfileLocation
+		case c.Volume != nil:
+			details = c.Volume.Size
+		case c.Kubernetes != nil:
+			details = c.Kubernetes.Uri
+		case c.Openshift != nil:
+			details = c.Openshift.Uri
+		case c.Plugin != nil:
+			details = c.Plugin.Uri + c.Plugin.Id
+		}
+		t.addRow(c.GetName(), string(c.GetComponentType()), details)
+	}
+	return components, t
+}
+
+// showCommands returns the commands of the devfile and their table
+func showCommands(d parser.DevfileObj) (interface{}, *table) {
+	commands := d.Data.GetCommands()
+	t := &table{headers: []string{"ID", "TYPE", "GROUP", "DEFAULT", "DETAILS"}}
+	for _, c := range commands {
+		var group, isDefault string
+		if g := c.GetGroup(); g != nil {
+			group, isDefault = string(g.Kind), strconv.FormatBool(g.IsDefault)
+		}
+		var details string
+		switch {
+		case c.Exec != nil:
+			details = fmt.Sprintf("%s: %s", c.Exec.Component, c.Exec.CommandLine)
+		case c.Apply != nil:
+			details = c.Apply.Component
+		case c.Composite != nil:
+			details = strings.Join(c.Composite.Commands, ",")
+		}
+		t.addRow(c.GetId(), string(c.GetCommandType()), group, isDefault, details)
+	}
+	return commands, t
+}
+
+// showProjects returns the projects of the devfile and their table
+func showProjects(d parser.DevfileObj) (interface{}, *table) {
+	projects := d.Data.GetProjects()
+	t := &table{headers: []string{"NAME", "SOURCE", "LOCATION"}}
+	for _, p := range projects {
+		switch {
+		case p.Git != nil:
+			t.addRow(p.Name, "git", p.Git.GetLocation())
+		case p.Github != nil:
+			t.addRow(p.Name, "github", p.Github.GetLocation())
+		case p.Zip != nil:
+			t.addRow(p.Name, "zip", p.Zip.Location)
+		default:
+			t.addRow(p.Name, "", "")
+		}
+	}
+	return projects, t
+}
+
+// showEvents returns the events of the devfile and their table
+func showEvents(d parser.DevfileObj) (interface{}, *table) {
+	events := d.Data.GetEvents()
+	t := &table{headers: []string{"EVENT", "COMMANDS"}}
+	for _, e := range []struct {
+		name     string
+		commands []string
+	}{
+		{"preStart", events.PreStart},
+		{"postStart", events.PostStart},
+		{"preStop", events.PreStop},
+		{"postStop", events.PostStop},
+	} {
+		if len(e.commands) > 0 {
+			t.addRow(e.name, strings.Join(e.commands, ","))
+		}
+	}
+	return events, t
+}
+
+// runSchema prints the JSON schema of a devfile version, or the supported versions
+func runSchema(env Env, args []string) error {
+	var version string
+	var list bool
+	flags := newFlagSet(env, schemaCommand)
+	flags.StringVar(&version, "version", "", "devfile version, the latest supported version when empty")
+	flags.BoolVar(&list, "list", false, "list the supported devfile versions")
+	positional, err := parseFlags(flags, args)
+	if err != nil {
+		return err
+	}
+	if len(positional) > 0 {
+		return usageErrorf("unexpected argument '%s'", positional[0])
+	}
+
+	if list {
+		for _, v := range data.SupportedVersions() {
+			fmt.Fprintln(env.Stdout, v)
+		}
+		return nil
+	}
+	if version == "" {
+		version = latestVersion()
+	}
+	schema, err := data.GetDevfileJSONSchema(version)
+	if err != nil {
+		return usageErrorf("%v", err)
+	}
+	_, err = fmt.Fprintln(env.Stdout, strings.TrimSpace(schema))
+	return err
+}
+
+// latestVersion returns the latest supported devfile version which is not a pre-release
+func latestVersion() string {
+	versions := data.SupportedVersions()
+	for i := len(versions) - 1; i >= 0; i-- {
+		if !strings.Contains(versions[i], "-") {
+			return versions[i]
+		}
+	}
+	return ""
+}
+
+// versionInfo is the output of the version command
+type versionInfo struct {
+	Version        string   `json:"version"`
+	SchemaVersions []string `json:"schemaVersions"`
+}
+
+// runVersion prints the version of the CLI and the supported devfile versions
+func runVersion(env Env, args []string) error {
+	var output string
+	flags := newFlagSet(env, versionCommand)
+	registerOutputFlag(flags, &output)
+	positional, err := parseFlags(flags, args)
+	if err != nil {
+		return err
+	}
+	if len(positional) > 0 {
+		return usageErrorf("unexpected argument '%s'", positional[0])
+	}
+	if err := checkOutputFormat(output); err != nil {
+		return err
+	}
+
+	info := versionInfo{Version: Version, SchemaVersions: data.SupportedVersions()}
+	t := &table{headers: []string{"VERSION", "SCHEMA VERSIONS"}}
+	t.addRow(info.Version, strings.Join(info.SchemaVersions, ","))
+	return printOutput(env.Stdout, output, info, t)
+}
+
+// runWrite writes the devfile, flattened and optionally converted, to a file or to stdout
+func runWrite(env Env, args []string) error {
+	var devfile devfileFlags
+	var out, format, to string
+	var preserve, prune bool
+	flags := newFlagSet(env, writeCommand)
+	devfile.register(flags)
+	flags.StringVar(&out, "out", "-", "path the devfile is written to, '-' to write it to stdout")
+	flags.StringVar(&format, "format", "", "format of the written devfile, yaml or json, inferred from the path when empty")
+	flags.StringVar(&to, "to", "", "devfile version to convert the devfile to")
+	flags.BoolVar(&preserve, "preserve-formatting", false, "keep the comments and formatting of a YAML devfile")
+	flags.BoolVar(&prune, "prune-defaults", false, "omit the fields set to their default value")
+	positional, err := parseFlags(flags, args)
+	if err != nil {
+		return err
+	}
+	if len(positional) > 0 {
+		return usageErrorf("unexpected argument '%s'", positional[0])
+	}
+	if format != "" && format != parser.FormatYAML && format != parser.FormatJSON {
+		return usageErrorf("unsupported format '%s', should be '%s' or '%s'", format, parser.FormatYAML, parser.FormatJSON)
+	}
+
+	d, err := devfile.parse(env, parser.ValidateSchema)
+	if err != nil {
+		return err
+	}
+	if to != "" {
+		converted, warnings, err := parser.Convert(d, to)
+		if err != nil {
+			return err
+		}
+		for _, w := range warnings.Errors {
+			fmt.Fprintf(env.Stderr, "warning: %s\n", w)
+		}
+		d = converted
+	}
+
+	options := parser.WriteOptions{Format: format, PreserveFormatting: preserve, PruneDefaults: prune, Flattened: !devfile.noFlatten}
+	if out != "-" {
+		return d.WriteDevfile(out, options)
+	}
+	content, err := d.Encode(options)
+	if err != nil {
+		return err
+	}
+	_, err = env.Stdout.Write(content)
+	return err
+}
@@ -0,0 +1,72 @@
+package cli
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/ghodss/yaml"
+	"github.com/pkg/errors"
+)
+
+// Output formats of the commands
+const (
+	OutputTable = "table"
+	OutputJSON  = "json"
+	OutputYAML  = "yaml"
+)
+
+// table is the tabular output of a command
+type table struct {
+	headers []string
+	rows    [][]string
+}
+
+// addRow adds a row to the table
+func (t *table) addRow(cells ...string) {
+	t.rows = append(t.rows, cells)
+}
+
+// registerOutputFlag adds the output format flag to the flag set
+func registerOutputFlag(flags *flag.FlagSet, output *string) {
+	flags.StringVar(output, "o", OutputTable, fmt.Sprintf("output format, one of '%s', '%s' or '%s'", OutputTable, OutputJSON, OutputYAML))
+}
+
+// checkOutputFormat returns a usage error if the output format is not supported
+func checkOutputFormat(format string) error {
+	switch format {
+	case OutputTable, OutputJSON, OutputYAML:
+		return nil
+	}
+	return usageErrorf("unsupported output format '%s', should be '%s', '%s' or '%s'", format, OutputTable, OutputJSON, OutputYAML)
+}
+
+// printOutput prints the value in JSON or YAML, or the table in the table format
+func printOutput(w io.Writer, format string, value interface{}, t *table) error {
+	switch format {
+	case OutputJSON:
+		content, err := json.MarshalIndent(value, "", "  ")
+		if err != nil {
+			return errors.Wrapf(err, "failed to encode the output")
+		}
+		_, err = fmt.Fprintf(w, "%s\n", content)
+		return err
+	case OutputYAML:
+		content, err := yaml.Marshal(value)
+		if err != nil {
+			return errors.Wrapf(err, "failed to encode the output")
+		}
+		_, err = w.Write(content)
+		return err
+	}
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, strings.Join(t.headers, "\t"))
+	for _, row := range t.rows {
+		fmt.Fprintln(tw, strings.Join(row, "\t"))
+	}
+	return tw.Flush()
+}
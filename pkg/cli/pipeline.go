@@ -0,0 +1,63 @@
+package cli
+
+import (
+	"github.com/devfile/parser/pkg/devfile/generator"
+	"github.com/devfile/parser/pkg/devfile/parser"
+)
+
+var pipelineCommand = &Command{
+	Name:  "pipeline",
+	Usage: "[flags]",
+	Short: "Generate the Tekton Pipeline and Tasks cloning, building, testing and imaging a devfile",
+}
+
+// Registers the pipeline subcommand
+func init() {
+	pipelineCommand.Run = runPipeline
+	mustRegisterCommand(pipelineCommand)
+}
+
+// runPipeline prints the Tekton Pipeline and Tasks of the devfile as a YAML stream
+func runPipeline(env Env, args []string) error {
+	var devfile devfileFlags
+	var name, namespace string
+	flags := newFlagSet(env, pipelineCommand)
+	devfile.register(flags)
+	flags.StringVar(&name, "name", "", "name of the Pipeline and prefix of the Tasks, the devfile name when empty")
+	flags.StringVar(&namespace, "namespace", "", "namespace of the Pipeline and the Tasks")
+	positional, err := parseFlags(flags, args)
+	if err != nil {
+		return err
+	}
+	if len(positional) > 0 {
+		return usageErrorf("unexpected argument '%s'", positional[0])
+	}
+
+	d, err := devfile.parse(env, parser.ValidateFull)
+	if err != nil {
+		return err
+	}
+	if name == "" {
+		name = d.Data.GetMetadata().Name
+	}
+	if name == "" {
+		return usageErrorf("the devfile has no name, the pipeline name is required")
+	}
+
+	pipeline, tasks, err := generator.GetPipeline(d, generator.PipelineParams{
+		ObjectMeta: generator.GetObjectMeta(name, namespace, nil, nil),
+	})
+	if err != nil {
+		return err
+	}
+	objects := []interface{}{pipeline}
+	for _, task := range tasks {
+		objects = append(objects, task)
+	}
+	content, err := generator.EncodeYAML(objects...)
+	if err != nil {
+		return err
+	}
+	_, err = env.Stdout.Write(content)
+	return err
+}
@@ -0,0 +1,61 @@
+package cli
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/devfile/parser/pkg/devfile/registry"
+)
+
+var registryCommand = &Command{
+	Name:  "registry",
+	Usage: "build [flags] <directory>",
+	Short: "Build the index of a local registry directory of stacks",
+}
+
+// Registers the registry subcommand
+func init() {
+	registryCommand.Run = runRegistry
+	mustRegisterCommand(registryCommand)
+}
+
+// runRegistry validates the stacks of a local registry and writes its index
+func runRegistry(env Env, args []string) error {
+	if len(args) == 0 || args[0] != "build" {
+		return usageErrorf("expected the 'build' registry command")
+	}
+
+	var output string
+	flags := newFlagSet(env, registryCommand)
+	registerOutputFlag(flags, &output)
+	positional, err := parseFlags(flags, args[1:])
+	if err != nil {
+		return err
+	}
+	if err := checkOutputFormat(output); err != nil {
+		return err
+	}
+	if len(positional) != 1 {
+		return usageErrorf("expected the registry directory")
+	}
+
+	stacks, err := registry.BuildRegistry(env.Ctx, nil, positional[0])
+	if err != nil {
+		if _, ok := err.(*registry.BuildError); ok {
+			return &exitError{code: ExitInvalidDevfile, err: err}
+		}
+		return err
+	}
+
+	t := &table{headers: []string{"NAME", "DISPLAY NAME", "LANGUAGE", "TAGS"}}
+	for _, s := range stacks {
+		t.addRow(s.Name, s.DisplayName, s.Language, strings.Join(s.Tags, ","))
+	}
+	if err := printOutput(env.Stdout, output, stacks, t); err != nil {
+		return err
+	}
+	if output == OutputTable {
+		fmt.Fprintf(env.Stdout, "\nwrote the index of %d stack(s)\n", len(stacks))
+	}
+	return nil
+}
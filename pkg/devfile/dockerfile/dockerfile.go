@@ -0,0 +1,543 @@
+package dockerfile
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Dockerfile instructions
+const (
+	InstructionAdd         = "ADD"
+	InstructionArg         = "ARG"
+	InstructionCmd         = "CMD"
+	InstructionCopy        = "COPY"
+	InstructionEntrypoint  = "ENTRYPOINT"
+	InstructionEnv         = "ENV"
+	InstructionExpose      = "EXPOSE"
+	InstructionFrom        = "FROM"
+	InstructionHealthcheck = "HEALTHCHECK"
+	InstructionLabel       = "LABEL"
+	InstructionMaintainer  = "MAINTAINER"
+	InstructionOnbuild     = "ONBUILD"
+	InstructionRun         = "RUN"
+	InstructionShell       = "SHELL"
+	InstructionStopsignal  = "STOPSIGNAL"
+	InstructionUser        = "USER"
+	InstructionVolume      = "VOLUME"
+	InstructionWorkdir     = "WORKDIR"
+)
+
+// knownInstructions are the instructions supported by the Dockerfile syntax
+var knownInstructions = map[string]bool{
+	InstructionAdd: true, InstructionArg: true, InstructionCmd: true, InstructionCopy: true,
+	InstructionEntrypoint: true, InstructionEnv: true, InstructionExpose: true, InstructionFrom: true,
+	InstructionHealthcheck: true, InstructionLabel: true, InstructionMaintainer: true, InstructionOnbuild: true,
+	InstructionRun: true, InstructionShell: true, InstructionStopsignal: true, InstructionUser: true,
+	InstructionVolume: true, InstructionWorkdir: true,
+}
+
+// jsonFormInstructions are the instructions whose arguments may be written as a JSON array
+var jsonFormInstructions = map[string]bool{
+	InstructionAdd: true, InstructionCmd: true, InstructionCopy: true, InstructionEntrypoint: true,
+	InstructionRun: true, InstructionShell: true, InstructionVolume: true,
+}
+
+// shellFormInstructions are the instructions whose arguments are a command line run by the shell when not written as a JSON array
+var shellFormInstructions = map[string]bool{
+	InstructionCmd: true, InstructionEntrypoint: true, InstructionRun: true,
+}
+
+var (
+	stageNameRegexp = regexp.MustCompile(`^[a-z][a-z0-9._-]*$`)
+	argNameRegexp   = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+)
+
+// Errors
+var (
+	ErrorEmptyDockerfile        = "the Dockerfile is empty"
+	ErrorNoFrom                 = "the Dockerfile has no FROM instruction"
+	ErrorUnknownInstruction     = "unknown instruction '%s'"
+	ErrorInstructionBeforeFrom  = "instruction '%s' before the first FROM, only ARG is allowed"
+	ErrorMissingArguments       = "instruction '%s' requires at least %d argument(s)"
+	ErrorInvalidFrom            = "invalid FROM instruction, expected 'FROM [--platform=<platform>] <image> [AS <name>]'"
+	ErrorInvalidStageName       = "invalid stage name '%s', it must start with a letter and only contain lowercase letters, digits, '.', '_' and '-'"
+	ErrorDuplicateStageName     = "stage name '%s' is already used by the stage at line %d"
+	ErrorInvalidArgName         = "invalid ARG name '%s'"
+	ErrorCopyFromUndefinedStage = "COPY --from=%s refers to a stage which is not defined before the stage '%s'"
+	ErrorInvalidShell           = "SHELL requires its arguments as a JSON array of strings"
+	ErrorInvalidEscapeDirective = "invalid escape directive '%s', expected '\\' or '`'"
+)
+
+// Problem is an error found in a Dockerfile
+type Problem struct {
+	// Line is the 1-based line of the instruction with the problem, 0 for the whole Dockerfile
+	Line int
+
+	// Message describes the problem
+	Message string
+}
+
+// String returns the problem prefixed with its line
+func (p Problem) String() string {
+	if p.Line == 0 {
+		return p.Message
+	}
+	return fmt.Sprintf("line %d: %s", p.Line, p.Message)
+}
+
+// ValidationError is returned when a Dockerfile is invalid, listing all the problems found
+type ValidationError struct {
+	Problems []Problem
+}
+
+// Error lists the problems of the Dockerfile
+func (e *ValidationError) Error() string {
+	var b strings.Builder
+	b.WriteString("invalid Dockerfile:")
+	for _, problem := range e.Problems {
+		fmt.Fprintf(&b, "\n  - %s", problem)
+	}
+	return b.String()
+}
+
+// Instruction is an instruction of a Dockerfile
+type Instruction struct {
+	// Command is the instruction keyword in upper case, e.g. FROM or COPY
+	Command string
+
+	// Flags are the --name=value flags preceding the arguments, e.g. the --from flag of COPY
+	Flags map[string]string
+
+	// Args are the arguments of the instruction. The command line of a RUN, CMD or ENTRYPOINT instruction
+	// written in the shell form is a single argument.
+	Args []string
+
+	// JSONForm is true if the arguments are written as a JSON array, e.g. ENTRYPOINT ["node", "server.js"]
+	JSONForm bool
+
+	// Original is the instruction as written, its continuation lines being joined
+	Original string
+
+	// Line is the 1-based line the instruction starts at
+	Line int
+}
+
+// Arg is a build argument declared by an ARG instruction
+type Arg struct {
+	Name string
+
+	// Value is the default value of the argument
+	Value string
+
+	// HasDefault is true if the argument has a default value, possibly empty
+	HasDefault bool
+}
+
+// Stage is a build stage, starting at a FROM instruction
+type Stage struct {
+	// Index is the 0-based index of the stage
+	Index int
+
+	// Name is the name given with FROM ... AS <name>, in lower case
+	Name string
+
+	// BaseImage is the image the stage starts from, or the name of a previous stage
+	BaseImage string
+
+	// Platform is the value of the --platform flag of FROM
+	Platform string
+
+	// From is the FROM instruction of the stage
+	From Instruction
+
+	// Instructions are the instructions of the stage following FROM
+	Instructions []Instruction
+}
+
+// Entrypoint returns the effective ENTRYPOINT instruction of the stage, the last one, or nil if none
+func (s *Stage) Entrypoint() *Instruction {
+	for i := len(s.Instructions) - 1; i >= 0; i-- {
+		if s.Instructions[i].Command == InstructionEntrypoint {
+			return &s.Instructions[i]
+		}
+	}
+	return nil
+}
+
+// Dockerfile is a parsed Dockerfile
+type Dockerfile struct {
+	// Directives are the parser directives at the top of the Dockerfile, e.g. syntax or escape
+	Directives map[string]string
+
+	// GlobalArgs are the build arguments declared before the first FROM, which can be used in the FROM instructions
+	GlobalArgs []Arg
+
+	// Stages are the build stages in order
+	Stages []Stage
+
+	// Instructions are all the instructions in order
+	Instructions []Instruction
+}
+
+// GetStage returns the stage with the given name, or nil if none
+func (d *Dockerfile) GetStage(name string) *Stage {
+	if name == "" {
+		return nil
+	}
+	name = strings.ToLower(name)
+	for i := range d.Stages {
+		if d.Stages[i].Name == name {
+			return &d.Stages[i]
+		}
+	}
+	return nil
+}
+
+// Args returns the build arguments declared by the Dockerfile, before the first FROM and in the stages
+func (d *Dockerfile) Args() []Arg {
+	args := append([]Arg{}, d.GlobalArgs...)
+	for _, stage := range d.Stages {
+		for _, instruction := range stage.Instructions {
+			if instruction.Command == InstructionArg {
+				args = append(args, parseArgs(instruction)...)
+			}
+		}
+	}
+	return args
+}
+
+// Parse parses the content of a Dockerfile into its instructions and build stages, and validates them:
+// the instructions must be known and have their required arguments, only ARG instructions may precede
+// the first FROM, the stage names must be valid and unique, and COPY --from must refer to an image or a
+// previous stage. A *ValidationError lists all the problems found.
+func Parse(content []byte) (*Dockerfile, error) {
+	p := &dockerfileParser{
+		d:          &Dockerfile{Directives: make(map[string]string)},
+		escape:     '\\',
+		stageLines: make(map[string]int),
+	}
+	p.parse(string(content))
+	if len(p.problems) > 0 {
+		return p.d, &ValidationError{Problems: p.problems}
+	}
+	return p.d, nil
+}
+
+// dockerfileParser parses and validates the Dockerfile instructions
+type dockerfileParser struct {
+	d          *Dockerfile
+	escape     byte
+	stageLines map[string]int
+	problems   []Problem
+}
+
+// addProblem reports a problem at the given line
+func (p *dockerfileParser) addProblem(line int, message string) {
+	p.problems = append(p.problems, Problem{Line: line, Message: message})
+}
+
+// parse splits the content into instructions, joining the continuation lines and skipping the comments
+func (p *dockerfileParser) parse(content string) {
+	lines := strings.Split(strings.Replace(content, "\r\n", "\n", -1), "\n")
+
+	start := p.parseDirectives(lines)
+
+	var current strings.Builder
+	startLine := 0
+	for i := start; i < len(lines); i++ {
+		line := lines[i]
+		trimmed := strings.TrimSpace(line)
+		if trimmed == "" || strings.HasPrefix(trimmed, "#") {
+			// Empty and comment lines are skipped, including within continuation lines
+			continue
+		}
+		if startLine == 0 {
+			startLine = i + 1
+		}
+
+		trimmed = strings.TrimRight(line, " \t")
+		if strings.HasSuffix(trimmed, string(p.escape)) {
+			current.WriteString(strings.TrimSuffix(trimmed, string(p.escape)))
+			continue
+		}
+		current.WriteString(line)
+		p.addInstruction(current.String(), startLine)
+		current.Reset()
+		startLine = 0
+	}
+	// The last instruction may end with a line continuation
+	if startLine != 0 {
+		p.addInstruction(current.String(), startLine)
+	}
+
+	p.validateCopyFrom()
+	if len(p.d.Instructions) == 0 {
+		p.addProblem(0, ErrorEmptyDockerfile)
+	} else if len(p.d.Stages) == 0 {
+		p.addProblem(0, ErrorNoFrom)
+	}
+}
+
+// parseDirectives parses the parser directives, the "# key=value" comments at the top of the Dockerfile,
+// and returns the index of the first line following them
+func (p *dockerfileParser) parseDirectives(lines []string) int {
+	for i, line := range lines {
+		trimmed := strings.TrimSpace(line)
+		if !strings.HasPrefix(trimmed, "#") {
+			return i
+		}
+		directive := strings.SplitN(strings.TrimSpace(strings.TrimPrefix(trimmed, "#")), "=", 2)
+		if len(directive) != 2 || strings.ContainsAny(strings.TrimSpace(directive[0]), " \t") {
+			return i
+		}
+		key, value := strings.ToLower(strings.TrimSpace(directive[0])), strings.TrimSpace(directive[1])
+		if _, ok := p.d.Directives[key]; ok {
+			return i
+		}
+		p.d.Directives[key] = value
+		if key == "escape" {
+			if value != "\\" && value != "`" {
+				p.addProblem(i+1, fmt.Sprintf(ErrorInvalidEscapeDirective, value))
+				continue
+			}
+			p.escape = value[0]
+		}
+	}
+	return len(lines)
+}
+
+// addInstruction parses an instruction, its continuation lines joined, and adds it to the Dockerfile
+func (p *dockerfileParser) addInstruction(original string, line int) {
+	text := strings.TrimSpace(original)
+	keyword, rest := text, ""
+	if i := strings.IndexAny(text, " \t"); i >= 0 {
+		keyword, rest = text[:i], strings.TrimSpace(text[i:])
+	}
+	command := strings.ToUpper(keyword)
+
+	instruction := Instruction{Command: command, Flags: make(map[string]string), Original: text, Line: line}
+	if !knownInstructions[command] {
+		p.addProblem(line, fmt.Sprintf(ErrorUnknownInstruction, keyword))
+		return
+	}
+
+	rest = parseFlags(rest, instruction.Flags)
+	instruction.Args, instruction.JSONForm = p.parseArguments(command, rest)
+	p.d.Instructions = append(p.d.Instructions, instruction)
+	p.validateInstruction(instruction)
+}
+
+// parseFlags parses the --name=value flags at the start of the arguments and returns the remaining arguments
+func parseFlags(rest string, flags map[string]string) string {
+	for strings.HasPrefix(rest, "--") {
+		end := strings.IndexAny(rest, " \t")
+		flag := rest
+		if end >= 0 {
+			flag = rest[:end]
+		}
+		nameValue := strings.SplitN(strings.TrimPrefix(flag, "--"), "=", 2)
+		value := ""
+		if len(nameValue) == 2 {
+			value = nameValue[1]
+		}
+		flags[strings.ToLower(nameValue[0])] = value
+		if end < 0 {
+			return ""
+		}
+		rest = strings.TrimSpace(rest[end:])
+	}
+	return rest
+}
+
+// parseArguments returns the arguments of the instruction, and whether they are written as a JSON array.
+// Like docker, arguments which are not a valid JSON array of strings are parsed in the shell form.
+func (p *dockerfileParser) parseArguments(command string, rest string) ([]string, bool) {
+	if rest == "" {
+		return nil, false
+	}
+	if jsonFormInstructions[command] && strings.HasPrefix(rest, "[") {
+		var args []string
+		if err := json.Unmarshal([]byte(rest), &args); err == nil {
+			return args, true
+		}
+	}
+	if shellFormInstructions[command] {
+		return []string{rest}, false
+	}
+	return splitWords(rest, p.escape), false
+}
+
+// splitWords splits the arguments on whitespaces, removing the quotes of the quoted words
+func splitWords(s string, escape byte) []string {
+	var words []string
+	var word strings.Builder
+	inWord := false
+	var quote byte
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case c == escape && quote != '\'' && i+1 < len(s):
+			i++
+			word.WriteByte(s[i])
+			inWord = true
+		case quote != 0:
+			if c == quote {
+				quote = 0
+			} else {
+				word.WriteByte(c)
+			}
+		case c == '"' || c == '\'':
+			quote = c
+			inWord = true
+		case c == ' ' || c == '\t':
+			if inWord {
+				words = append(words, word.String())
+				word.Reset()
+				inWord = false
+			}
+		default:
+			word.WriteByte(c)
+			inWord = true
+		}
+	}
+	if inWord {
+		words = append(words, word.String())
+	}
+	return words
+}
+
+// validateInstruction validates the instruction and adds it to its stage
+func (p *dockerfileParser) validateInstruction(instruction Instruction) {
+	minArgs := 1
+	switch instruction.Command {
+	case InstructionCopy, InstructionAdd:
+		minArgs = 2
+	case InstructionHealthcheck, InstructionCmd, InstructionEntrypoint:
+		// CMD [] and ENTRYPOINT [] reset the command of the base image
+		minArgs = 0
+		if !instruction.JSONForm && instruction.Command != InstructionHealthcheck {
+			minArgs = 1
+		}
+	}
+	if len(instruction.Args) < minArgs {
+		p.addProblem(instruction.Line, fmt.Sprintf(ErrorMissingArguments, instruction.Command, minArgs))
+		return
+	}
+
+	if instruction.Command == InstructionFrom {
+		p.addStage(instruction)
+		return
+	}
+	if len(p.d.Stages) == 0 {
+		if instruction.Command != InstructionArg {
+			p.addProblem(instruction.Line, fmt.Sprintf(ErrorInstructionBeforeFrom, instruction.Command))
+			return
+		}
+		p.validateArgs(instruction)
+		p.d.GlobalArgs = append(p.d.GlobalArgs, parseArgs(instruction)...)
+		return
+	}
+
+	stage := &p.d.Stages[len(p.d.Stages)-1]
+	switch instruction.Command {
+	case InstructionArg:
+		p.validateArgs(instruction)
+	case InstructionShell:
+		if !instruction.JSONForm {
+			p.addProblem(instruction.Line, ErrorInvalidShell)
+		}
+	}
+	stage.Instructions = append(stage.Instructions, instruction)
+}
+
+// addStage validates a FROM instruction and starts a new stage
+func (p *dockerfileParser) addStage(from Instruction) {
+	args := from.Args
+	if (len(args) != 1 && len(args) != 3) || (len(args) == 3 && !strings.EqualFold(args[1], "AS")) {
+		p.addProblem(from.Line, ErrorInvalidFrom)
+		return
+	}
+	for flag := range from.Flags {
+		if flag != "platform" {
+			p.addProblem(from.Line, ErrorInvalidFrom)
+			return
+		}
+	}
+
+	stage := Stage{Index: len(p.d.Stages), BaseImage: args[0], Platform: from.Flags["platform"], From: from}
+	if len(args) == 3 {
+		name := strings.ToLower(args[2])
+		switch line, ok := p.stageLines[name]; {
+		case !stageNameRegexp.MatchString(name):
+			p.addProblem(from.Line, fmt.Sprintf(ErrorInvalidStageName, args[2]))
+		case ok:
+			p.addProblem(from.Line, fmt.Sprintf(ErrorDuplicateStageName, name, line))
+		default:
+			p.stageLines[name] = from.Line
+		}
+		stage.Name = name
+	}
+	p.d.Stages = append(p.d.Stages, stage)
+}
+
+// validateArgs validates the names of the build arguments declared by an ARG instruction
+func (p *dockerfileParser) validateArgs(instruction Instruction) {
+	for _, arg := range parseArgs(instruction) {
+		if !argNameRegexp.MatchString(arg.Name) {
+			p.addProblem(instruction.Line, fmt.Sprintf(ErrorInvalidArgName, arg.Name))
+		}
+	}
+}
+
+// validateCopyFrom validates that the COPY --from flags refer to an image, or to a stage defined before
+// the stage of the instruction. A name which is not the name of a stage refers to an image.
+func (p *dockerfileParser) validateCopyFrom() {
+	for i := range p.d.Stages {
+		stage := &p.d.Stages[i]
+		for _, instruction := range stage.Instructions {
+			from, ok := instruction.Flags["from"]
+			if instruction.Command != InstructionCopy || !ok {
+				continue
+			}
+			index, err := strconv.Atoi(from)
+			if err != nil {
+				source := p.d.GetStage(from)
+				if source == nil && from != "" {
+					continue
+				}
+				index = -1
+				if source != nil {
+					index = source.Index
+				}
+			}
+			if index < 0 || index >= stage.Index {
+				p.addProblem(instruction.Line, fmt.Sprintf(ErrorCopyFromUndefinedStage, from, stageDisplayName(stage)))
+			}
+		}
+	}
+}
+
+// stageDisplayName returns the name of the stage, or its index if it's unnamed
+func stageDisplayName(stage *Stage) string {
+	if stage.Name != "" {
+		return stage.Name
+	}
+	return strconv.Itoa(stage.Index)
+}
+
+// parseArgs returns the build arguments declared by an ARG instruction
+func parseArgs(instruction Instruction) []Arg {
+	var args []Arg
+	for _, arg := range instruction.Args {
+		nameValue := strings.SplitN(arg, "=", 2)
+		a := Arg{Name: nameValue[0]}
+		if len(nameValue) == 2 {
+			a.Value, a.HasDefault = nameValue[1], true
+		}
+		args = append(args, a)
+	}
+	return args
+}
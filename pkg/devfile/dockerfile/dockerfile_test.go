@@ -0,0 +1,150 @@
+package dockerfile
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+
+	const multiStage = `# syntax=docker/dockerfile:1
+# Builds the application then copies it in a runtime image
+ARG NODE_VERSION=14
+FROM --platform=linux/amd64 node:${NODE_VERSION} AS build
+ARG NPM_FLAGS
+WORKDIR /app
+COPY package.json \
+     package-lock.json ./
+RUN npm install $NPM_FLAGS && \
+    # comment within the continuation lines
+    npm run build
+
+FROM registry.access.redhat.com/ubi8/nodejs-14
+LABEL description="runtime image"
+COPY --from=build --chown=1001 /app/dist /opt/app
+COPY --from=0 /app/node_modules /opt/app/node_modules
+COPY --from=quay.io/acme/tools /bin/tool /usr/bin/tool
+ENTRYPOINT node
+ENTRYPOINT ["node", "/opt/app/server.js"]
+`
+
+	t.Run("Multi-stage Dockerfile", func(t *testing.T) {
+		d, err := Parse([]byte(multiStage))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if !reflect.DeepEqual(d.Directives, map[string]string{"syntax": "docker/dockerfile:1"}) {
+			t.Errorf("unexpected directives '%v'", d.Directives)
+		}
+		if len(d.Instructions) != 13 || len(d.Stages) != 2 {
+			t.Fatalf("expected 13 instructions in 2 stages, got %d instructions in %d stages", len(d.Instructions), len(d.Stages))
+		}
+
+		build := d.GetStage("BUILD")
+		if build == nil || build.Index != 0 || build.BaseImage != "node:${NODE_VERSION}" || build.Platform != "linux/amd64" {
+			t.Fatalf("unexpected build stage '%+v'", build)
+		}
+		copyPackage := build.Instructions[2]
+		if copyPackage.Line != 7 || !reflect.DeepEqual(copyPackage.Args, []string{"package.json", "package-lock.json", "./"}) {
+			t.Errorf("unexpected COPY instruction '%+v'", copyPackage)
+		}
+		if run := build.Instructions[3]; len(run.Args) != 1 || !strings.HasSuffix(run.Args[0], "npm run build") {
+			t.Errorf("unexpected RUN instruction '%+v'", run)
+		}
+
+		runtime := d.Stages[1]
+		if copyFrom := runtime.Instructions[1]; copyFrom.Flags["from"] != "build" || copyFrom.Flags["chown"] != "1001" {
+			t.Errorf("unexpected COPY --from instruction '%+v'", copyFrom)
+		}
+		entrypoint := runtime.Entrypoint()
+		if entrypoint == nil || !entrypoint.JSONForm || !reflect.DeepEqual(entrypoint.Args, []string{"node", "/opt/app/server.js"}) {
+			t.Errorf("unexpected entrypoint '%+v'", entrypoint)
+		}
+		if label := runtime.Instructions[0]; !reflect.DeepEqual(label.Args, []string{"description=runtime image"}) {
+			t.Errorf("unexpected LABEL instruction '%+v'", label)
+		}
+
+		want := []Arg{{Name: "NODE_VERSION", Value: "14", HasDefault: true}, {Name: "NPM_FLAGS"}}
+		if args := d.Args(); !reflect.DeepEqual(args, want) {
+			t.Errorf("got args: '%v', want: '%v'", args, want)
+		}
+	})
+
+	tests := []struct {
+		name         string
+		dockerfile   string
+		wantProblems []Problem
+	}{
+		{
+			name:       "Case 1: Escape directive",
+			dockerfile: "# escape=`\nFROM mcr.microsoft.com/windows/servercore\nCOPY testfile.txt `\n  C:\\\nRUN dir C:\\\n",
+		},
+		{
+			name:         "Case 2: Empty Dockerfile",
+			dockerfile:   "# only a comment\n\n",
+			wantProblems: []Problem{{Message: ErrorEmptyDockerfile}},
+		},
+		{
+			name:         "Case 3: No FROM",
+			dockerfile:   "ARG VERSION=1\n",
+			wantProblems: []Problem{{Message: ErrorNoFrom}},
+		},
+		{
+			name:       "Case 4: Instructions before FROM and unknown instruction",
+			dockerfile: "RUN echo\nFROM alpine\nCOPPY . /app\n",
+			wantProblems: []Problem{
+				{Line: 1, Message: fmt.Sprintf(ErrorInstructionBeforeFrom, "RUN")},
+				{Line: 3, Message: fmt.Sprintf(ErrorUnknownInstruction, "COPPY")},
+			},
+		},
+		{
+			name:       "Case 5: Invalid FROM and stage names",
+			dockerfile: "FROM alpine AS base\nFROM alpine base\nFROM alpine AS 1st\nFROM alpine AS Base\nFROM --arch=arm alpine\n",
+			wantProblems: []Problem{
+				{Line: 2, Message: ErrorInvalidFrom},
+				{Line: 3, Message: fmt.Sprintf(ErrorInvalidStageName, "1st")},
+				{Line: 4, Message: fmt.Sprintf(ErrorDuplicateStageName, "base", 1)},
+				{Line: 5, Message: ErrorInvalidFrom},
+			},
+		},
+		{
+			name:       "Case 6: COPY --from a stage not defined before",
+			dockerfile: "FROM alpine AS build\nCOPY --from=build /a /b\nCOPY --from=1 /a /b\nCOPY --from=runtime /a /b\nFROM alpine AS runtime\nCOPY --from=0 /a /b\n",
+			wantProblems: []Problem{
+				{Line: 2, Message: fmt.Sprintf(ErrorCopyFromUndefinedStage, "build", "build")},
+				{Line: 3, Message: fmt.Sprintf(ErrorCopyFromUndefinedStage, "1", "build")},
+				{Line: 4, Message: fmt.Sprintf(ErrorCopyFromUndefinedStage, "runtime", "build")},
+			},
+		},
+		{
+			name:       "Case 7: Missing arguments, invalid ARG and SHELL",
+			dockerfile: "ARG 1VERSION\nFROM alpine\nCOPY app.js\nWORKDIR\nSHELL /bin/bash -c\nCMD []\n",
+			wantProblems: []Problem{
+				{Line: 1, Message: fmt.Sprintf(ErrorInvalidArgName, "1VERSION")},
+				{Line: 3, Message: fmt.Sprintf(ErrorMissingArguments, "COPY", 2)},
+				{Line: 4, Message: fmt.Sprintf(ErrorMissingArguments, "WORKDIR", 1)},
+				{Line: 5, Message: ErrorInvalidShell},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Parse([]byte(tt.dockerfile))
+			var problems []Problem
+			if err != nil {
+				validationErr, ok := err.(*ValidationError)
+				if !ok {
+					t.Fatalf("expected a *ValidationError, got '%v'", err)
+				}
+				problems = validationErr.Problems
+			}
+			if !reflect.DeepEqual(problems, tt.wantProblems) {
+				t.Errorf("got problems: '%v', want: '%v'", problems, tt.wantProblems)
+			}
+		})
+	}
+}
@@ -0,0 +1,251 @@
+package dockerfile
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"path/filepath"
+	"strings"
+
+	"github.com/pkg/errors"
+	"k8s.io/klog"
+
+	"github.com/devfile/parser/pkg/devfile/parser"
+	devfileCtx "github.com/devfile/parser/pkg/devfile/parser/context"
+	"github.com/devfile/parser/pkg/devfile/parser/data/common"
+	"github.com/devfile/parser/pkg/testingutil/filesystem"
+	"github.com/devfile/parser/pkg/util"
+)
+
+// DefaultMaxDockerfileSize is the maximum size in bytes of a downloaded Dockerfile, unless set otherwise
+const DefaultMaxDockerfileSize int64 = 1024 * 1024
+
+// predefinedArgs are the build arguments docker accepts without ARG instruction
+var predefinedArgs = map[string]bool{
+	"HTTP_PROXY": true, "http_proxy": true, "HTTPS_PROXY": true, "https_proxy": true,
+	"FTP_PROXY": true, "ftp_proxy": true, "NO_PROXY": true, "no_proxy": true, "ALL_PROXY": true, "all_proxy": true,
+}
+
+// PlanOptions configures the build plan of a Dockerfile component
+type PlanOptions struct {
+	// HTTPClient downloads the Dockerfile, a client with the default timeout when nil
+	HTTPClient *http.Client
+
+	// MaxSize is the maximum size in bytes of the downloaded Dockerfile, DefaultMaxDockerfileSize when not set
+	MaxSize int64
+
+	// BuildArgs override the default value of the build arguments declared by the Dockerfile
+	BuildArgs map[string]string
+
+	// Target is the stage to build, the last stage when empty
+	Target string
+
+	// Tag is the tag of the built image, the destination of the component when empty
+	Tag string
+}
+
+// BuildPlan describes how to build the image of a Dockerfile component
+type BuildPlan struct {
+	// Component is the name of the Dockerfile component
+	Component string
+
+	// ContextDir is the local directory sent as build context, empty for a remote build context
+	ContextDir string
+
+	// ContextURL is the remote build context, a repository URL followed by #:<directory> when the
+	// sources are in a subdirectory of the repository, empty for a local build context
+	ContextURL string
+
+	// DockerfilePath is the local path of the Dockerfile, empty for a downloaded Dockerfile
+	DockerfilePath string
+
+	// DockerfileURL is the http(s) URL the Dockerfile was downloaded from, empty for a local Dockerfile
+	DockerfileURL string
+
+	// Content is the content of the Dockerfile
+	Content []byte
+
+	// Dockerfile is the parsed Dockerfile
+	Dockerfile *Dockerfile
+
+	// BuildArgs are the build arguments passed to the build, the declared arguments with a default
+	// value or an override
+	BuildArgs map[string]string
+
+	// Target is the name of the stage to build, empty to build the last stage
+	Target string
+
+	// Tag is the tag of the built image
+	Tag string
+}
+
+// NewBuildPlan returns the plan building the image of the Dockerfile component with the given name.
+// The Dockerfile location is a path relative to the devfile, a file:// URI or an http(s) URL. The build
+// context is the source directory within the source location, a local path relative to the devfile or
+// the URL of a remote repository. The Dockerfile is fetched, parsed and validated, the overridden build
+// arguments must be declared by the Dockerfile and the tag must be a valid <registry>/<namespace>/<image> tag.
+func NewBuildPlan(ctx context.Context, d parser.DevfileObj, componentName string, options PlanOptions) (*BuildPlan, error) {
+	var component *common.Dockerfile
+	for _, c := range d.Data.GetComponents() {
+		if c.Dockerfile != nil && c.Dockerfile.Name == componentName {
+			component = c.Dockerfile
+			break
+		}
+	}
+	if component == nil {
+		return nil, fmt.Errorf("dockerfile component '%s' not found", componentName)
+	}
+	if component.DockerfileLocation == "" {
+		return nil, fmt.Errorf("dockerfile component '%s' has no dockerfileLocation", componentName)
+	}
+	if component.Source == nil {
+		return nil, fmt.Errorf("dockerfile component '%s' has no source", componentName)
+	}
+
+	plan := &BuildPlan{Component: componentName, Target: options.Target}
+
+	// Resolve the tag first, to fail before fetching the Dockerfile
+	plan.Tag = options.Tag
+	if plan.Tag == "" {
+		plan.Tag = component.Destination
+	}
+	if plan.Tag == "" {
+		return nil, fmt.Errorf("dockerfile component '%s' has no destination and no tag is provided", componentName)
+	}
+	if err := util.ValidateTag(plan.Tag); err != nil {
+		return nil, err
+	}
+
+	fs := d.Ctx.GetFs()
+	if fs == nil {
+		fs = filesystem.DefaultFs{}
+	}
+	if err := plan.resolveContext(&d.Ctx, fs, component.Source); err != nil {
+		return nil, errors.Wrapf(err, "failed to resolve the build context of dockerfile component '%s'", componentName)
+	}
+
+	location, err := d.Ctx.ResolveURI(component.DockerfileLocation)
+	if err != nil {
+		return nil, err
+	}
+	if devfileCtx.IsURL(location) {
+		plan.DockerfileURL = location
+		plan.Content, err = downloadDockerfile(ctx, options.HTTPClient, location, options.MaxSize)
+	} else {
+		plan.DockerfilePath = location
+		plan.Content, err = fs.ReadFile(location)
+	}
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to fetch the Dockerfile of dockerfile component '%s'", componentName)
+	}
+	klog.V(4).Infof("fetched Dockerfile '%s' of dockerfile component '%s'", location, componentName)
+
+	plan.Dockerfile, err = Parse(plan.Content)
+	if err != nil {
+		return nil, errors.Wrapf(err, "dockerfile component '%s'", componentName)
+	}
+	if plan.Target != "" && plan.Dockerfile.GetStage(plan.Target) == nil {
+		return nil, fmt.Errorf("target stage '%s' is not defined by the Dockerfile of dockerfile component '%s'", plan.Target, componentName)
+	}
+
+	plan.BuildArgs, err = resolveBuildArgs(plan.Dockerfile, options.BuildArgs)
+	if err != nil {
+		return nil, errors.Wrapf(err, "dockerfile component '%s'", componentName)
+	}
+	return plan, nil
+}
+
+// resolveContext resolves the build context, the source directory within the source location.
+// The location defaults to the directory of the devfile.
+func (plan *BuildPlan) resolveContext(ctx *devfileCtx.DevfileCtx, fs filesystem.Filesystem, source *common.Source) error {
+	sourceDir := filepath.ToSlash(filepath.Clean(filepath.FromSlash(source.SourceDir)))
+	if filepath.IsAbs(source.SourceDir) || sourceDir == ".." || strings.HasPrefix(sourceDir, "../") {
+		return fmt.Errorf("source directory '%s' must be a path within the source location", source.SourceDir)
+	}
+
+	location := source.Location
+	if location == "" {
+		location = "."
+	}
+	if !devfileCtx.IsURL(location) {
+		resolved, err := ctx.ResolveURI(location)
+		if err != nil {
+			return err
+		}
+		location = resolved
+	}
+
+	if devfileCtx.IsURL(location) {
+		plan.ContextURL = location
+		if sourceDir != "." {
+			plan.ContextURL = fmt.Sprintf("%s#:%s", location, sourceDir)
+		}
+		return nil
+	}
+
+	plan.ContextDir = filepath.Join(location, filepath.FromSlash(sourceDir))
+	info, err := fs.Stat(plan.ContextDir)
+	if err != nil {
+		return err
+	}
+	if !info.IsDir() {
+		return fmt.Errorf("build context '%s' is not a directory", plan.ContextDir)
+	}
+	return nil
+}
+
+// resolveBuildArgs returns the build arguments of the build, the default value of the declared arguments
+// overridden by the given build arguments
+func resolveBuildArgs(d *Dockerfile, overrides map[string]string) (map[string]string, error) {
+	buildArgs := make(map[string]string)
+	declared := make(map[string]bool)
+	for _, arg := range d.Args() {
+		declared[arg.Name] = true
+		if _, ok := buildArgs[arg.Name]; !ok && arg.HasDefault {
+			buildArgs[arg.Name] = arg.Value
+		}
+	}
+	for name, value := range overrides {
+		if !declared[name] && !predefinedArgs[name] {
+			return nil, fmt.Errorf("build argument '%s' is not declared by the Dockerfile", name)
+		}
+		buildArgs[name] = value
+	}
+	return buildArgs, nil
+}
+
+// downloadDockerfile downloads the Dockerfile at the given http(s) URL, failing if it's larger than maxSize bytes
+func downloadDockerfile(ctx context.Context, client *http.Client, location string, maxSize int64) ([]byte, error) {
+	if client == nil {
+		client = &http.Client{Timeout: util.HTTPRequestTimeout}
+	}
+	if maxSize <= 0 {
+		maxSize = DefaultMaxDockerfileSize
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, location, nil)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid Dockerfile url '%s'", location)
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return nil, fmt.Errorf("failed to download Dockerfile '%s': %s", location, http.StatusText(resp.StatusCode))
+	}
+
+	// Read one more byte than allowed to detect the Dockerfiles exceeding the size
+	content, err := ioutil.ReadAll(io.LimitReader(resp.Body, maxSize+1))
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to download Dockerfile '%s'", location)
+	}
+	if int64(len(content)) > maxSize {
+		return nil, fmt.Errorf("the Dockerfile '%s' exceeds the maximum size of %d bytes", location, maxSize)
+	}
+	return content, nil
+}
@@ -0,0 +1,188 @@
+package dockerfile
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/devfile/parser/pkg/devfile/parser"
+	"github.com/devfile/parser/pkg/testingutil/filesystem"
+)
+
+func TestNewBuildPlan(t *testing.T) {
+
+	const dockerfile = `ARG NODE_VERSION=14
+FROM node:${NODE_VERSION} AS build
+ARG NPM_FLAGS
+RUN npm install $NPM_FLAGS
+FROM node:${NODE_VERSION}-slim AS runtime
+COPY --from=build /app /app
+ENTRYPOINT ["node", "/app/server.js"]
+`
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/acme/Dockerfile" {
+			fmt.Fprint(w, dockerfile)
+			return
+		}
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	fs := filesystem.NewFakeFs()
+	files := map[string]string{
+		"/project/docker/Dockerfile":  dockerfile,
+		"/project/docker/Invalid":     "RUN npm install\n",
+		"/project/src/server.js":      "",
+		"/project/backend/Dockerfile": dockerfile,
+	}
+	for path, content := range files {
+		if err := fs.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatalf("failed to write file: %v", err)
+		}
+	}
+
+	// devfile returns a devfile with a Dockerfile component
+	devfile := func(dockerfileLocation, sourceDir, location, destination string) string {
+		return fmt.Sprintf(`schemaVersion: 2.1.0
+components:
+  - dockerfile:
+      name: image
+      dockerfileLocation: %s
+      source:
+        sourceDir: %s
+        location: %s
+      destination: %s
+`, dockerfileLocation, sourceDir, location, destination)
+	}
+
+	tests := []struct {
+		name    string
+		devfile string
+		options PlanOptions
+		want    BuildPlan
+		wantErr string
+	}{
+		{
+			name:    "Case 1: Relative Dockerfile and local source directory",
+			devfile: devfile("docker/Dockerfile", "src", `""`, "quay.io/acme/nodejs"),
+			options: PlanOptions{BuildArgs: map[string]string{"NPM_FLAGS": "--production", "HTTP_PROXY": "http://proxy"}},
+			want: BuildPlan{
+				ContextDir:     "/project/src",
+				DockerfilePath: "/project/docker/Dockerfile",
+				BuildArgs:      map[string]string{"NODE_VERSION": "14", "NPM_FLAGS": "--production", "HTTP_PROXY": "http://proxy"},
+				Tag:            "quay.io/acme/nodejs",
+			},
+		},
+		{
+			name:    "Case 2: file:// Dockerfile, source location and tag override",
+			devfile: devfile("file:///project/backend/Dockerfile", ".", "backend", "quay.io/acme/nodejs"),
+			options: PlanOptions{Tag: "quay.io/acme/backend", Target: "build"},
+			want: BuildPlan{
+				ContextDir:     "/project/backend",
+				DockerfilePath: "/project/backend/Dockerfile",
+				BuildArgs:      map[string]string{"NODE_VERSION": "14"},
+				Target:         "build",
+				Tag:            "quay.io/acme/backend",
+			},
+		},
+		{
+			name:    "Case 3: Dockerfile url and remote repository",
+			devfile: devfile(server.URL+"/acme/Dockerfile", "services/api", "https://github.com/acme/app.git", "quay.io/acme/nodejs"),
+			want: BuildPlan{
+				ContextURL:    "https://github.com/acme/app.git#:services/api",
+				DockerfileURL: server.URL + "/acme/Dockerfile",
+				BuildArgs:     map[string]string{"NODE_VERSION": "14"},
+				Tag:           "quay.io/acme/nodejs",
+			},
+		},
+		{
+			name:    "Case 4: Invalid tag",
+			devfile: devfile("docker/Dockerfile", "src", `""`, "nodejs"),
+			wantErr: "invalid tag",
+		},
+		{
+			name:    "Case 5: Missing destination",
+			devfile: devfile("docker/Dockerfile", "src", `""`, `""`),
+			wantErr: "dockerfile component 'image' has no destination and no tag is provided",
+		},
+		{
+			name:    "Case 6: Source directory outside of the source location",
+			devfile: devfile("docker/Dockerfile", "../src", `""`, "quay.io/acme/nodejs"),
+			wantErr: "source directory '../src' must be a path within the source location",
+		},
+		{
+			name:    "Case 7: Missing build context",
+			devfile: devfile("docker/Dockerfile", "frontend", `""`, "quay.io/acme/nodejs"),
+			wantErr: "failed to resolve the build context of dockerfile component 'image'",
+		},
+		{
+			name:    "Case 8: Dockerfile not found",
+			devfile: devfile(server.URL+"/missing/Dockerfile", "src", `""`, "quay.io/acme/nodejs"),
+			wantErr: "failed to download Dockerfile",
+		},
+		{
+			name:    "Case 9: Invalid Dockerfile",
+			devfile: devfile("docker/Invalid", "src", `""`, "quay.io/acme/nodejs"),
+			wantErr: "invalid Dockerfile",
+		},
+		{
+			name:    "Case 10: Undeclared build argument",
+			devfile: devfile("docker/Dockerfile", "src", `""`, "quay.io/acme/nodejs"),
+			options: PlanOptions{BuildArgs: map[string]string{"VERSION": "1"}},
+			wantErr: "build argument 'VERSION' is not declared by the Dockerfile",
+		},
+		{
+			name:    "Case 11: Undefined target",
+			devfile: devfile("docker/Dockerfile", "src", `""`, "quay.io/acme/nodejs"),
+			options: PlanOptions{Target: "test"},
+			wantErr: "target stage 'test' is not defined",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := fs.WriteFile("/project/devfile.yaml", []byte(tt.devfile), 0644); err != nil {
+				t.Fatalf("failed to write devfile: %v", err)
+			}
+			d, err := parser.ParseDevfile(context.Background(), parser.ParserArgs{Path: "/project/devfile.yaml", Fs: fs, SkipContainerCheck: true})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			plan, err := NewBuildPlan(context.Background(), d, "image", tt.options)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Errorf("expected error containing '%s', got '%v'", tt.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if string(plan.Content) != dockerfile || plan.Dockerfile == nil || len(plan.Dockerfile.Stages) != 2 {
+				t.Errorf("unexpected Dockerfile '%s'", plan.Content)
+			}
+			tt.want.Component = "image"
+			tt.want.Content, tt.want.Dockerfile = plan.Content, plan.Dockerfile
+			if !reflect.DeepEqual(*plan, tt.want) {
+				t.Errorf("got: '%+v', want: '%+v'", *plan, tt.want)
+			}
+		})
+	}
+
+	t.Run("Missing component", func(t *testing.T) {
+		d, err := parser.ParseDevfile(context.Background(), parser.ParserArgs{Data: []byte(devfile("Dockerfile", "src", `""`, "quay.io/acme/nodejs")), SkipContainerCheck: true})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if _, err := NewBuildPlan(context.Background(), d, "runtime", PlanOptions{}); err == nil || err.Error() != "dockerfile component 'runtime' not found" {
+			t.Errorf("unexpected error '%v'", err)
+		}
+	})
+}
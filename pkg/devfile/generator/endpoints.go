@@ -0,0 +1,259 @@
+package generator
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/devfile/parser/pkg/devfile/parser"
+	"github.com/devfile/parser/pkg/devfile/parser/data/common"
+	"github.com/devfile/parser/pkg/util"
+	networkingv1beta1 "k8s.io/api/networking/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+// Placeholders of the host pattern used to expose endpoints
+const (
+	// HostPatternEndpoint is replaced by the name of the endpoint
+	HostPatternEndpoint = "{endpoint}"
+
+	// HostPatternComponent is replaced by the name of the container component exposing the endpoint
+	HostPatternComponent = "{component}"
+)
+
+// EndpointParams is a struct that contains the required data to expose the public endpoints of a devfile
+// with Ingress or Route objects
+type EndpointParams struct {
+	// ObjectMeta is the base metadata of the objects, their names are suffixed with the endpoint names
+	ObjectMeta metav1.ObjectMeta
+
+	// ServiceName is the name of the Service returned by GetService
+	ServiceName string
+
+	// HostPattern is the host of the exposed endpoints, e.g. "{endpoint}-{component}.apps.example.com".
+	// When empty, Ingresses match any host and Routes get a host generated by OpenShift.
+	HostPattern string
+
+	// TLSSecretName is the secret holding the TLS certificate of the secure Ingresses,
+	// the default certificate of the ingress controller is used when empty
+	TLSSecretName string
+}
+
+// exposedEndpoint is a public HTTP endpoint of a container component
+type exposedEndpoint struct {
+	component string
+	endpoint  common.Endpoint
+}
+
+// GetIngresses returns an Ingress for every public HTTP endpoint of the container components of the devfile
+func GetIngresses(devfileObj parser.DevfileObj, endpointParams EndpointParams) []*networkingv1beta1.Ingress {
+	var ingresses []*networkingv1beta1.Ingress
+	for _, exposed := range getExposedEndpoints(devfileObj) {
+		host := getEndpointHost(endpointParams.HostPattern, exposed)
+
+		ingress := &networkingv1beta1.Ingress{
+			TypeMeta:   GetTypeMeta("Ingress", "networking.k8s.io/v1beta1"),
+			ObjectMeta: getEndpointObjectMeta(endpointParams.ObjectMeta, exposed),
+			Spec: networkingv1beta1.IngressSpec{
+				Rules: []networkingv1beta1.IngressRule{
+					{
+						Host: host,
+						IngressRuleValue: networkingv1beta1.IngressRuleValue{
+							HTTP: &networkingv1beta1.HTTPIngressRuleValue{
+								Paths: []networkingv1beta1.HTTPIngressPath{
+									{
+										Path: getEndpointPath(exposed.endpoint),
+										Backend: networkingv1beta1.IngressBackend{
+											ServiceName: endpointParams.ServiceName,
+											ServicePort: intstr.FromInt(int(exposed.endpoint.TargetPort)),
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		}
+
+		if isSecureEndpoint(exposed.endpoint) {
+			tls := networkingv1beta1.IngressTLS{
+				SecretName: endpointParams.TLSSecretName,
+			}
+			if host != "" {
+				tls.Hosts = []string{host}
+			}
+			ingress.Spec.TLS = []networkingv1beta1.IngressTLS{tls}
+		}
+
+		ingresses = append(ingresses, ingress)
+	}
+	return ingresses
+}
+
+// GetRoutes returns an OpenShift Route for every public HTTP endpoint of the container components of the devfile.
+// The Routes are returned as unstructured objects, OpenShift types not being a dependency of the library.
+func GetRoutes(devfileObj parser.DevfileObj, endpointParams EndpointParams) []*unstructured.Unstructured {
+	var routes []*unstructured.Unstructured
+	for _, exposed := range getExposedEndpoints(devfileObj) {
+		spec := map[string]interface{}{
+			"path": getEndpointPath(exposed.endpoint),
+			"to": map[string]interface{}{
+				"kind":   "Service",
+				"name":   endpointParams.ServiceName,
+				"weight": int64(100),
+			},
+			"port": map[string]interface{}{
+				"targetPort": int64(exposed.endpoint.TargetPort),
+			},
+		}
+		if host := getEndpointHost(endpointParams.HostPattern, exposed); host != "" {
+			spec["host"] = host
+		}
+		if isSecureEndpoint(exposed.endpoint) {
+			spec["tls"] = map[string]interface{}{
+				"termination":                   "edge",
+				"insecureEdgeTerminationPolicy": "Redirect",
+			}
+		}
+
+		route := &unstructured.Unstructured{
+			Object: map[string]interface{}{
+				"spec": spec,
+			},
+		}
+		route.SetAPIVersion("route.openshift.io/v1")
+		route.SetKind("Route")
+		objectMeta := getEndpointObjectMeta(endpointParams.ObjectMeta, exposed)
+		route.SetName(objectMeta.Name)
+		route.SetNamespace(objectMeta.Namespace)
+		route.SetLabels(objectMeta.Labels)
+		route.SetAnnotations(objectMeta.Annotations)
+
+		routes = append(routes, route)
+	}
+	return routes
+}
+
+// getExposedEndpoints returns the public HTTP endpoints of the container components of the devfile
+func getExposedEndpoints(devfileObj parser.DevfileObj) []exposedEndpoint {
+	// The endpoints of devfile 2.2.0 onwards are public when their exposure is omitted
+	publicByDefault := parser.SupportsEndpointExposure(devfileObj.Ctx.GetApiVersion())
+
+	var endpoints []exposedEndpoint
+	for _, comp := range devfileObj.Data.GetComponents() {
+		if comp.Container == nil {
+			continue
+		}
+		for _, endpoint := range comp.Container.Endpoints {
+			if !isPublicEndpoint(endpoint, publicByDefault) || !isHTTPEndpoint(endpoint) {
+				continue
+			}
+			endpoints = append(endpoints, exposedEndpoint{
+				component: comp.Container.Name,
+				endpoint:  endpoint,
+			})
+		}
+	}
+	return endpoints
+}
+
+// isPublicEndpoint returns true if the endpoint is exposed outside of the cluster, as set by its exposure
+// or else by its configuration
+func isPublicEndpoint(endpoint common.Endpoint, publicByDefault bool) bool {
+	if endpoint.Exposure != "" {
+		return endpoint.Exposure == common.PublicEndpointExposure
+	}
+	if endpoint.Configuration != nil {
+		return endpoint.Configuration.Public
+	}
+	return publicByDefault
+}
+
+// isHTTPEndpoint returns true if the endpoint serves HTTP or websocket traffic, as set by its protocol
+// or else by the protocol and scheme of its configuration
+func isHTTPEndpoint(endpoint common.Endpoint) bool {
+	if endpoint.Protocol != "" {
+		switch strings.ToLower(endpoint.Protocol) {
+		case "http", "https", "ws", "wss":
+			return true
+		}
+		return false
+	}
+
+	configuration := endpoint.Configuration
+	if configuration == nil {
+		return true
+	}
+	switch strings.ToLower(configuration.Protocol) {
+	case "", "tcp", "http", "https", "ws", "wss":
+	default:
+		return false
+	}
+	switch strings.ToLower(configuration.Scheme) {
+	case "", "http", "https", "ws", "wss":
+		return true
+	}
+	return false
+}
+
+// isSecureEndpoint returns true if the endpoint must be exposed over TLS
+func isSecureEndpoint(endpoint common.Endpoint) bool {
+	if endpoint.Secure {
+		return true
+	}
+	switch strings.ToLower(endpoint.Protocol) {
+	case "https", "wss":
+		return true
+	}
+
+	configuration := endpoint.Configuration
+	if configuration == nil {
+		return false
+	}
+	switch strings.ToLower(configuration.Scheme) {
+	case "https", "wss":
+		return true
+	}
+	return configuration.Secure
+}
+
+// getEndpointPath returns the path the endpoint is exposed on, its path or else the path of its configuration,
+// "/" if omitted
+func getEndpointPath(endpoint common.Endpoint) string {
+	path := endpoint.Path
+	if path == "" && endpoint.Configuration != nil {
+		path = endpoint.Configuration.Path
+	}
+	if path == "" {
+		return "/"
+	}
+	if !strings.HasPrefix(path, "/") {
+		return "/" + path
+	}
+	return path
+}
+
+// getEndpointHost returns the host the endpoint is exposed on, replacing the placeholders of the host pattern
+func getEndpointHost(hostPattern string, exposed exposedEndpoint) string {
+	if hostPattern == "" {
+		return ""
+	}
+	replacer := strings.NewReplacer(
+		HostPatternEndpoint, util.GetDNS1123Name(strings.ToLower(exposed.endpoint.Name)),
+		HostPatternComponent, util.GetDNS1123Name(strings.ToLower(exposed.component)),
+	)
+	return replacer.Replace(hostPattern)
+}
+
+// getEndpointObjectMeta returns the metadata of the object exposing the endpoint
+func getEndpointObjectMeta(base metav1.ObjectMeta, exposed exposedEndpoint) metav1.ObjectMeta {
+	objectMeta := *base.DeepCopy()
+	name := strings.ToLower(exposed.endpoint.Name)
+	if objectMeta.Name != "" {
+		name = fmt.Sprintf("%s-%s", objectMeta.Name, name)
+	}
+	objectMeta.Name = util.GetDNS1123Name(name)
+	return objectMeta
+}
@@ -0,0 +1,192 @@
+package generator
+
+import (
+	"reflect"
+	"testing"
+
+	networkingv1beta1 "k8s.io/api/networking/v1beta1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+const testEndpointsDevfile = `schemaVersion: 2.0.0
+components:
+  - container:
+      name: runtime
+      image: quay.io/nodejs
+      endpoints:
+        - name: http
+          targetPort: 3000
+          configuration:
+            public: true
+            path: api
+        - name: secure
+          targetPort: 8443
+          configuration:
+            public: true
+            secure: true
+        - name: private
+          targetPort: 8080
+        - name: metrics
+          targetPort: 9090
+          configuration:
+            public: false
+        - name: dns
+          targetPort: 53
+          configuration:
+            public: true
+            protocol: udp
+        - name: db
+          targetPort: 5432
+          configuration:
+            public: true
+            scheme: postgres
+`
+
+const testEndpointsDevfile220 = `schemaVersion: 2.2.0
+components:
+  - name: runtime
+    container:
+      image: quay.io/nodejs
+      endpoints:
+        - name: http
+          targetPort: 3000
+          exposure: public
+          path: api
+        - name: secure
+          targetPort: 8443
+          protocol: https
+        - name: internal
+          targetPort: 8080
+          exposure: internal
+          configuration:
+            public: true
+        - name: debug
+          targetPort: 5858
+          protocol: tcp
+`
+
+func TestGetIngresses(t *testing.T) {
+
+	devfileObj := parseTestDevfile(t, testEndpointsDevfile)
+
+	ingresses := GetIngresses(devfileObj, EndpointParams{
+		ObjectMeta:    GetObjectMeta("nodejs", "ns", nil, nil),
+		ServiceName:   "nodejs",
+		HostPattern:   "{endpoint}-{component}.apps.example.com",
+		TLSSecretName: "tls",
+	})
+	if len(ingresses) != 2 {
+		t.Fatalf("expected 2 ingresses, got %d", len(ingresses))
+	}
+
+	http := ingresses[0]
+	if http.Name != "nodejs-http" || http.Namespace != "ns" {
+		t.Errorf("unexpected ingress metadata: %+v", http.ObjectMeta)
+	}
+	wantRules := []networkingv1beta1.IngressRule{
+		{
+			Host: "http-runtime.apps.example.com",
+			IngressRuleValue: networkingv1beta1.IngressRuleValue{
+				HTTP: &networkingv1beta1.HTTPIngressRuleValue{
+					Paths: []networkingv1beta1.HTTPIngressPath{
+						{
+							Path: "/api",
+							Backend: networkingv1beta1.IngressBackend{
+								ServiceName: "nodejs",
+								ServicePort: intstr.FromInt(3000),
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	if !reflect.DeepEqual(http.Spec.Rules, wantRules) {
+		t.Errorf("got rules: %+v\nwant: %+v", http.Spec.Rules, wantRules)
+	}
+	if http.Spec.TLS != nil {
+		t.Errorf("expected no TLS for an insecure endpoint, got: %+v", http.Spec.TLS)
+	}
+
+	secure := ingresses[1]
+	wantTLS := []networkingv1beta1.IngressTLS{
+		{Hosts: []string{"secure-runtime.apps.example.com"}, SecretName: "tls"},
+	}
+	if !reflect.DeepEqual(secure.Spec.TLS, wantTLS) {
+		t.Errorf("got TLS: %+v, want: %+v", secure.Spec.TLS, wantTLS)
+	}
+}
+
+func TestGetIngressesFromEndpointFields(t *testing.T) {
+
+	devfileObj := parseTestDevfile(t, testEndpointsDevfile220)
+
+	ingresses := GetIngresses(devfileObj, EndpointParams{ServiceName: "nodejs", TLSSecretName: "tls"})
+	if len(ingresses) != 2 {
+		t.Fatalf("expected 2 ingresses, got %d", len(ingresses))
+	}
+
+	http := ingresses[0]
+	if path := http.Spec.Rules[0].HTTP.Paths[0].Path; http.Name != "http" || path != "/api" {
+		t.Errorf("expected ingress 'http' on path '/api', got '%s' on path '%s'", http.Name, path)
+	}
+	if http.Spec.TLS != nil {
+		t.Errorf("expected no TLS for an insecure endpoint, got: %+v", http.Spec.TLS)
+	}
+
+	secure := ingresses[1]
+	wantTLS := []networkingv1beta1.IngressTLS{{SecretName: "tls"}}
+	if secure.Name != "secure" || !reflect.DeepEqual(secure.Spec.TLS, wantTLS) {
+		t.Errorf("expected ingress 'secure' with TLS %+v, got '%s' with TLS %+v", wantTLS, secure.Name, secure.Spec.TLS)
+	}
+}
+
+func TestGetRoutes(t *testing.T) {
+
+	devfileObj := parseTestDevfile(t, testEndpointsDevfile)
+
+	t.Run("Routes with a host pattern", func(t *testing.T) {
+		routes := GetRoutes(devfileObj, EndpointParams{
+			ObjectMeta:  GetObjectMeta("nodejs", "ns", nil, nil),
+			ServiceName: "nodejs",
+			HostPattern: "{endpoint}.apps.example.com",
+		})
+		if len(routes) != 2 {
+			t.Fatalf("expected 2 routes, got %d", len(routes))
+		}
+
+		route := routes[1]
+		if route.GetKind() != "Route" || route.GetAPIVersion() != "route.openshift.io/v1" || route.GetName() != "nodejs-secure" {
+			t.Errorf("unexpected route: %v", route.Object)
+		}
+		wantSpec := map[string]interface{}{
+			"host": "secure.apps.example.com",
+			"path": "/",
+			"to": map[string]interface{}{
+				"kind":   "Service",
+				"name":   "nodejs",
+				"weight": int64(100),
+			},
+			"port": map[string]interface{}{
+				"targetPort": int64(8443),
+			},
+			"tls": map[string]interface{}{
+				"termination":                   "edge",
+				"insecureEdgeTerminationPolicy": "Redirect",
+			},
+		}
+		if !reflect.DeepEqual(route.Object["spec"], wantSpec) {
+			t.Errorf("got spec: %v\nwant: %v", route.Object["spec"], wantSpec)
+		}
+	})
+
+	t.Run("Routes without a host pattern", func(t *testing.T) {
+		routes := GetRoutes(devfileObj, EndpointParams{ServiceName: "nodejs"})
+		for _, route := range routes {
+			spec := route.Object["spec"].(map[string]interface{})
+			if _, ok := spec["host"]; ok {
+				t.Errorf("expected no host, got: %v", spec["host"])
+			}
+		}
+	})
+}
@@ -0,0 +1,255 @@
+package generator
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/devfile/parser/pkg/devfile/parser"
+	"github.com/devfile/parser/pkg/devfile/parser/data/common"
+	"github.com/devfile/parser/pkg/util"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// ProjectsRootEnvVar is the env var holding the path where the project sources are mounted
+	ProjectsRootEnvVar = "PROJECTS_ROOT"
+
+	// DefaultProjectsRoot is the path where the project sources are mounted when the container has no source mapping
+	DefaultProjectsRoot = "/projects"
+
+	// ProjectsVolumeName is the name of the volume holding the project sources
+	ProjectsVolumeName = "projects"
+
+	// DefaultVolumeSize is the size of the PersistentVolumeClaim of a volume component without size
+	DefaultVolumeSize = "1Gi"
+)
+
+// DeploymentParams is a struct that contains the required data to create a Deployment object
+type DeploymentParams struct {
+	ObjectMeta metav1.ObjectMeta
+
+	// PodSelectorLabels are the labels of the pods of the Deployment
+	PodSelectorLabels map[string]string
+
+	// PVCNamePrefix is the prefix of the names of the PersistentVolumeClaims backing the volume components
+	PVCNamePrefix string
+}
+
+// ServiceParams is a struct that contains the required data to create a Service object
+type ServiceParams struct {
+	ObjectMeta metav1.ObjectMeta
+
+	// SelectorLabels are the labels of the pods the Service forwards to
+	SelectorLabels map[string]string
+}
+
+// PVCParams is a struct that contains the required data to create PersistentVolumeClaim objects
+type PVCParams struct {
+	// ObjectMeta is the base metadata of the PersistentVolumeClaims, their names are set from the volume components
+	ObjectMeta metav1.ObjectMeta
+
+	// NamePrefix is the prefix of the names of the PersistentVolumeClaims
+	NamePrefix string
+}
+
+// GetTypeMeta gets a type meta of the specified kind and version
+func GetTypeMeta(kind string, apiVersion string) metav1.TypeMeta {
+	return metav1.TypeMeta{
+		Kind:       kind,
+		APIVersion: apiVersion,
+	}
+}
+
+// GetObjectMeta gets an object meta with the parameters
+func GetObjectMeta(name, namespace string, labels, annotations map[string]string) metav1.ObjectMeta {
+	return metav1.ObjectMeta{
+		Name:        name,
+		Namespace:   namespace,
+		Labels:      labels,
+		Annotations: annotations,
+	}
+}
+
+// GetContainers iterates through the devfile components and returns a slice of the corresponding containers
+func GetContainers(devfileObj parser.DevfileObj) ([]corev1.Container, error) {
+	var containers []corev1.Container
+	for _, comp := range devfileObj.Data.GetComponents() {
+		if comp.Container == nil {
+			continue
+		}
+		container, err := convertContainer(comp.Container)
+		if err != nil {
+			return nil, err
+		}
+		containers = append(containers, container)
+	}
+	return containers, nil
+}
+
+// convertContainer converts a devfile container component to a Kubernetes container
+func convertContainer(devfileContainer *common.Container) (corev1.Container, error) {
+	resources, err := getResourceRequirements(devfileContainer)
+	if err != nil {
+		return corev1.Container{}, err
+	}
+
+	container := corev1.Container{
+		Name:            devfileContainer.Name,
+		Image:           devfileContainer.Image,
+		ImagePullPolicy: corev1.PullAlways,
+		Command:         devfileContainer.Command,
+		Args:            devfileContainer.Args,
+		Env:             convertEnvs(devfileContainer.Env),
+		Ports:           convertPorts(devfileContainer.Endpoints),
+		Resources:       resources,
+	}
+
+	for _, volumeMount := range devfileContainer.VolumeMounts {
+		container.VolumeMounts = append(container.VolumeMounts, corev1.VolumeMount{
+			Name:      util.GetDNS1123Name(strings.ToLower(volumeMount.Name)),
+			MountPath: getVolumeMountPath(volumeMount),
+		})
+	}
+
+	if devfileContainer.GetMountSources() {
+		sourceMapping := getSourceMapping(devfileContainer)
+		container.Env = append(container.Env, corev1.EnvVar{
+			Name:  ProjectsRootEnvVar,
+			Value: sourceMapping,
+		})
+		container.VolumeMounts = append(container.VolumeMounts, corev1.VolumeMount{
+			Name:      ProjectsVolumeName,
+			MountPath: sourceMapping,
+		})
+	}
+
+	return container, nil
+}
+
+// GetDeployment returns a Deployment running the container components of the devfile. The volume components
+// mounted by the containers are backed by the PersistentVolumeClaims returned by GetPVCs, and the project
+// sources are shared between the containers mounting them through an emptyDir volume. An error is returned
+// when a container mounts a volume without volume component.
+func GetDeployment(devfileObj parser.DevfileObj, deployParams DeploymentParams) (*appsv1.Deployment, error) {
+	containers, err := GetContainers(devfileObj)
+	if err != nil {
+		return nil, err
+	}
+
+	var volumes []corev1.Volume
+	volumeNames := make(map[string]bool)
+	mountSources := false
+	for _, comp := range devfileObj.Data.GetComponents() {
+		switch {
+		case comp.Volume != nil:
+			volumeNames[strings.ToLower(comp.Volume.Name)] = true
+			volumes = append(volumes, corev1.Volume{
+				Name: util.GetDNS1123Name(strings.ToLower(comp.Volume.Name)),
+				VolumeSource: corev1.VolumeSource{
+					PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
+						ClaimName: getPVCName(deployParams.PVCNamePrefix, comp.Volume.Name),
+					},
+				},
+			})
+		case comp.Container != nil && comp.Container.GetMountSources():
+			mountSources = true
+		}
+	}
+	for _, comp := range devfileObj.Data.GetComponents() {
+		if comp.Container == nil {
+			continue
+		}
+		for _, volumeMount := range comp.Container.VolumeMounts {
+			if !volumeNames[strings.ToLower(volumeMount.Name)] {
+				return nil, fmt.Errorf("volume '%s' mounted by container '%s' has no volume component, "+
+					"add one or parse the devfile with ImplicitVolumes", volumeMount.Name, comp.Container.Name)
+			}
+		}
+	}
+	if mountSources {
+		volumes = append(volumes, corev1.Volume{
+			Name: ProjectsVolumeName,
+			VolumeSource: corev1.VolumeSource{
+				EmptyDir: &corev1.EmptyDirVolumeSource{},
+			},
+		})
+	}
+
+	podTemplateSpec := getPodTemplateSpec(metav1.ObjectMeta{
+		Name:   deployParams.ObjectMeta.Name,
+		Labels: deployParams.PodSelectorLabels,
+	}, containers, volumes)
+
+	deployment := &appsv1.Deployment{
+		TypeMeta:   GetTypeMeta("Deployment", "apps/v1"),
+		ObjectMeta: deployParams.ObjectMeta,
+		Spec:       getDeploymentSpec(podTemplateSpec, deployParams.PodSelectorLabels),
+	}
+	return deployment, nil
+}
+
+// GetService returns a Service exposing the endpoints of the container components of the devfile,
+// or nil if no container has endpoints
+func GetService(devfileObj parser.DevfileObj, serviceParams ServiceParams) (*corev1.Service, error) {
+	var ports []corev1.ServicePort
+	exposed := make(map[int32]bool)
+	for _, comp := range devfileObj.Data.GetComponents() {
+		if comp.Container == nil {
+			continue
+		}
+		for _, endpoint := range comp.Container.Endpoints {
+			// Containers of a pod share their network, a port can only be exposed once
+			if exposed[endpoint.TargetPort] {
+				continue
+			}
+			exposed[endpoint.TargetPort] = true
+			ports = append(ports, getServicePort(endpoint))
+		}
+	}
+	if len(ports) == 0 {
+		return nil, nil
+	}
+
+	service := &corev1.Service{
+		TypeMeta:   GetTypeMeta("Service", "v1"),
+		ObjectMeta: serviceParams.ObjectMeta,
+		Spec: corev1.ServiceSpec{
+			Ports:    ports,
+			Selector: serviceParams.SelectorLabels,
+		},
+	}
+	return service, nil
+}
+
+// GetPVCs returns a PersistentVolumeClaim for every volume component of the devfile
+func GetPVCs(devfileObj parser.DevfileObj, pvcParams PVCParams) ([]*corev1.PersistentVolumeClaim, error) {
+	var pvcs []*corev1.PersistentVolumeClaim
+	for _, comp := range devfileObj.Data.GetComponents() {
+		if comp.Volume == nil {
+			continue
+		}
+		size, err := getVolumeSize(comp.Volume)
+		if err != nil {
+			return nil, err
+		}
+
+		objectMeta := *pvcParams.ObjectMeta.DeepCopy()
+		objectMeta.Name = getPVCName(pvcParams.NamePrefix, comp.Volume.Name)
+
+		pvcs = append(pvcs, &corev1.PersistentVolumeClaim{
+			TypeMeta:   GetTypeMeta("PersistentVolumeClaim", "v1"),
+			ObjectMeta: objectMeta,
+			Spec: corev1.PersistentVolumeClaimSpec{
+				AccessModes: []corev1.PersistentVolumeAccessMode{corev1.ReadWriteOnce},
+				Resources: corev1.ResourceRequirements{
+					Requests: corev1.ResourceList{
+						corev1.ResourceStorage: size,
+					},
+				},
+			},
+		})
+	}
+	return pvcs, nil
+}
@@ -0,0 +1,228 @@
+package generator
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/devfile/parser/pkg/devfile/parser"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+const testDevfile = `schemaVersion: 2.0.0
+metadata:
+  name: nodejs
+components:
+  - container:
+      name: runtime
+      image: quay.io/nodejs
+      command: ["npm"]
+      args: ["start"]
+      memoryLimit: 512Mi
+      mountSources: true
+      env:
+        - name: FOO
+          value: bar
+      endpoints:
+        - name: http-3000
+          targetPort: 3000
+        - name: debug
+          targetPort: 5858
+          configuration:
+            protocol: udp
+      volumeMounts:
+        - name: cache
+          path: /cache
+        - name: data
+          path: /var/data
+  - container:
+      name: tools
+      image: quay.io/tools
+      sourceMapping: /src
+      endpoints:
+        - name: http
+          targetPort: 3000
+  - volume:
+      name: cache
+  - volume:
+      name: data
+      size: 5Gi
+`
+
+func parseTestDevfile(t *testing.T, content string) parser.DevfileObj {
+	t.Helper()
+	devfileObj, err := parser.ParseInMemoryAndValidate([]byte(content))
+	if err != nil {
+		t.Fatalf("failed to parse test devfile: %v", err)
+	}
+	return devfileObj
+}
+
+func TestGetContainers(t *testing.T) {
+
+	devfileObj := parseTestDevfile(t, testDevfile)
+
+	containers, err := GetContainers(devfileObj)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(containers) != 2 {
+		t.Fatalf("expected 2 containers, got %d", len(containers))
+	}
+
+	runtime := containers[0]
+	want := corev1.Container{
+		Name:            "runtime",
+		Image:           "quay.io/nodejs",
+		ImagePullPolicy: corev1.PullAlways,
+		Command:         []string{"npm"},
+		Args:            []string{"start"},
+		Env: []corev1.EnvVar{
+			{Name: "FOO", Value: "bar"},
+			{Name: ProjectsRootEnvVar, Value: DefaultProjectsRoot},
+		},
+		Ports: []corev1.ContainerPort{
+			{Name: "http-3000", ContainerPort: 3000, Protocol: corev1.ProtocolTCP},
+			{Name: "debug", ContainerPort: 5858, Protocol: corev1.ProtocolUDP},
+		},
+		Resources: corev1.ResourceRequirements{
+			Limits: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("512Mi")},
+		},
+		VolumeMounts: []corev1.VolumeMount{
+			{Name: "cache", MountPath: "/cache"},
+			{Name: "data", MountPath: "/var/data"},
+			{Name: ProjectsVolumeName, MountPath: DefaultProjectsRoot},
+		},
+	}
+	if !reflect.DeepEqual(runtime, want) {
+		t.Errorf("got: %+v\nwant: %+v", runtime, want)
+	}
+
+	tools := containers[1]
+	wantMount := corev1.VolumeMount{Name: ProjectsVolumeName, MountPath: "/src"}
+	if len(tools.VolumeMounts) != 1 || tools.VolumeMounts[0] != wantMount {
+		t.Errorf("got volume mounts: %v, want: %v", tools.VolumeMounts, wantMount)
+	}
+}
+
+func TestGetDeployment(t *testing.T) {
+
+	devfileObj := parseTestDevfile(t, testDevfile)
+	labels := map[string]string{"component": "nodejs"}
+
+	deployment, err := GetDeployment(devfileObj, DeploymentParams{
+		ObjectMeta:        GetObjectMeta("nodejs", "ns", labels, nil),
+		PodSelectorLabels: labels,
+		PVCNamePrefix:     "nodejs",
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if deployment.Name != "nodejs" || deployment.Namespace != "ns" {
+		t.Errorf("unexpected deployment metadata: %+v", deployment.ObjectMeta)
+	}
+	if !reflect.DeepEqual(deployment.Spec.Selector.MatchLabels, labels) || !reflect.DeepEqual(deployment.Spec.Template.Labels, labels) {
+		t.Errorf("unexpected deployment labels: %v, %v", deployment.Spec.Selector.MatchLabels, deployment.Spec.Template.Labels)
+	}
+	if len(deployment.Spec.Template.Spec.Containers) != 2 {
+		t.Errorf("expected 2 containers, got %d", len(deployment.Spec.Template.Spec.Containers))
+	}
+
+	wantVolumes := []corev1.Volume{
+		{Name: "cache", VolumeSource: corev1.VolumeSource{PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{ClaimName: "nodejs-cache"}}},
+		{Name: "data", VolumeSource: corev1.VolumeSource{PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{ClaimName: "nodejs-data"}}},
+		{Name: ProjectsVolumeName, VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{}}},
+	}
+	if !reflect.DeepEqual(deployment.Spec.Template.Spec.Volumes, wantVolumes) {
+		t.Errorf("got volumes: %+v\nwant: %+v", deployment.Spec.Template.Spec.Volumes, wantVolumes)
+	}
+
+	t.Run("volume mounted without volume component", func(t *testing.T) {
+		devfileObj, err := parser.ParseDevfile(context.Background(), parser.ParserArgs{
+			Data:            []byte(strings.Replace(testDevfile, "      name: data\n      size: 5Gi\n", "      name: logs\n", 1)),
+			ValidationLevel: parser.ValidateSchema,
+		})
+		if err != nil {
+			t.Fatalf("failed to parse test devfile: %v", err)
+		}
+		_, err = GetDeployment(devfileObj, DeploymentParams{PodSelectorLabels: labels})
+		if err == nil || !strings.Contains(err.Error(), "volume 'data' mounted by container 'runtime' has no volume component") {
+			t.Errorf("expected an error for the volume without component, got: %v", err)
+		}
+	})
+}
+
+func TestGetService(t *testing.T) {
+
+	t.Run("endpoints are exposed once per port", func(t *testing.T) {
+		devfileObj := parseTestDevfile(t, testDevfile)
+		labels := map[string]string{"component": "nodejs"}
+
+		service, err := GetService(devfileObj, ServiceParams{
+			ObjectMeta:     GetObjectMeta("nodejs", "", nil, nil),
+			SelectorLabels: labels,
+		})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		wantPorts := []corev1.ServicePort{
+			{Name: "http-3000", Port: 3000, TargetPort: intstr.FromInt(3000), Protocol: corev1.ProtocolTCP},
+			{Name: "debug", Port: 5858, TargetPort: intstr.FromInt(5858), Protocol: corev1.ProtocolUDP},
+		}
+		if !reflect.DeepEqual(service.Spec.Ports, wantPorts) {
+			t.Errorf("got ports: %+v\nwant: %+v", service.Spec.Ports, wantPorts)
+		}
+		if !reflect.DeepEqual(service.Spec.Selector, labels) {
+			t.Errorf("got selector: %v, want: %v", service.Spec.Selector, labels)
+		}
+	})
+
+	t.Run("no service without endpoints", func(t *testing.T) {
+		devfileObj := parseTestDevfile(t, `schemaVersion: 2.0.0
+components:
+  - container:
+      name: runtime
+      image: quay.io/nodejs
+`)
+		service, err := GetService(devfileObj, ServiceParams{})
+		if err != nil || service != nil {
+			t.Errorf("expected no service and no error, got: %v, %v", service, err)
+		}
+	})
+}
+
+func TestGetPVCs(t *testing.T) {
+
+	devfileObj := parseTestDevfile(t, testDevfile)
+
+	pvcs, err := GetPVCs(devfileObj, PVCParams{
+		ObjectMeta: GetObjectMeta("", "ns", map[string]string{"component": "nodejs"}, nil),
+		NamePrefix: "nodejs",
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	wantSizes := map[string]string{"nodejs-cache": DefaultVolumeSize, "nodejs-data": "5Gi"}
+	if len(pvcs) != len(wantSizes) {
+		t.Fatalf("expected %d pvcs, got %d", len(wantSizes), len(pvcs))
+	}
+	for _, pvc := range pvcs {
+		wantSize, ok := wantSizes[pvc.Name]
+		if !ok {
+			t.Errorf("unexpected pvc '%s'", pvc.Name)
+			continue
+		}
+		if size := pvc.Spec.Resources.Requests[corev1.ResourceStorage]; size.Cmp(resource.MustParse(wantSize)) != 0 {
+			t.Errorf("pvc '%s' has size %s, want %s", pvc.Name, size.String(), wantSize)
+		}
+		if pvc.Namespace != "ns" || pvc.Labels["component"] != "nodejs" {
+			t.Errorf("unexpected pvc metadata: %+v", pvc.ObjectMeta)
+		}
+	}
+}
@@ -0,0 +1,385 @@
+package generator
+
+import (
+	"bytes"
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/ghodss/yaml"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/klog"
+
+	"github.com/devfile/parser/pkg/devfile/parser"
+	devfileCtx "github.com/devfile/parser/pkg/devfile/parser/context"
+	"github.com/devfile/parser/pkg/devfile/parser/data/common"
+	"github.com/devfile/parser/pkg/util"
+)
+
+const (
+	// ProjectSourceEnvVar is the env var holding the path of the first project sources
+	ProjectSourceEnvVar = "PROJECT_SOURCE"
+
+	// SourceWorkspace is the pipeline workspace the projects are cloned into
+	SourceWorkspace = "source"
+
+	// GitCloneImage is the image of the steps cloning the git projects
+	GitCloneImage = "docker.io/alpine/git:v2.26.2"
+
+	// BuildahImage is the image of the steps building and pushing the images of the Dockerfile components
+	BuildahImage = "quay.io/buildah/buildah:v1.18.0"
+
+	// containersVolumeName is the volume holding the buildah storage
+	containersVolumeName = "varlibcontainers"
+
+	// dockerfileSourcesDir is the directory of the source workspace the git source locations of the Dockerfile components are cloned into
+	dockerfileSourcesDir = ".dockerfile-sources"
+)
+
+// Names of the tasks of the generated pipelines
+const (
+	CloneTaskName = "clone"
+	BuildTaskName = "build"
+	TestTaskName  = "test"
+	ImageTaskName = "image"
+)
+
+// sourceWorkspacePath is the path of the source workspace, substituted by Tekton in the steps
+var sourceWorkspacePath = fmt.Sprintf("$(workspaces.%s.path)", SourceWorkspace)
+
+// PipelineParams is a struct that contains the required data to create a Tekton Pipeline and its Tasks
+type PipelineParams struct {
+	// ObjectMeta is the metadata of the Pipeline, the Tasks are named after it and share its namespace and labels
+	ObjectMeta metav1.ObjectMeta
+}
+
+// GetPipeline returns a Tekton Pipeline and the Tasks it runs in order, sharing the projects through the source
+// workspace: a clone task cloning the git projects, a build then a test task running the exec commands of the
+// build and test groups in the image of their container component, and an image task building the image of every
+// Dockerfile component with buildah and pushing it to its destination. The tasks without steps are omitted.
+func GetPipeline(devfileObj parser.DevfileObj, params PipelineParams) (*Pipeline, []*Task, error) {
+	projects := devfileObj.Data.GetProjects()
+	projectSource := sourceWorkspacePath
+	if len(projects) > 0 {
+		clonePath, err := getClonePath(projects[0])
+		if err != nil {
+			return nil, nil, err
+		}
+		projectSource = path.Join(sourceWorkspacePath, clonePath)
+	}
+
+	cloneSteps, err := getCloneSteps(projects)
+	if err != nil {
+		return nil, nil, err
+	}
+	buildSteps, err := getCommandSteps(devfileObj, common.BuildCommandGroupType, projectSource)
+	if err != nil {
+		return nil, nil, err
+	}
+	testSteps, err := getCommandSteps(devfileObj, common.TestCommandGroupType, projectSource)
+	if err != nil {
+		return nil, nil, err
+	}
+	imageSteps, err := getImageSteps(devfileObj, projectSource)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	pipeline := &Pipeline{
+		TypeMeta:   GetTypeMeta("Pipeline", TektonAPIVersion),
+		ObjectMeta: params.ObjectMeta,
+		Spec: PipelineSpec{
+			Workspaces: []PipelineWorkspaceDeclaration{{Name: SourceWorkspace, Description: "Workspace the projects are cloned into"}},
+		},
+	}
+
+	var tasks []*Task
+	for _, t := range []struct {
+		name  string
+		steps []Step
+	}{
+		{CloneTaskName, cloneSteps},
+		{BuildTaskName, buildSteps},
+		{TestTaskName, testSteps},
+		{ImageTaskName, imageSteps},
+	} {
+		if len(t.steps) == 0 {
+			continue
+		}
+		task := getTask(params.ObjectMeta, t.name, t.steps)
+		pipelineTask := PipelineTask{
+			Name:       t.name,
+			TaskRef:    &TaskRef{Name: task.Name},
+			Workspaces: []WorkspacePipelineTaskBinding{{Name: SourceWorkspace, Workspace: SourceWorkspace}},
+		}
+		// The tasks run one after the other
+		if n := len(pipeline.Spec.Tasks); n > 0 {
+			pipelineTask.RunAfter = []string{pipeline.Spec.Tasks[n-1].Name}
+		}
+		pipeline.Spec.Tasks = append(pipeline.Spec.Tasks, pipelineTask)
+		tasks = append(tasks, task)
+	}
+	if len(tasks) == 0 {
+		return nil, nil, fmt.Errorf("the devfile has no git project, no build or test exec command and no Dockerfile component to run in a pipeline")
+	}
+	return pipeline, tasks, nil
+}
+
+// EncodeYAML returns the objects as a multi-document YAML stream, e.g. to apply them with kubectl
+func EncodeYAML(objects ...interface{}) ([]byte, error) {
+	var b bytes.Buffer
+	for i, object := range objects {
+		content, err := yaml.Marshal(object)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to marshal object into yaml")
+		}
+		if i > 0 {
+			b.WriteString("---\n")
+		}
+		b.Write(content)
+	}
+	return b.Bytes(), nil
+}
+
+// getTask returns the Task of the pipeline with the given name and steps
+func getTask(pipelineMeta metav1.ObjectMeta, name string, steps []Step) *Task {
+	task := &Task{
+		TypeMeta: GetTypeMeta("Task", TektonAPIVersion),
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      util.GetDNS1123Name(strings.ToLower(fmt.Sprintf("%s-%s", pipelineMeta.Name, name))),
+			Namespace: pipelineMeta.Namespace,
+			Labels:    pipelineMeta.Labels,
+		},
+		Spec: TaskSpec{
+			Workspaces: []WorkspaceDeclaration{{Name: SourceWorkspace}},
+			Steps:      steps,
+		},
+	}
+	for _, step := range steps {
+		if len(step.VolumeMounts) > 0 {
+			task.Spec.Volumes = []corev1.Volume{{
+				Name:         containersVolumeName,
+				VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{}},
+			}}
+			break
+		}
+	}
+	return task
+}
+
+// getCloneSteps returns a step cloning every git or GitHub project in its clone path within the source workspace
+func getCloneSteps(projects []common.DevfileProject) ([]Step, error) {
+	var steps []Step
+	for _, project := range projects {
+		git := project.Git
+		if git == nil && project.Github != nil {
+			git = &common.Git{
+				Branch:            project.Github.Branch,
+				CheckoutFrom:      project.Github.CheckoutFrom,
+				Location:          project.Github.Location,
+				Remotes:           project.Github.Remotes,
+				SparseCheckoutDir: project.Github.SparseCheckoutDir,
+				StartPoint:        project.Github.StartPoint,
+			}
+		}
+		if git == nil {
+			klog.V(4).Infof("project '%s' is not cloned by the pipeline, it has no git source", project.Name)
+			continue
+		}
+		clonePath, err := getClonePath(project)
+		if err != nil {
+			return nil, err
+		}
+
+		dir := shellQuote(path.Join(sourceWorkspacePath, clonePath))
+		script := []string{"set -e"}
+		clone := "git clone"
+		if git.SparseCheckoutDir != "" {
+			clone += " --no-checkout"
+		}
+		if git.Branch != "" {
+			clone += " --branch " + shellQuote(git.Branch)
+		}
+		script = append(script, fmt.Sprintf("%s %s %s", clone, shellQuote(git.GetLocation()), dir))
+		if git.SparseCheckoutDir != "" {
+			script = append(script,
+				fmt.Sprintf("git -C %s sparse-checkout init --cone", dir),
+				fmt.Sprintf("git -C %s sparse-checkout set %s", dir, shellQuote(git.SparseCheckoutDir)))
+		}
+		if git.SparseCheckoutDir != "" || git.StartPoint != "" {
+			startPoint := "HEAD"
+			if git.StartPoint != "" {
+				startPoint = git.StartPoint
+			}
+			script = append(script, fmt.Sprintf("git -C %s reset --hard %s", dir, shellQuote(startPoint)))
+		}
+		if git.StartPoint == "" && git.CheckoutFrom != nil && git.CheckoutFrom.Revision != "" {
+			script = append(script, fmt.Sprintf("git -C %s checkout %s", dir, shellQuote(git.CheckoutFrom.Revision)))
+		}
+
+		steps = append(steps, Step{
+			Name:   util.GetDNS1123Name(strings.ToLower("clone-" + project.Name)),
+			Image:  GitCloneImage,
+			Script: strings.Join(script, "\n") + "\n",
+		})
+	}
+	return steps, nil
+}
+
+// getCommandSteps returns a step running every exec command of the group in the image of its container component
+func getCommandSteps(devfileObj parser.DevfileObj, group common.DevfileCommandGroupType, projectSource string) ([]Step, error) {
+	containers := make(map[string]*common.Container)
+	for _, comp := range devfileObj.Data.GetComponents() {
+		if comp.Container != nil {
+			containers[strings.ToLower(comp.Container.Name)] = comp.Container
+		}
+	}
+	pathReplacer := strings.NewReplacer(
+		"${"+ProjectsRootEnvVar+"}", sourceWorkspacePath, "$"+ProjectsRootEnvVar, sourceWorkspacePath,
+		"${"+ProjectSourceEnvVar+"}", projectSource, "$"+ProjectSourceEnvVar, projectSource,
+	)
+
+	var steps []Step
+	for _, command := range devfileObj.Data.GetCommands() {
+		exec := command.Exec
+		if exec == nil || exec.Group == nil || exec.Group.Kind != group {
+			continue
+		}
+		container, ok := containers[strings.ToLower(exec.Component)]
+		if !ok {
+			return nil, fmt.Errorf("command '%s' runs in component '%s' which is not a container component", exec.Id, exec.Component)
+		}
+
+		step := Step{
+			Name:       util.GetDNS1123Name(strings.ToLower(exec.Id)),
+			Image:      container.Image,
+			WorkingDir: projectSource,
+			Env: append([]corev1.EnvVar{
+				{Name: ProjectsRootEnvVar, Value: sourceWorkspacePath},
+				{Name: ProjectSourceEnvVar, Value: projectSource},
+			}, convertEnvs(append(append([]common.Env{}, container.Env...), exec.Env...))...),
+			Script: exec.CommandLine + "\n",
+		}
+		if exec.WorkingDir != "" {
+			step.WorkingDir = pathReplacer.Replace(exec.WorkingDir)
+		}
+		resources, err := getResourceRequirements(container)
+		if err != nil {
+			return nil, err
+		}
+		if len(resources.Limits) > 0 || len(resources.Requests) > 0 {
+			step.Resources = &resources
+		}
+		steps = append(steps, step)
+	}
+	return steps, nil
+}
+
+// getImageSteps returns a step building the image of every Dockerfile component with buildah and pushing it to its destination.
+// The Dockerfile and the source location are relative to the first project, the devfile being expected at its root.
+// A git source location is cloned by a step of its own, its source directory being the build context.
+func getImageSteps(devfileObj parser.DevfileObj, projectSource string) ([]Step, error) {
+	var steps []Step
+	for _, comp := range devfileObj.Data.GetComponents() {
+		dockerfile := comp.Dockerfile
+		if dockerfile == nil {
+			continue
+		}
+		if err := util.ValidateTag(dockerfile.Destination); err != nil {
+			return nil, errors.Wrapf(err, "invalid destination for dockerfile component '%s'", dockerfile.Name)
+		}
+		dockerfilePath, err := getWorkspacePath(projectSource, dockerfile.DockerfileLocation)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid dockerfileLocation for dockerfile component '%s'", dockerfile.Name)
+		}
+		var buildContext string
+		if source := dockerfile.Source; source != nil && isGitURL(source.Location) {
+			dir := path.Join(sourceWorkspacePath, dockerfileSourcesDir, strings.ToLower(dockerfile.Name))
+			steps = append(steps, Step{
+				Name:   util.GetDNS1123Name(strings.ToLower("clone-" + dockerfile.Name)),
+				Image:  GitCloneImage,
+				Script: fmt.Sprintf("set -e\ngit clone %s %s\n", shellQuote(source.Location), shellQuote(dir)),
+			})
+			buildContext, err = getWorkspacePath(dir, source.SourceDir)
+		} else {
+			buildContext, err = getBuildContext(projectSource, source)
+		}
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid source for dockerfile component '%s'", dockerfile.Name)
+		}
+
+		privileged := true
+		destination := shellQuote(dockerfile.Destination)
+		steps = append(steps, Step{
+			Name:  util.GetDNS1123Name(strings.ToLower("build-" + dockerfile.Name)),
+			Image: BuildahImage,
+			VolumeMounts: []corev1.VolumeMount{{
+				Name:      containersVolumeName,
+				MountPath: "/var/lib/containers",
+			}},
+			SecurityContext: &corev1.SecurityContext{Privileged: &privileged},
+			Script: strings.Join([]string{
+				"set -e",
+				fmt.Sprintf("buildah bud --storage-driver=vfs -f %s -t %s %s", shellQuote(dockerfilePath), destination, shellQuote(buildContext)),
+				fmt.Sprintf("buildah push --storage-driver=vfs %s %s", destination, shellQuote("docker://"+dockerfile.Destination)),
+			}, "\n") + "\n",
+		})
+	}
+	return steps, nil
+}
+
+// getBuildContext returns the build context of a Dockerfile component, the source directory within the source location
+func getBuildContext(projectSource string, source *common.Source) (string, error) {
+	if source == nil {
+		return projectSource, nil
+	}
+	if devfileCtx.IsURL(source.Location) {
+		if sourceDir := path.Clean(source.SourceDir); sourceDir != "." {
+			return "", fmt.Errorf("source directory '%s' is not supported with the remote source location '%s'", source.SourceDir, source.Location)
+		}
+		return source.Location, nil
+	}
+	location, err := getWorkspacePath(projectSource, source.Location)
+	if err != nil {
+		return "", err
+	}
+	return getWorkspacePath(location, source.SourceDir)
+}
+
+// isGitURL returns true if the location is the http(s) URL of a git repository
+func isGitURL(location string) bool {
+	return devfileCtx.IsURL(location) && strings.HasSuffix(strings.TrimSuffix(location, "/"), ".git")
+}
+
+// getWorkspacePath returns the location relative to the directory, or the location itself if it's an http(s) URL.
+// The location can't be absolute nor escape the directory.
+func getWorkspacePath(dir string, location string) (string, error) {
+	if devfileCtx.IsURL(location) {
+		return location, nil
+	}
+	cleaned := path.Clean(location)
+	if path.IsAbs(cleaned) || strings.HasPrefix(location, "file://") || cleaned == ".." || strings.HasPrefix(cleaned, "../") {
+		return "", fmt.Errorf("'%s' must be an http(s) URL or a path relative to the project", location)
+	}
+	return path.Join(dir, cleaned), nil
+}
+
+// getClonePath returns the path of the project within the source workspace, its clone path or its name
+func getClonePath(project common.DevfileProject) (string, error) {
+	clonePath := project.ClonePath
+	if clonePath == "" {
+		clonePath = project.Name
+	}
+	cleaned := path.Clean(clonePath)
+	if path.IsAbs(cleaned) || cleaned == ".." || strings.HasPrefix(cleaned, "../") {
+		return "", fmt.Errorf("invalid clone path '%s' for project '%s', it must be relative to the projects root", clonePath, project.Name)
+	}
+	return cleaned, nil
+}
+
+// shellQuote quotes the value for a POSIX shell
+func shellQuote(value string) string {
+	return "'" + strings.Replace(value, "'", `'"'"'`, -1) + "'"
+}
@@ -0,0 +1,301 @@
+package generator
+
+import (
+	"io/ioutil"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/ghodss/yaml"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+
+	"github.com/devfile/parser/pkg/devfile/parser/data/common"
+)
+
+const pipelineDevfile = `schemaVersion: 2.1.0
+metadata:
+  name: nodejs
+projects:
+  - name: app
+    clonePath: src/app
+    git:
+      location: https://github.com/acme/app.git
+      branch: main
+  - name: docs
+    github:
+      location: https://github.com/acme/docs.git
+      sparseCheckoutDir: guides
+      startPoint: v1.0
+  - name: assets
+    zip:
+      location: https://example.com/assets.zip
+components:
+  - container:
+      name: runtime
+      image: quay.io/nodejs
+      memoryLimit: 1Gi
+      env:
+        - name: NODE_ENV
+          value: development
+  - dockerfile:
+      name: image
+      dockerfileLocation: docker/Dockerfile
+      source:
+        sourceDir: src
+        location: ""
+      destination: quay.io/acme/nodejs
+commands:
+  - exec:
+      id: install
+      component: runtime
+      commandLine: npm install
+      group:
+        kind: build
+  - exec:
+      id: run
+      component: runtime
+      commandLine: npm start
+      group:
+        kind: run
+  - exec:
+      id: unit-tests
+      component: runtime
+      commandLine: npm test
+      workingDir: ${PROJECTS_ROOT}/src/app/test
+      env:
+        - name: CI
+          value: "true"
+      group:
+        kind: test
+`
+
+func TestGetPipeline(t *testing.T) {
+
+	devfileObj := parseTestDevfile(t, pipelineDevfile)
+	labels := map[string]string{"app": "nodejs"}
+
+	pipeline, tasks, err := GetPipeline(devfileObj, PipelineParams{ObjectMeta: GetObjectMeta("nodejs", "ci", labels, nil)})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if pipeline.Kind != "Pipeline" || pipeline.APIVersion != TektonAPIVersion || pipeline.Name != "nodejs" {
+		t.Errorf("unexpected pipeline '%+v'", pipeline.ObjectMeta)
+	}
+	wantTasks := []PipelineTask{
+		{Name: CloneTaskName, TaskRef: &TaskRef{Name: "nodejs-clone"}},
+		{Name: BuildTaskName, TaskRef: &TaskRef{Name: "nodejs-build"}, RunAfter: []string{CloneTaskName}},
+		{Name: TestTaskName, TaskRef: &TaskRef{Name: "nodejs-test"}, RunAfter: []string{BuildTaskName}},
+		{Name: ImageTaskName, TaskRef: &TaskRef{Name: "nodejs-image"}, RunAfter: []string{TestTaskName}},
+	}
+	for i := range wantTasks {
+		wantTasks[i].Workspaces = []WorkspacePipelineTaskBinding{{Name: SourceWorkspace, Workspace: SourceWorkspace}}
+	}
+	if !reflect.DeepEqual(pipeline.Spec.Tasks, wantTasks) {
+		t.Errorf("got pipeline tasks: %+v\nwant: %+v", pipeline.Spec.Tasks, wantTasks)
+	}
+
+	if len(tasks) != 4 {
+		t.Fatalf("expected 4 tasks, got %d", len(tasks))
+	}
+	for _, task := range tasks {
+		if task.Kind != "Task" || task.Namespace != "ci" || !reflect.DeepEqual(task.Labels, labels) {
+			t.Errorf("unexpected task '%+v'", task.ObjectMeta)
+		}
+	}
+
+	t.Run("clone task", func(t *testing.T) {
+		steps := tasks[0].Spec.Steps
+		if len(steps) != 2 || steps[0].Name != "clone-app" || steps[1].Image != GitCloneImage {
+			t.Fatalf("unexpected clone steps %+v", steps)
+		}
+		want := "set -e\ngit clone --branch 'main' 'https://github.com/acme/app.git' '$(workspaces.source.path)/src/app'\n"
+		if steps[0].Script != want {
+			t.Errorf("got script:\n%s\nwant:\n%s", steps[0].Script, want)
+		}
+		want = `set -e
+git clone --no-checkout 'https://github.com/acme/docs.git' '$(workspaces.source.path)/docs'
+git -C '$(workspaces.source.path)/docs' sparse-checkout init --cone
+git -C '$(workspaces.source.path)/docs' sparse-checkout set 'guides'
+git -C '$(workspaces.source.path)/docs' reset --hard 'v1.0'
+`
+		if steps[1].Script != want {
+			t.Errorf("got script:\n%s\nwant:\n%s", steps[1].Script, want)
+		}
+	})
+
+	t.Run("clone step of a project checked out from its remotes", func(t *testing.T) {
+		steps, err := getCloneSteps([]common.DevfileProject{{
+			Name: "app",
+			Git: &common.Git{
+				Remotes: map[string]string{
+					"origin":   "https://github.com/acme/app.git",
+					"upstream": "https://github.com/devfile/app.git",
+				},
+				CheckoutFrom: &common.CheckoutFrom{Remote: "upstream", Revision: "v2.0"},
+			},
+		}})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		want := `set -e
+git clone 'https://github.com/devfile/app.git' '$(workspaces.source.path)/app'
+git -C '$(workspaces.source.path)/app' checkout 'v2.0'
+`
+		if len(steps) != 1 || steps[0].Script != want {
+			t.Errorf("got steps %+v, want script:\n%s", steps, want)
+		}
+	})
+
+	t.Run("build and test tasks", func(t *testing.T) {
+		resources := corev1.ResourceRequirements{Limits: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("1Gi")}}
+		wantBuild := []Step{{
+			Name:       "install",
+			Image:      "quay.io/nodejs",
+			WorkingDir: "$(workspaces.source.path)/src/app",
+			Env: []corev1.EnvVar{
+				{Name: ProjectsRootEnvVar, Value: "$(workspaces.source.path)"},
+				{Name: ProjectSourceEnvVar, Value: "$(workspaces.source.path)/src/app"},
+				{Name: "NODE_ENV", Value: "development"},
+			},
+			Resources: &resources,
+			Script:    "npm install\n",
+		}}
+		if !reflect.DeepEqual(tasks[1].Spec.Steps, wantBuild) {
+			t.Errorf("got build steps: %+v\nwant: %+v", tasks[1].Spec.Steps, wantBuild)
+		}
+
+		steps := tasks[2].Spec.Steps
+		if len(steps) != 1 || steps[0].Name != "unit-tests" || steps[0].WorkingDir != "$(workspaces.source.path)/src/app/test" {
+			t.Fatalf("unexpected test steps %+v", steps)
+		}
+		if env := steps[0].Env; len(env) != 4 || env[3] != (corev1.EnvVar{Name: "CI", Value: "true"}) {
+			t.Errorf("unexpected test step env %v", env)
+		}
+	})
+
+	t.Run("image task", func(t *testing.T) {
+		task := tasks[3]
+		if len(task.Spec.Steps) != 1 || len(task.Spec.Volumes) != 1 || task.Spec.Volumes[0].EmptyDir == nil {
+			t.Fatalf("unexpected image task %+v", task.Spec)
+		}
+		step := task.Spec.Steps[0]
+		if step.Image != BuildahImage || step.SecurityContext == nil || !*step.SecurityContext.Privileged {
+			t.Errorf("unexpected buildah step %+v", step)
+		}
+		want := `set -e
+buildah bud --storage-driver=vfs -f '$(workspaces.source.path)/src/app/docker/Dockerfile' -t 'quay.io/acme/nodejs' '$(workspaces.source.path)/src/app/src'
+buildah push --storage-driver=vfs 'quay.io/acme/nodejs' 'docker://quay.io/acme/nodejs'
+`
+		if step.Script != want {
+			t.Errorf("got script:\n%s\nwant:\n%s", step.Script, want)
+		}
+	})
+
+	t.Run("yaml output", func(t *testing.T) {
+		objects := []interface{}{pipeline}
+		for _, task := range tasks {
+			objects = append(objects, task)
+		}
+		content, err := EncodeYAML(objects...)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		documents := strings.Split(string(content), "---\n")
+		if len(documents) != 5 {
+			t.Fatalf("expected 5 YAML documents, got %d", len(documents))
+		}
+		var task Task
+		if err := yaml.Unmarshal([]byte(documents[4]), &task); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if !reflect.DeepEqual(&task, tasks[3]) {
+			t.Errorf("got task: %+v\nwant: %+v", task, tasks[3])
+		}
+	})
+}
+
+func TestGetPipelineSources(t *testing.T) {
+
+	t.Run("command component in another case", func(t *testing.T) {
+		devfileObj := parseTestDevfile(t, strings.Replace(pipelineDevfile, "component: runtime\n      commandLine: npm install", "component: Runtime\n      commandLine: npm install", 1))
+		_, tasks, err := GetPipeline(devfileObj, PipelineParams{ObjectMeta: GetObjectMeta("nodejs", "ci", nil, nil)})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if steps := tasks[1].Spec.Steps; len(steps) != 1 || steps[0].Image != "quay.io/nodejs" {
+			t.Errorf("unexpected build steps %+v", steps)
+		}
+	})
+
+	t.Run("git source location of a Dockerfile component", func(t *testing.T) {
+		devfileObj := parseTestDevfile(t, strings.Replace(pipelineDevfile, `location: ""`, "location: https://github.com/acme/image.git", 1))
+		_, tasks, err := GetPipeline(devfileObj, PipelineParams{ObjectMeta: GetObjectMeta("nodejs", "ci", nil, nil)})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		steps := tasks[3].Spec.Steps
+		if len(steps) != 2 || steps[0].Name != "clone-image" || steps[0].Image != GitCloneImage {
+			t.Fatalf("unexpected image steps %+v", steps)
+		}
+		wantClone := "set -e\ngit clone 'https://github.com/acme/image.git' '$(workspaces.source.path)/.dockerfile-sources/image'\n"
+		if steps[0].Script != wantClone {
+			t.Errorf("got script:\n%s\nwant:\n%s", steps[0].Script, wantClone)
+		}
+		wantBuild := "'$(workspaces.source.path)/.dockerfile-sources/image/src'\n"
+		if !strings.Contains(steps[1].Script, wantBuild) {
+			t.Errorf("expected the build context of the cloned source, got script:\n%s", steps[1].Script)
+		}
+	})
+
+	t.Run("repository devfile", func(t *testing.T) {
+		content, err := ioutil.ReadFile("../../../devfile.yaml")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if _, _, err := GetPipeline(parseTestDevfile(t, string(content)), PipelineParams{ObjectMeta: GetObjectMeta("nodejs", "ci", nil, nil)}); err != nil {
+			t.Errorf("unexpected error: %v", err)
+		}
+	})
+}
+
+func TestGetPipelineErrors(t *testing.T) {
+
+	tests := []struct {
+		name    string
+		replace []string
+		wantErr string
+	}{
+		{
+			name:    "Case 1: Invalid destination",
+			replace: []string{"destination: quay.io/acme/nodejs", "destination: nodejs"},
+			wantErr: "invalid destination for dockerfile component 'image'",
+		},
+		{
+			name:    "Case 2: Dockerfile outside of the project",
+			replace: []string{"dockerfileLocation: docker/Dockerfile", "dockerfileLocation: ../Dockerfile"},
+			wantErr: "'../Dockerfile' must be an http(s) URL or a path relative to the project",
+		},
+		{
+			name:    "Case 3: Source directory of a remote archive source location",
+			replace: []string{`location: ""`, "location: https://example.com/image.tar.gz"},
+			wantErr: "source directory 'src' is not supported with the remote source location",
+		},
+		{
+			name:    "Case 4: Invalid clone path",
+			replace: []string{"clonePath: src/app", "clonePath: ../app"},
+			wantErr: "invalid clone path '../app' for project 'app'",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			devfileObj := parseTestDevfile(t, strings.Replace(pipelineDevfile, tt.replace[0], tt.replace[1], 1))
+			_, _, err := GetPipeline(devfileObj, PipelineParams{ObjectMeta: GetObjectMeta("nodejs", "ci", nil, nil)})
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("expected error containing '%s', got '%v'", tt.wantErr, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,83 @@
+package generator
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// TektonAPIVersion is the API version of the generated Tekton resources
+const TektonAPIVersion = "tekton.dev/v1beta1"
+
+// The Tekton resources below are the subset of the tekton.dev/v1beta1 API used by the generated pipelines
+
+// Pipeline is a Tekton Pipeline running Tasks in order
+type Pipeline struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec PipelineSpec `json:"spec"`
+}
+
+// PipelineSpec defines the Tasks of a Pipeline and the workspaces they share
+type PipelineSpec struct {
+	Workspaces []PipelineWorkspaceDeclaration `json:"workspaces,omitempty"`
+	Tasks      []PipelineTask                 `json:"tasks,omitempty"`
+}
+
+// PipelineWorkspaceDeclaration declares a workspace provided to the PipelineRuns
+type PipelineWorkspaceDeclaration struct {
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+}
+
+// PipelineTask runs a Task in a Pipeline
+type PipelineTask struct {
+	Name       string                         `json:"name"`
+	TaskRef    *TaskRef                       `json:"taskRef,omitempty"`
+	RunAfter   []string                       `json:"runAfter,omitempty"`
+	Workspaces []WorkspacePipelineTaskBinding `json:"workspaces,omitempty"`
+}
+
+// TaskRef references a Task by name
+type TaskRef struct {
+	Name string `json:"name"`
+}
+
+// WorkspacePipelineTaskBinding binds a workspace of a Pipeline to a workspace of a Task
+type WorkspacePipelineTaskBinding struct {
+	Name      string `json:"name"`
+	Workspace string `json:"workspace"`
+}
+
+// Task is a Tekton Task running Steps in order in the same pod
+type Task struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec TaskSpec `json:"spec"`
+}
+
+// TaskSpec defines the Steps of a Task, the workspaces they use and the volumes they mount
+type TaskSpec struct {
+	Workspaces []WorkspaceDeclaration `json:"workspaces,omitempty"`
+	Steps      []Step                 `json:"steps,omitempty"`
+	Volumes    []corev1.Volume        `json:"volumes,omitempty"`
+}
+
+// WorkspaceDeclaration declares a workspace used by a Task
+type WorkspaceDeclaration struct {
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+}
+
+// Step is a container of a Task running a script
+type Step struct {
+	Name            string                       `json:"name"`
+	Image           string                       `json:"image"`
+	WorkingDir      string                       `json:"workingDir,omitempty"`
+	Env             []corev1.EnvVar              `json:"env,omitempty"`
+	Resources       *corev1.ResourceRequirements `json:"resources,omitempty"`
+	VolumeMounts    []corev1.VolumeMount         `json:"volumeMounts,omitempty"`
+	SecurityContext *corev1.SecurityContext      `json:"securityContext,omitempty"`
+	Script          string                       `json:"script"`
+}
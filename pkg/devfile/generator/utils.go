@@ -0,0 +1,160 @@
+package generator
+
+import (
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/devfile/parser/pkg/devfile/parser/data/common"
+	"github.com/devfile/parser/pkg/util"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+// convertEnvs converts the devfile env vars to Kubernetes env vars
+func convertEnvs(vars []common.Env) []corev1.EnvVar {
+	kVars := []corev1.EnvVar{}
+	for _, env := range vars {
+		kVars = append(kVars, corev1.EnvVar{
+			Name:  env.Name,
+			Value: env.Value,
+		})
+	}
+	return kVars
+}
+
+// convertPorts converts the devfile endpoints to Kubernetes container ports
+func convertPorts(endpoints []common.Endpoint) []corev1.ContainerPort {
+	containerPorts := []corev1.ContainerPort{}
+	for _, endpoint := range endpoints {
+		containerPorts = append(containerPorts, corev1.ContainerPort{
+			Name:          util.GetDNS1123Name(strings.ToLower(endpoint.Name)),
+			ContainerPort: endpoint.TargetPort,
+			Protocol:      getPortProtocol(endpoint),
+		})
+	}
+	return containerPorts
+}
+
+// getPortProtocol returns the Kubernetes protocol of the endpoint, TCP unless the endpoint protocol, or the protocol
+// of its configuration in devfile 2.0.0, is UDP or SCTP
+func getPortProtocol(endpoint common.Endpoint) corev1.Protocol {
+	protocol := endpoint.Protocol
+	if protocol == "" && endpoint.Configuration != nil {
+		protocol = endpoint.Configuration.Protocol
+	}
+	switch strings.ToLower(protocol) {
+	case "udp":
+		return corev1.ProtocolUDP
+	case "sctp":
+		return corev1.ProtocolSCTP
+	}
+	return corev1.ProtocolTCP
+}
+
+// getResourceRequirements returns the resource requirements of the container from its cpu and memory limits and requests
+func getResourceRequirements(container *common.Container) (corev1.ResourceRequirements, error) {
+	resources := corev1.ResourceRequirements{}
+	quantities := []struct {
+		description string
+		value       string
+		name        corev1.ResourceName
+		list        *corev1.ResourceList
+	}{
+		{"cpu limit", container.CpuLimit, corev1.ResourceCPU, &resources.Limits},
+		{"memory limit", container.MemoryLimit, corev1.ResourceMemory, &resources.Limits},
+		{"cpu request", container.CpuRequest, corev1.ResourceCPU, &resources.Requests},
+		{"memory request", container.MemoryRequest, corev1.ResourceMemory, &resources.Requests},
+	}
+	for _, q := range quantities {
+		if q.value == "" {
+			continue
+		}
+		quantity, err := resource.ParseQuantity(q.value)
+		if err != nil {
+			return resources, fmt.Errorf("invalid %s '%s' for container '%s': %v", q.description, q.value, container.Name, err)
+		}
+		if *q.list == nil {
+			*q.list = corev1.ResourceList{}
+		}
+		(*q.list)[q.name] = quantity
+	}
+	return resources, nil
+}
+
+// getSourceMapping returns the path where the project sources are mounted in the container
+func getSourceMapping(container *common.Container) string {
+	if container.SourceMapping != "" {
+		return container.SourceMapping
+	}
+	return DefaultProjectsRoot
+}
+
+// getVolumeMountPath returns the path where the volume is mounted, "/<volume name>" if omitted
+func getVolumeMountPath(volumeMount common.VolumeMount) string {
+	if volumeMount.Path != "" {
+		return volumeMount.Path
+	}
+	return path.Join("/", volumeMount.Name)
+}
+
+// getPVCName returns the name of the PersistentVolumeClaim backing the volume component
+func getPVCName(prefix string, volumeName string) string {
+	name := strings.ToLower(volumeName)
+	if prefix != "" {
+		name = fmt.Sprintf("%s-%s", prefix, name)
+	}
+	return util.GetDNS1123Name(name)
+}
+
+// getVolumeSize returns the requested size of the volume, DefaultVolumeSize if omitted
+func getVolumeSize(volume *common.Volume) (resource.Quantity, error) {
+	size := volume.Size
+	if size == "" {
+		size = DefaultVolumeSize
+	}
+	quantity, err := resource.ParseQuantity(size)
+	if err != nil {
+		return quantity, fmt.Errorf("invalid size '%s' for volume '%s': %v", volume.Size, volume.Name, err)
+	}
+	return quantity, nil
+}
+
+// getPodTemplateSpec returns the pod template running the containers with the given volumes
+func getPodTemplateSpec(objectMeta metav1.ObjectMeta, containers []corev1.Container, volumes []corev1.Volume) corev1.PodTemplateSpec {
+	return corev1.PodTemplateSpec{
+		ObjectMeta: objectMeta,
+		Spec: corev1.PodSpec{
+			Containers: containers,
+			Volumes:    volumes,
+		},
+	}
+}
+
+// getDeploymentSpec returns the spec of a Deployment running a single replica of the pod template
+func getDeploymentSpec(podTemplateSpec corev1.PodTemplateSpec, podSelectorLabels map[string]string) appsv1.DeploymentSpec {
+	replicas := int32(1)
+	return appsv1.DeploymentSpec{
+		Replicas: &replicas,
+		Strategy: appsv1.DeploymentStrategy{
+			Type: appsv1.RecreateDeploymentStrategyType,
+		},
+		Selector: &metav1.LabelSelector{
+			MatchLabels: podSelectorLabels,
+		},
+		Template: podTemplateSpec,
+	}
+}
+
+// getServicePort returns the Service port forwarding to the endpoint
+func getServicePort(endpoint common.Endpoint) corev1.ServicePort {
+	return corev1.ServicePort{
+		Name:       util.GetDNS1123Name(strings.ToLower(endpoint.Name)),
+		Port:       endpoint.TargetPort,
+		TargetPort: intstr.FromInt(int(endpoint.TargetPort)),
+		Protocol:   getPortProtocol(endpoint),
+	}
+}
@@ -0,0 +1,126 @@
+package generator
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/devfile/parser/pkg/devfile/parser/data/common"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+func TestGetVolumeMountPath(t *testing.T) {
+
+	tests := []struct {
+		name        string
+		volumeMount common.VolumeMount
+		want        string
+	}{
+		{
+			name:        "Path set",
+			volumeMount: common.VolumeMount{Name: "cache", Path: "/var/cache"},
+			want:        "/var/cache",
+		},
+		{
+			name:        "Path omitted",
+			volumeMount: common.VolumeMount{Name: "cache"},
+			want:        "/cache",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := getVolumeMountPath(tt.volumeMount); got != tt.want {
+				t.Errorf("got: '%s', want: '%s'", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGetPortProtocol(t *testing.T) {
+
+	tests := []struct {
+		name     string
+		endpoint common.Endpoint
+		want     corev1.Protocol
+	}{
+		{
+			name:     "Case 1: No protocol",
+			endpoint: common.Endpoint{Name: "http", TargetPort: 3000},
+			want:     corev1.ProtocolTCP,
+		},
+		{
+			name:     "Case 2: Devfile 2.2.0 endpoint protocol",
+			endpoint: common.Endpoint{Name: "dns", TargetPort: 53, Protocol: "udp"},
+			want:     corev1.ProtocolUDP,
+		},
+		{
+			name:     "Case 3: Devfile 2.0.0 configuration protocol",
+			endpoint: common.Endpoint{Name: "dns", TargetPort: 53, Configuration: &common.Configuration{Protocol: "UDP"}},
+			want:     corev1.ProtocolUDP,
+		},
+		{
+			name:     "Case 4: Application protocol",
+			endpoint: common.Endpoint{Name: "http", TargetPort: 3000, Protocol: "https"},
+			want:     corev1.ProtocolTCP,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := getPortProtocol(tt.endpoint); got != tt.want {
+				t.Errorf("got: '%s', want: '%s'", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGetResourceRequirements(t *testing.T) {
+
+	t.Run("No memory limit", func(t *testing.T) {
+		resources, err := getResourceRequirements(&common.Container{Name: "runtime"})
+		if err != nil || resources.Limits != nil {
+			t.Errorf("expected no limits and no error, got: %v, %v", resources.Limits, err)
+		}
+	})
+
+	t.Run("Invalid memory limit", func(t *testing.T) {
+		_, err := getResourceRequirements(&common.Container{Name: "runtime", MemoryLimit: "lots"})
+		if err == nil {
+			t.Errorf("expected an error, didn't get one")
+		}
+	})
+
+	t.Run("Cpu and memory limits and requests", func(t *testing.T) {
+		resources, err := getResourceRequirements(&common.Container{
+			Name:          "runtime",
+			CpuLimit:      "500m",
+			CpuRequest:    "100m",
+			MemoryLimit:   "512Mi",
+			MemoryRequest: "256Mi",
+		})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		want := corev1.ResourceRequirements{
+			Limits: corev1.ResourceList{
+				corev1.ResourceCPU:    resource.MustParse("500m"),
+				corev1.ResourceMemory: resource.MustParse("512Mi"),
+			},
+			Requests: corev1.ResourceList{
+				corev1.ResourceCPU:    resource.MustParse("100m"),
+				corev1.ResourceMemory: resource.MustParse("256Mi"),
+			},
+		}
+		if !reflect.DeepEqual(resources, want) {
+			t.Errorf("got: %v, want: %v", resources, want)
+		}
+	})
+
+	t.Run("Invalid cpu request", func(t *testing.T) {
+		_, err := getResourceRequirements(&common.Container{Name: "runtime", CpuRequest: "fast"})
+		if err == nil {
+			t.Errorf("expected an error, didn't get one")
+		}
+	})
+}
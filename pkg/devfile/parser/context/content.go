@@ -63,11 +63,16 @@ func (d *DevfileCtx) SetDevfileContent() error {
 func (d *DevfileCtx) SetDevfileContentFromBytes(data []byte) error {
 	// If YAML file convert it to JSON
 	var err error
+	d.source = data
+	d.isJSON = hasJSONPrefix(data)
 	d.rawContent, err = YAMLToJSON(data)
 	if err != nil {
 		return err
 	}
 
+	// Keep track of the source positions for error reporting
+	d.setPositions(data)
+
 	// Successful
 	return nil
 }
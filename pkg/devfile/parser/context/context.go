@@ -1,8 +1,11 @@
 package parser
 
 import (
+	"context"
 	"fmt"
+	"net/http"
 
+	"github.com/devfile/parser/pkg/devfile/validate"
 	"github.com/devfile/parser/pkg/testingutil/filesystem"
 	"github.com/devfile/parser/pkg/util"
 	"k8s.io/klog"
@@ -23,19 +26,41 @@ type DevfileCtx struct {
 	// raw content of the devfile
 	rawContent []byte
 
+	// content of the devfile as provided, before its conversion to JSON
+	source []byte
+
+	// true if the devfile was provided in JSON rather than in YAML
+	isJSON bool
+
 	// devfile json schema
 	jsonSchema string
 
+	// positions of the devfile elements in the source, indexed by JSON pointer
+	positions map[string]validate.Position
+
+	// http(s) URL the devfile is downloaded from
+	url string
+
+	// HTTP client downloading the devfile
+	httpClient *http.Client
+
+	// maximum size in bytes of the downloaded devfile
+	maxSize int64
+
 	// filesystem for devfile
 	Fs filesystem.Filesystem
 }
 
-// NewDevfileCtx returns a new DevfileCtx type object
+// NewDevfileCtx returns a new DevfileCtx type object for the devfile at the given path or http(s) URL
 func NewDevfileCtx(path string) DevfileCtx {
-	return DevfileCtx{
+	d := DevfileCtx{
 		relPath: path,
 		Fs:      filesystem.DefaultFs{},
 	}
+	if IsURL(path) {
+		d.url = path
+	}
+	return d
 }
 
 // populateDevfile checks the API version is supported and returns the JSON schema for the given devfile API Version
@@ -58,6 +83,9 @@ func (d *DevfileCtx) populateDevfile() (err error) {
 
 // Populate fills the DevfileCtx struct with relevant context info
 func (d *DevfileCtx) Populate() (err error) {
+	if d.url != "" {
+		return d.PopulateWithContext(context.Background())
+	}
 
 	// Get devfile absolute path
 	if d.absPath, err = util.GetAbsPath(d.relPath); err != nil {
@@ -88,3 +116,23 @@ func (d *DevfileCtx) Validate() error {
 	// Validate devfile
 	return d.ValidateDevfileSchema()
 }
+
+// GetAbsPath returns the absolute path of the devfile
+func (d *DevfileCtx) GetAbsPath() string {
+	return d.absPath
+}
+
+// SetAbsPath sets the absolute path of the devfile, e.g. for a devfile populated from bytes
+func (d *DevfileCtx) SetAbsPath(absPath string) {
+	d.absPath = absPath
+}
+
+// IsJSON returns true if the devfile was provided in JSON rather than in YAML
+func (d *DevfileCtx) IsJSON() bool {
+	return d.isJSON
+}
+
+// GetSource returns the content of the devfile as provided, before its conversion to JSON
+func (d *DevfileCtx) GetSource() []byte {
+	return d.source
+}
@@ -0,0 +1,54 @@
+package parser
+
+import (
+	"github.com/devfile/parser/pkg/devfile/validate"
+	"gopkg.in/yaml.v3"
+	"k8s.io/klog"
+)
+
+// setPositions maps the JSON pointer of every element of the devfile source to its line and column.
+// JSON being a subset of YAML, both formats are handled.
+func (d *DevfileCtx) setPositions(data []byte) {
+	d.positions = make(map[string]validate.Position)
+
+	var root yaml.Node
+	if err := yaml.Unmarshal(data, &root); err != nil {
+		klog.V(4).Infof("failed to compute devfile source positions: %v", err)
+		return
+	}
+	if root.Kind == yaml.DocumentNode && len(root.Content) > 0 {
+		addPositions(d.positions, "", root.Content[0])
+	}
+}
+
+// addPositions records the position of the node and of its descendants. The members of a mapping
+// are positioned on their key, e.g. /components/0/container/image points at "image:".
+func addPositions(positions map[string]validate.Position, path string, node *yaml.Node) {
+	if _, ok := positions[path]; !ok {
+		positions[path] = validate.Position{Line: node.Line, Column: node.Column}
+	}
+
+	switch node.Kind {
+	case yaml.MappingNode:
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			key, value := node.Content[i], node.Content[i+1]
+			childPath := path + validate.JSONPointer(key.Value)
+			positions[childPath] = validate.Position{Line: key.Line, Column: key.Column}
+			addPositions(positions, childPath, value)
+		}
+	case yaml.SequenceNode:
+		for i, item := range node.Content {
+			addPositions(positions, path+validate.JSONPointer(i), item)
+		}
+	case yaml.AliasNode:
+		if node.Alias != nil {
+			addPositions(positions, path, node.Alias)
+		}
+	}
+}
+
+// GetPosition returns the position in the devfile source of the element at the given JSON pointer
+func (d *DevfileCtx) GetPosition(path string) (validate.Position, bool) {
+	position, ok := d.positions[path]
+	return position, ok
+}
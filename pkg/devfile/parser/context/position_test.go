@@ -0,0 +1,85 @@
+package parser
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/devfile/parser/pkg/devfile/validate"
+)
+
+func TestSetPositions(t *testing.T) {
+
+	yamlContent := []byte(`schemaVersion: 2.0.0
+metadata:
+  name: nodejs
+components:
+  - container:
+      name: runtime
+      image: "quay.io/nodejs"
+  - volume:
+      name: "a/b"
+`)
+
+	jsonContent := []byte(`{
+  "schemaVersion": "2.0.0",
+  "components": [
+    {"container": {"name": "runtime"}}
+  ]
+}`)
+
+	tests := []struct {
+		name    string
+		content []byte
+		path    string
+		want    validate.Position
+		wantOk  bool
+	}{
+		{
+			name:    "YAML root",
+			content: yamlContent,
+			path:    "",
+			want:    validate.Position{Line: 1, Column: 1},
+			wantOk:  true,
+		},
+		{
+			name:    "YAML mapping member",
+			content: yamlContent,
+			path:    "/components/0/container/image",
+			want:    validate.Position{Line: 7, Column: 7},
+			wantOk:  true,
+		},
+		{
+			name:    "YAML sequence item",
+			content: yamlContent,
+			path:    "/components/1",
+			want:    validate.Position{Line: 8, Column: 5},
+			wantOk:  true,
+		},
+		{
+			name:    "JSON mapping member",
+			content: jsonContent,
+			path:    "/components/0/container/name",
+			want:    validate.Position{Line: 4, Column: 20},
+			wantOk:  true,
+		},
+		{
+			name:    "Missing element",
+			content: yamlContent,
+			path:    "/components/0/container/memoryLimit",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var d DevfileCtx
+			if err := d.SetDevfileContentFromBytes(tt.content); err != nil {
+				t.Fatalf("unexpected error: '%v'", err)
+			}
+
+			got, ok := d.GetPosition(tt.path)
+			if ok != tt.wantOk || !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got: '%v' (%t), want: '%v' (%t)", got, ok, tt.want, tt.wantOk)
+			}
+		})
+	}
+}
@@ -1,9 +1,10 @@
 package parser
 
 import (
-	"fmt"
+	"strings"
 
 	"github.com/devfile/parser/pkg/devfile/parser/data"
+	"github.com/devfile/parser/pkg/devfile/validate"
 	"github.com/pkg/errors"
 	"github.com/xeipuuv/gojsonschema"
 	"k8s.io/klog"
@@ -21,8 +22,19 @@ func (d *DevfileCtx) SetDevfileJSONSchema() error {
 	return nil
 }
 
-// ValidateDevfileSchema validate JSON schema of the provided devfile
+// ValidateDevfileSchema validate JSON schema of the provided devfile.
+// The returned error is a *validate.ValidationResult listing all the schema violations.
 func (d *DevfileCtx) ValidateDevfileSchema() error {
+	result, err := d.GetSchemaValidationResult()
+	if err != nil {
+		return err
+	}
+	return result.ToError()
+}
+
+// GetSchemaValidationResult validates the provided devfile against its JSON schema
+// and returns a report of all the schema violations
+func (d *DevfileCtx) GetSchemaValidationResult() (*validate.ValidationResult, error) {
 
 	var (
 		schemaLoader   = gojsonschema.NewStringLoader(d.jsonSchema)
@@ -30,20 +42,38 @@ func (d *DevfileCtx) ValidateDevfileSchema() error {
 	)
 
 	// Validate devfile with JSON schema
-	result, err := gojsonschema.Validate(schemaLoader, documentLoader)
+	schemaResult, err := gojsonschema.Validate(schemaLoader, documentLoader)
 	if err != nil {
-		return errors.Wrapf(err, "failed to validate devfile schema")
+		return nil, errors.Wrapf(err, "failed to validate devfile schema")
 	}
 
-	if !result.Valid() {
-		errMsg := fmt.Sprintf("invalid devfile schema. errors :\n")
-		for _, desc := range result.Errors() {
-			errMsg = errMsg + fmt.Sprintf("- %s\n", desc)
-		}
-		return fmt.Errorf(errMsg)
+	result := validate.NewValidationResult()
+	for _, desc := range schemaResult.Errors() {
+		result.AddError(getSchemaErrorPath(desc), validate.CodeSchemaPrefix+desc.Type(), desc.Description())
 	}
+	result.AttachPositions(d.GetPosition)
 
-	// Sucessful
-	klog.V(4).Info("validated devfile schema")
-	return nil
+	if !result.HasErrors() {
+		// Sucessful
+		klog.V(4).Info("validated devfile schema")
+	}
+	return result, nil
+}
+
+// getSchemaErrorPath returns the JSON pointer to the element a schema violation is about.
+// Missing required properties are reported on the property itself rather than on its parent.
+func getSchemaErrorPath(desc gojsonschema.ResultError) string {
+	var tokens []interface{}
+	for _, token := range strings.Split(desc.Context().String("/"), "/") {
+		if token != gojsonschema.STRING_CONTEXT_ROOT {
+			tokens = append(tokens, token)
+		}
+	}
+	if property, ok := desc.Details()["property"].(string); ok && desc.Type() == "required" {
+		tokens = append(tokens, property)
+	}
+	if len(tokens) == 0 {
+		return ""
+	}
+	return validate.JSONPointer(tokens...)
 }
@@ -1,9 +1,12 @@
 package parser
 
 import (
+	"reflect"
 	"testing"
 
 	v100 "github.com/devfile/parser/pkg/devfile/parser/data/1.0.0"
+	v200 "github.com/devfile/parser/pkg/devfile/parser/data/2.0.0"
+	"github.com/devfile/parser/pkg/devfile/validate"
 )
 
 const (
@@ -41,6 +44,31 @@ func TestValidateDevfileSchema(t *testing.T) {
 			t.Errorf("expected error, didn't get one")
 		}
 	})
+
+	t.Run("schema violations are reported with their path", func(t *testing.T) {
+
+		var (
+			d = DevfileCtx{
+				jsonSchema: v200.JsonSchema200,
+				rawContent: []byte(`{"schemaVersion":"2.0.0","components":[{"container":{"name":"runtime"}}]}`),
+			}
+		)
+
+		result, err := d.GetSchemaValidationResult()
+		if err != nil {
+			t.Fatalf("unexpected error: '%v'", err)
+		}
+
+		want := validate.ValidationError{
+			Path:     "/components/0/container/image",
+			Code:     validate.CodeSchemaPrefix + "required",
+			Severity: validate.SeverityError,
+			Message:  "image is required",
+		}
+		if len(result.Errors) != 1 || !reflect.DeepEqual(result.Errors[0], want) {
+			t.Errorf("got: '%v', want: '%v'", result.Errors, want)
+		}
+	})
 }
 
 func validJsonRawContent100() []byte {
@@ -0,0 +1,139 @@
+package parser
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"path/filepath"
+	"strings"
+
+	"github.com/pkg/errors"
+	"k8s.io/klog"
+
+	"github.com/devfile/parser/pkg/util"
+)
+
+// DefaultMaxDevfileSize is the maximum size in bytes of a downloaded devfile, unless set otherwise
+const DefaultMaxDevfileSize int64 = 10 * 1024 * 1024
+
+// IsURL returns true if the location is an http(s) URL
+func IsURL(location string) bool {
+	lower := strings.ToLower(location)
+	return strings.HasPrefix(lower, "http://") || strings.HasPrefix(lower, "https://")
+}
+
+// ResolveLocation resolves a uri referenced in a devfile against the location of that devfile,
+// an absolute path or an http(s) URL. A uri is resolved against the working directory without base.
+func ResolveLocation(base string, uri string) (string, error) {
+	if IsURL(uri) {
+		return uri, nil
+	}
+	uri = strings.TrimPrefix(uri, "file://")
+
+	if IsURL(base) {
+		baseURL, err := url.Parse(base)
+		if err != nil {
+			return "", errors.Wrapf(err, "invalid devfile url '%s'", base)
+		}
+		ref, err := url.Parse(filepath.ToSlash(uri))
+		if err != nil {
+			return "", errors.Wrapf(err, "invalid uri '%s'", uri)
+		}
+		return baseURL.ResolveReference(ref).String(), nil
+	}
+
+	if filepath.IsAbs(uri) || base == "" {
+		return util.GetAbsPath(uri)
+	}
+	return filepath.Join(filepath.Dir(base), uri), nil
+}
+
+// DownloadDevfile downloads the devfile at the given http(s) URL with the HTTP client, a client with
+// the default timeout when nil. The download is cancelled with the context and fails if the devfile
+// is larger than maxSize bytes, DefaultMaxDevfileSize when not positive.
+func DownloadDevfile(ctx context.Context, client *http.Client, location string, maxSize int64) ([]byte, error) {
+	if client == nil {
+		client = &http.Client{Timeout: util.HTTPRequestTimeout}
+	}
+	if maxSize <= 0 {
+		maxSize = DefaultMaxDevfileSize
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, location, nil)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid devfile url '%s'", location)
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return nil, fmt.Errorf("failed to download devfile '%s': %s", location, http.StatusText(resp.StatusCode))
+	}
+	if resp.ContentLength > maxSize {
+		return nil, fmt.Errorf("devfile '%s' exceeds the maximum size of %d bytes", location, maxSize)
+	}
+
+	// Read one more byte than allowed to detect the devfiles without content length exceeding the size
+	content, err := ioutil.ReadAll(io.LimitReader(resp.Body, maxSize+1))
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to download devfile '%s'", location)
+	}
+	if int64(len(content)) > maxSize {
+		return nil, fmt.Errorf("devfile '%s' exceeds the maximum size of %d bytes", location, maxSize)
+	}
+	return content, nil
+}
+
+// PopulateWithContext fills the DevfileCtx struct with relevant context info, the download of
+// a devfile referenced by URL being cancelled with the context
+func (d *DevfileCtx) PopulateWithContext(ctx context.Context) error {
+	if d.url == "" {
+		return d.Populate()
+	}
+
+	content, err := DownloadDevfile(ctx, d.httpClient, d.url, d.maxSize)
+	if err != nil {
+		return err
+	}
+	klog.V(4).Infof("downloaded devfile from '%s'", d.url)
+
+	if err := d.SetDevfileContentFromBytes(content); err != nil {
+		return err
+	}
+	return d.populateDevfile()
+}
+
+// GetURL returns the http(s) URL the devfile was downloaded from, empty for a local devfile
+func (d *DevfileCtx) GetURL() string {
+	return d.url
+}
+
+// GetOrigin returns where the devfile comes from, its URL or its absolute path. It's empty for a devfile populated from bytes.
+func (d *DevfileCtx) GetOrigin() string {
+	if d.url != "" {
+		return d.url
+	}
+	return d.absPath
+}
+
+// SetHTTPClient sets the HTTP client downloading the devfile, e.g. to configure its timeout
+func (d *DevfileCtx) SetHTTPClient(client *http.Client) {
+	d.httpClient = client
+}
+
+// SetMaxSize sets the maximum size in bytes of the downloaded devfile
+func (d *DevfileCtx) SetMaxSize(maxSize int64) {
+	d.maxSize = maxSize
+}
+
+// ResolveURI resolves a uri referenced in the devfile, e.g. the uri of a kubernetes component or the
+// location of a Dockerfile, against the origin of the devfile
+func (d *DevfileCtx) ResolveURI(uri string) (string, error) {
+	return ResolveLocation(d.GetOrigin(), uri)
+}
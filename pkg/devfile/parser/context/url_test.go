@@ -0,0 +1,133 @@
+package parser
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestResolveLocation(t *testing.T) {
+
+	tests := []struct {
+		name string
+		base string
+		uri  string
+		want string
+	}{
+		{
+			name: "relative path",
+			base: "/stacks/nodejs/devfile.yaml",
+			uri:  "../parent/devfile.yaml",
+			want: "/stacks/parent/devfile.yaml",
+		},
+		{
+			name: "file uri",
+			base: "/stacks/nodejs/devfile.yaml",
+			uri:  "file:///parent/devfile.yaml",
+			want: "/parent/devfile.yaml",
+		},
+		{
+			name: "relative to url",
+			base: "https://example.com/stacks/nodejs/devfile.yaml",
+			uri:  "../parent/devfile.yaml",
+			want: "https://example.com/stacks/parent/devfile.yaml",
+		},
+		{
+			name: "absolute url",
+			base: "/stacks/nodejs/devfile.yaml",
+			uri:  "https://example.com/devfile.yaml",
+			want: "https://example.com/devfile.yaml",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ResolveLocation(tt.base, tt.uri)
+			if err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("want: '%s', got: '%s'", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestPopulateFromURL(t *testing.T) {
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/stacks/nodejs/devfile.yaml":
+			fmt.Fprint(w, "schemaVersion: 2.1.0\nmetadata:\n  name: nodejs\n")
+		case "/stacks/large/devfile.yaml":
+			fmt.Fprint(w, "schemaVersion: 2.1.0\nmetadata:\n  name: "+strings.Repeat("a", 1024)+"\n")
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	tests := []struct {
+		name    string
+		url     string
+		maxSize int64
+		wantErr string
+	}{
+		{
+			name: "Case 1: Devfile URL",
+			url:  server.URL + "/stacks/nodejs/devfile.yaml",
+		},
+		{
+			name:    "Case 2: Devfile not found",
+			url:     server.URL + "/stacks/missing/devfile.yaml",
+			wantErr: "Not Found",
+		},
+		{
+			name:    "Case 3: Devfile exceeding the maximum size",
+			url:     server.URL + "/stacks/large/devfile.yaml",
+			maxSize: 512,
+			wantErr: "exceeds the maximum size of 512 bytes",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := NewDevfileCtx(tt.url)
+			d.SetHTTPClient(server.Client())
+			d.SetMaxSize(tt.maxSize)
+			err := d.PopulateWithContext(context.Background())
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("expected error containing '%s', got %v", tt.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if d.GetApiVersion() != "2.1.0" {
+				t.Errorf("expected apiVersion '2.1.0', got '%s'", d.GetApiVersion())
+			}
+			if d.GetURL() != tt.url || d.GetOrigin() != tt.url {
+				t.Errorf("expected the origin '%s', got url '%s' and origin '%s'", tt.url, d.GetURL(), d.GetOrigin())
+			}
+			got, err := d.ResolveURI("Dockerfile")
+			if want := server.URL + "/stacks/nodejs/Dockerfile"; err != nil || got != want {
+				t.Errorf("expected Dockerfile location '%s', got '%s' (%v)", want, got, err)
+			}
+		})
+	}
+
+	t.Run("cancelled context", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		d := NewDevfileCtx(server.URL + "/stacks/nodejs/devfile.yaml")
+		if err := d.PopulateWithContext(ctx); err == nil {
+			t.Errorf("expected the download to be cancelled")
+		}
+	})
+}
@@ -0,0 +1,653 @@
+package parser
+
+import (
+	"encoding/json"
+	"fmt"
+	"path"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/ghodss/yaml"
+	"github.com/pkg/errors"
+	"k8s.io/klog"
+
+	devfileCtx "github.com/devfile/parser/pkg/devfile/parser/context"
+	"github.com/devfile/parser/pkg/devfile/parser/data"
+	v100 "github.com/devfile/parser/pkg/devfile/parser/data/1.0.0"
+	"github.com/devfile/parser/pkg/devfile/parser/data/common"
+	"github.com/devfile/parser/pkg/devfile/validate"
+	"github.com/devfile/parser/pkg/util"
+)
+
+// Conversion warning codes
+const (
+	// CodeConversionDroppedField is reported for a field which has no equivalent in the target version
+	CodeConversionDroppedField = "conversion_dropped_field"
+
+	// CodeConversionDroppedElement is reported for a component or command which has no equivalent in the target version
+	CodeConversionDroppedElement = "conversion_dropped_element"
+
+	// CodeConversionChangedValue is reported for a value which was rewritten to keep its meaning in the target version
+	CodeConversionChangedValue = "conversion_changed_value"
+)
+
+// v1ProjectsRootEnvVar is the devfile 1.0.0 env var holding the path of the projects, PROJECTS_ROOT in 2.x
+const v1ProjectsRootEnvVar = "CHE_PROJECTS_ROOT"
+
+// Convert converts the devfile to the target schema version. Every element of the devfile which can't be
+// converted as is is reported as a warning, positioned in the devfile source when the devfile data was
+// neither edited nor flattened. The converted devfile is validated against the schema of the target version.
+func Convert(d DevfileObj, targetVersion string) (DevfileObj, *validate.ValidationResult, error) {
+	warnings := validate.NewValidationResult()
+
+	sourceVersion := d.Ctx.GetApiVersion()
+	content, inSource, err := getConversionContent(d)
+	if err != nil {
+		return DevfileObj{}, nil, err
+	}
+
+	// Patch versions are converted as the registered version of their minor version
+	source, _ := data.ResolveVersion(sourceVersion)
+	target, ok := data.ResolveVersion(targetVersion)
+	if !ok {
+		return DevfileObj{}, nil, fmt.Errorf("devfile version '%s' is not supported", targetVersion)
+	}
+
+	switch {
+	case source == target:
+		if _, ok := content["schemaVersion"]; ok {
+			content["schemaVersion"] = targetVersion
+		}
+	case source == "1.0.0" && (target == "2.0.0" || target == "2.1.0"):
+		content = convertV100(content, targetVersion, warnings)
+	case source == "2.0.0" && target == "2.1.0":
+		upgradeV200(content, targetVersion)
+	case source == "2.1.0" && target == "2.0.0":
+		downgradeV210(content, targetVersion, warnings)
+	default:
+		converter := data.GetConverter(sourceVersion)
+		if converter == nil {
+			converter = data.GetConverter(targetVersion)
+		}
+		if converter == nil {
+			return DevfileObj{}, nil, fmt.Errorf("conversion of devfile from version '%s' to '%s' is not supported", sourceVersion, targetVersion)
+		}
+		if content, err = converter(content, sourceVersion, targetVersion, warnings); err != nil {
+			return DevfileObj{}, nil, errors.Wrapf(err, "failed to convert devfile from version '%s' to '%s'", sourceVersion, targetVersion)
+		}
+	}
+	if inSource {
+		warnings.AttachPositions(d.Ctx.GetPosition)
+	}
+
+	converted, err := newConvertedDevfile(d, content)
+	if err != nil {
+		return DevfileObj{}, warnings, err
+	}
+
+	// Successful
+	klog.V(4).Infof("converted devfile from version '%s' to '%s' with %d warnings", sourceVersion, targetVersion, len(warnings.Errors))
+	return converted, warnings, nil
+}
+
+// getConversionContent returns the JSON content of the devfile to convert, and whether this content is
+// the one of the devfile source. Devfile 1.0.0 content is read from the source, the data of 1.0.0 devfiles
+// being converted to the 2.x model.
+func getConversionContent(d DevfileObj) (content map[string]interface{}, inSource bool, err error) {
+	if d.Data == nil || d.Ctx.GetApiVersion() == "1.0.0" {
+		if err := json.Unmarshal(d.Ctx.GetDevfileContent(), &content); err != nil {
+			return nil, false, errors.Wrapf(err, "failed to decode devfile content")
+		}
+		return content, true, nil
+	}
+
+	if content, err = toJSONMap(d.Data); err != nil {
+		return nil, false, err
+	}
+
+	// The data is in the source if it's the data decoded from the source
+	source := DevfileObj{Ctx: d.Ctx}
+	if err := setDevfileData(&source, d.Ctx.GetDevfileContent()); err != nil {
+		return content, false, nil
+	}
+	sourceContent, err := toJSONMap(source.Data)
+	return content, err == nil && reflect.DeepEqual(sourceContent, content), nil
+}
+
+// newConvertedDevfile returns the devfile with the converted content, in the format of the original devfile
+func newConvertedDevfile(d DevfileObj, content map[string]interface{}) (converted DevfileObj, err error) {
+	data, err := json.Marshal(content)
+	if err != nil {
+		return converted, errors.Wrapf(err, "failed to encode converted devfile")
+	}
+	if !d.Ctx.IsJSON() {
+		if data, err = yaml.JSONToYAML(data); err != nil {
+			return converted, errors.Wrapf(err, "failed to encode converted devfile")
+		}
+	}
+
+	converted.Ctx = devfileCtx.NewDevfileCtx(d.Ctx.GetAbsPath())
+	converted.Ctx.Fs = d.Ctx.GetFs()
+	converted.Ctx.SetAbsPath(d.Ctx.GetAbsPath())
+	if err = converted.Ctx.PopulateFromBytes(data); err != nil {
+		return converted, err
+	}
+
+	// Validate the converted devfile against the schema of its version
+	if err = converted.Ctx.Validate(); err != nil {
+		return converted, errors.Wrapf(err, "converted devfile is invalid")
+	}
+	if err = setDevfileData(&converted, converted.Ctx.GetDevfileContent()); err != nil {
+		return converted, err
+	}
+	return converted, nil
+}
+
+// v100Converter converts the content of a devfile 1.0.0 into the content of a devfile 2.x
+type v100Converter struct {
+	targetVersion string
+	warnings      *validate.ValidationResult
+
+	// names of the 2.x components by alias of the 1.0.0 components
+	componentNames map[string]string
+
+	// names of the 2.x components, volumes included
+	usedNames map[string]bool
+
+	// index of the converted containers by name
+	containers map[string]map[string]interface{}
+}
+
+// convertV100 converts the content of a devfile 1.0.0 into the content of a devfile of the target 2.x version
+func convertV100(content map[string]interface{}, targetVersion string, warnings *validate.ValidationResult) map[string]interface{} {
+	c := &v100Converter{
+		targetVersion:  targetVersion,
+		warnings:       warnings,
+		componentNames: make(map[string]string),
+		usedNames:      make(map[string]bool),
+		containers:     make(map[string]map[string]interface{}),
+	}
+
+	converted := map[string]interface{}{
+		"schemaVersion": targetVersion,
+	}
+	for _, key := range sortedKeys(content) {
+		value := content[key]
+		switch key {
+		case "apiVersion":
+		case "metadata":
+			if metadata := c.convertMetadata(value); len(metadata) > 0 {
+				converted["metadata"] = metadata
+			}
+		case "projects":
+			if projects := c.convertProjects(value); len(projects) > 0 {
+				converted["projects"] = projects
+			}
+		case "components", "commands":
+			// converted below, commands referencing components
+		default:
+			c.dropField(validate.JSONPointer(key), key)
+		}
+	}
+
+	if components := c.convertComponents(content["components"]); len(components) > 0 {
+		converted["components"] = components
+	}
+	if commands := c.convertCommands(content["commands"]); len(commands) > 0 {
+		converted["commands"] = commands
+	}
+	return converted
+}
+
+// dropField reports a field which has no equivalent in the target version
+func (c *v100Converter) dropField(path string, field string) {
+	c.warnings.AddWarning(path, CodeConversionDroppedField, fmt.Sprintf("field '%s' has no equivalent in devfile %s and was dropped", field, c.targetVersion))
+}
+
+// convertMetadata converts the metadata, using the generate name as name if the name is missing
+func (c *v100Converter) convertMetadata(value interface{}) map[string]interface{} {
+	metadata, _ := value.(map[string]interface{})
+	converted := make(map[string]interface{})
+	for _, key := range sortedKeys(metadata) {
+		switch key {
+		case "name":
+			converted["name"] = metadata[key]
+		case "generateName":
+			if name, _ := metadata["name"].(string); name == "" {
+				generateName, _ := metadata[key].(string)
+				converted["name"] = strings.TrimRight(generateName, "-")
+				c.warnings.AddWarning(validate.JSONPointer("metadata", key), CodeConversionChangedValue, fmt.Sprintf("generateName '%s' was used as the devfile name", generateName))
+				continue
+			}
+			c.dropField(validate.JSONPointer("metadata", key), key)
+		default:
+			c.dropField(validate.JSONPointer("metadata", key), key)
+		}
+	}
+	return converted
+}
+
+// convertProjects converts the projects, turning their source into a git, github or zip member
+func (c *v100Converter) convertProjects(value interface{}) []interface{} {
+	projects, _ := toItemList(value)
+	var converted []interface{}
+	for i, project := range projects {
+		convertedProject := make(map[string]interface{})
+		for _, key := range sortedKeys(project) {
+			switch key {
+			case "name", "clonePath":
+				convertedProject[key] = project[key]
+			case "source":
+				source, _ := project[key].(map[string]interface{})
+				sourceType, _ := source["type"].(string)
+				convertedProject[sourceType] = c.convertProjectSource(validate.JSONPointer("projects", i, key), source)
+			default:
+				c.dropField(validate.JSONPointer("projects", i, key), key)
+			}
+		}
+		converted = append(converted, convertedProject)
+	}
+	return converted
+}
+
+// convertProjectSource converts the source of a project. The tag or commit id to check out become the start point.
+func (c *v100Converter) convertProjectSource(sourcePath string, source map[string]interface{}) map[string]interface{} {
+	sourceType, _ := source["type"].(string)
+	converted := make(map[string]interface{})
+	for _, key := range sortedKeys(source) {
+		fieldPath := sourcePath + validate.JSONPointer(key)
+		switch key {
+		case "type":
+		case "location", "sparseCheckoutDir":
+			converted[key] = source[key]
+		case "branch", "startPoint":
+			if sourceType == string(v100.ProjectTypeZip) {
+				c.dropField(fieldPath, key)
+				continue
+			}
+			converted[key] = source[key]
+		case "tag", "commitId":
+			if _, ok := source["startPoint"]; ok || sourceType == string(v100.ProjectTypeZip) {
+				c.dropField(fieldPath, key)
+				continue
+			}
+			if _, ok := converted["startPoint"]; ok {
+				c.dropField(fieldPath, key)
+				continue
+			}
+			converted["startPoint"] = source[key]
+		default:
+			c.dropField(fieldPath, key)
+		}
+	}
+	return converted
+}
+
+// convertComponents converts the components. Editors and plugins have no equivalent in devfile 2.x,
+// and the volumes of the containers become volume components.
+func (c *v100Converter) convertComponents(value interface{}) []interface{} {
+	components, _ := toItemList(value)
+
+	// Reserve the names of the aliased components first
+	for _, component := range components {
+		if alias, _ := component["alias"].(string); alias != "" {
+			c.usedNames[strings.ToLower(alias)] = true
+		}
+	}
+
+	var converted, volumes []interface{}
+	for i, component := range components {
+		componentPath := validate.JSONPointer("components", i)
+		componentType, _ := component["type"].(string)
+
+		switch v100.ComponentType(componentType) {
+		case v100.DevfileComponentTypeDockerimage:
+			container, containerVolumes := c.convertDockerimage(componentPath, component)
+			converted = append(converted, map[string]interface{}{"container": container})
+			volumes = append(volumes, containerVolumes...)
+		case v100.DevfileComponentTypeKubernetes, v100.DevfileComponentTypeOpenshift:
+			converted = append(converted, map[string]interface{}{componentType: c.convertKubernetes(componentPath, component)})
+		default:
+			id, _ := component["id"].(string)
+			c.warnings.AddWarning(componentPath, CodeConversionDroppedElement, fmt.Sprintf("%s component '%s' has no equivalent in devfile %s and was dropped", componentType, id, c.targetVersion))
+		}
+	}
+	return append(converted, volumes...)
+}
+
+// getComponentName returns the name of the converted component, generated from the given
+// candidate if the component has no alias
+func (c *v100Converter) getComponentName(componentPath string, component map[string]interface{}, candidate string) string {
+	if alias, _ := component["alias"].(string); alias != "" {
+		c.componentNames[alias] = alias
+		return alias
+	}
+
+	base := toDNS1123Name(candidate)
+	if base == "" {
+		base = "component"
+	}
+	name := base
+	for i := 1; c.usedNames[name]; i++ {
+		name = fmt.Sprintf("%s-%d", base, i)
+	}
+	c.usedNames[name] = true
+	c.warnings.AddWarning(componentPath, CodeConversionChangedValue, fmt.Sprintf("component has no alias and was named '%s'", name))
+	return name
+}
+
+// convertDockerimage converts a dockerimage component into a container component, and returns
+// the volume components mounted by the container
+func (c *v100Converter) convertDockerimage(componentPath string, component map[string]interface{}) (map[string]interface{}, []interface{}) {
+	image, _ := component["image"].(string)
+	imageName := path.Base(image)
+	if i := strings.IndexAny(imageName, ":@"); i >= 0 {
+		imageName = imageName[:i]
+	}
+
+	container := map[string]interface{}{
+		"name": c.getComponentName(componentPath, component, imageName),
+	}
+	c.containers[container["name"].(string)] = container
+
+	var volumes []interface{}
+	for _, key := range sortedKeys(component) {
+		fieldPath := componentPath + validate.JSONPointer(key)
+		switch key {
+		case "alias", "type":
+		case "image", "memoryLimit", "mountSources", "command", "args":
+			container[key] = component[key]
+		case "env":
+			env, _ := toItemList(component[key])
+			container[key] = fromItemList(env)
+		case "endpoints":
+			container[key] = c.convertEndpoints(fieldPath, component[key])
+		case "volumes":
+			mounts, _ := toItemList(component[key])
+			var volumeMounts []interface{}
+			for _, mount := range mounts {
+				name, _ := mount["name"].(string)
+				volumeMounts = append(volumeMounts, map[string]interface{}{
+					"name": name,
+					"path": mount["containerPath"],
+				})
+				if !c.usedNames[strings.ToLower(name)] {
+					c.usedNames[strings.ToLower(name)] = true
+					volumes = append(volumes, map[string]interface{}{"volume": map[string]interface{}{"name": name}})
+				}
+			}
+			container["volumeMounts"] = volumeMounts
+		default:
+			c.dropField(fieldPath, key)
+		}
+	}
+	return container, volumes
+}
+
+// convertEndpoints converts the endpoints of a dockerimage component. The well-known attributes become
+// the endpoint configuration, endpoints being public by default in devfile 1.0.0.
+func (c *v100Converter) convertEndpoints(endpointsPath string, value interface{}) []interface{} {
+	endpoints, _ := toItemList(value)
+	var converted []interface{}
+	for i, endpoint := range endpoints {
+		configuration := map[string]interface{}{
+			"public": true,
+		}
+		convertedEndpoint := map[string]interface{}{
+			"name":          endpoint["name"],
+			"targetPort":    endpoint["port"],
+			"configuration": configuration,
+		}
+
+		attributes, _ := endpoint["attributes"].(map[string]interface{})
+		otherAttributes := make(map[string]interface{})
+		for _, key := range sortedKeys(attributes) {
+			value := fmt.Sprintf("%v", attributes[key])
+			switch key {
+			case "public", "secure", "discoverable", "cookiesAuthEnabled":
+				b, err := strconv.ParseBool(value)
+				if err != nil {
+					c.warnings.AddWarning(fmt.Sprintf("%s/%d/attributes/%s", endpointsPath, i, key), CodeConversionDroppedField, fmt.Sprintf("endpoint attribute '%s' is not a boolean and was dropped", key))
+					continue
+				}
+				configuration[key] = b
+			case "protocol":
+				// The protocol of devfile 1.0.0 endpoints is the scheme of their URL
+				configuration["scheme"] = value
+			case "path":
+				configuration[key] = value
+			case "type":
+				switch value {
+				case "ide", "terminal", "ide-dev":
+					configuration[key] = value
+				default:
+					otherAttributes[key] = value
+				}
+			default:
+				otherAttributes[key] = value
+			}
+		}
+		if len(otherAttributes) > 0 {
+			convertedEndpoint["attributes"] = otherAttributes
+		}
+		converted = append(converted, convertedEndpoint)
+	}
+	return converted
+}
+
+// convertKubernetes converts a kubernetes or openshift component, referencing its manifest by uri or inlining it
+func (c *v100Converter) convertKubernetes(componentPath string, component map[string]interface{}) map[string]interface{} {
+	reference, _ := component["reference"].(string)
+	candidate := strings.TrimSuffix(path.Base(reference), path.Ext(reference))
+	if reference == "" {
+		candidate, _ = component["type"].(string)
+	}
+
+	converted := map[string]interface{}{
+		"name": c.getComponentName(componentPath, component, candidate),
+	}
+	for _, key := range sortedKeys(component) {
+		switch key {
+		case "alias", "type":
+		case "reference":
+			converted["uri"] = component[key]
+		case "referenceContent":
+			converted["inlined"] = component[key]
+		default:
+			c.dropField(componentPath+validate.JSONPointer(key), key)
+		}
+	}
+	return converted
+}
+
+// convertCommands converts the commands. Command names become ids, the preview url of a command
+// becomes the path of the matching public endpoint.
+func (c *v100Converter) convertCommands(value interface{}) []interface{} {
+	commands, _ := toItemList(value)
+	usedIds := make(map[string]bool)
+
+	var converted []interface{}
+	for i, command := range commands {
+		commandPath := validate.JSONPointer("commands", i)
+		name, _ := command["name"].(string)
+
+		base := toDNS1123Name(name)
+		id := base
+		for j := 1; usedIds[id]; j++ {
+			id = fmt.Sprintf("%s-%d", base, j)
+		}
+		usedIds[id] = true
+
+		actions, _ := toItemList(command["actions"])
+		if len(actions) == 0 {
+			c.warnings.AddWarning(commandPath, CodeConversionDroppedElement, fmt.Sprintf("command '%s' has no action and was dropped", name))
+			continue
+		}
+		for j := range actions[1:] {
+			c.warnings.AddWarning(commandPath+validate.JSONPointer("actions", j+1), CodeConversionDroppedElement, fmt.Sprintf("command '%s' has more than one action, only the first one was converted", name))
+		}
+
+		kind, convertedCommand := c.convertAction(commandPath+validate.JSONPointer("actions", 0), actions[0])
+		if convertedCommand == nil {
+			continue
+		}
+		convertedCommand["id"] = id
+		if id != name {
+			convertedCommand["label"] = name
+		}
+		if group := v100.GetGroup(strings.ToLower(name)); group != nil {
+			// devfile 2.x has no init group, init commands being bound to events
+			if group.Kind == common.InitCommandGroupType {
+				c.warnings.AddWarning(commandPath+validate.JSONPointer("name"), CodeConversionDroppedField, fmt.Sprintf("command '%s' is not part of a group in devfile %s, bind it to the postStart event instead", name, c.targetVersion))
+			} else {
+				convertedCommand["group"] = map[string]interface{}{"kind": string(group.Kind), "isDefault": group.IsDefault}
+			}
+		}
+
+		for _, key := range sortedKeys(command) {
+			switch key {
+			case "name", "actions":
+			case "attributes":
+				convertedCommand[key] = command[key]
+			case "previewUrl":
+				c.convertPreviewUrl(commandPath+validate.JSONPointer(key), command[key], convertedCommand["component"])
+			default:
+				c.dropField(commandPath+validate.JSONPointer(key), key)
+			}
+		}
+		converted = append(converted, map[string]interface{}{kind: convertedCommand})
+	}
+	return converted
+}
+
+// convertAction converts the action of a command into an exec, vscodeTask or vscodeLaunch command
+func (c *v100Converter) convertAction(actionPath string, action map[string]interface{}) (string, map[string]interface{}) {
+	actionType, _ := action["type"].(string)
+	converted := make(map[string]interface{})
+
+	var kind string
+	switch actionType {
+	case string(v100.DevfileCommandTypeExec):
+		kind = "exec"
+	case "vscode-task":
+		kind = "vscodeTask"
+	case "vscode-launch":
+		kind = "vscodeLaunch"
+	default:
+		c.warnings.AddWarning(actionPath, CodeConversionDroppedElement, fmt.Sprintf("%s action has no equivalent in devfile %s and was dropped", actionType, c.targetVersion))
+		return "", nil
+	}
+
+	for _, key := range sortedKeys(action) {
+		fieldPath := actionPath + validate.JSONPointer(key)
+		switch {
+		case key == "type":
+		case kind == "exec" && key == "command":
+			converted["commandLine"] = c.convertProjectsRoot(fieldPath, action[key])
+		case kind == "exec" && key == "workdir":
+			converted["workingDir"] = c.convertProjectsRoot(fieldPath, action[key])
+		case kind == "exec" && key == "component":
+			component, _ := action[key].(string)
+			if name, ok := c.componentNames[component]; ok {
+				component = name
+			}
+			converted[key] = component
+		case kind != "exec" && key == "reference":
+			converted["uri"] = action[key]
+		case kind != "exec" && key == "referenceContent":
+			converted["inlined"] = action[key]
+		default:
+			c.dropField(fieldPath, key)
+		}
+	}
+	return kind, converted
+}
+
+// convertProjectsRoot replaces the devfile 1.0.0 projects root env var by its devfile 2.x equivalent
+func (c *v100Converter) convertProjectsRoot(fieldPath string, value interface{}) interface{} {
+	s, ok := value.(string)
+	if !ok || !strings.Contains(s, v1ProjectsRootEnvVar) {
+		return value
+	}
+	c.warnings.AddWarning(fieldPath, CodeConversionChangedValue, fmt.Sprintf("env var '%s' was replaced by 'PROJECTS_ROOT'", v1ProjectsRootEnvVar))
+	return strings.Replace(s, v1ProjectsRootEnvVar, "PROJECTS_ROOT", -1)
+}
+
+// convertPreviewUrl sets the path of the preview url on the endpoint of the command component exposing its port
+func (c *v100Converter) convertPreviewUrl(previewPath string, value interface{}, component interface{}) {
+	previewUrl, _ := value.(map[string]interface{})
+	port, _ := previewUrl["port"].(float64)
+	componentName, _ := component.(string)
+
+	if container, ok := c.containers[componentName]; ok {
+		endpoints, _ := container["endpoints"].([]interface{})
+		for _, e := range endpoints {
+			endpoint := e.(map[string]interface{})
+			if targetPort, _ := endpoint["targetPort"].(float64); targetPort != port {
+				continue
+			}
+			configuration := endpoint["configuration"].(map[string]interface{})
+			configuration["public"] = true
+			if p, ok := previewUrl["path"]; ok {
+				configuration["path"] = p
+			}
+			return
+		}
+	}
+	c.warnings.AddWarning(previewPath, CodeConversionDroppedField, fmt.Sprintf("preview url port %v is not exposed by an endpoint of component '%s' and was dropped", port, componentName))
+}
+
+// toDNS1123Name returns the lowercase DNS-1123 name derived from the string
+func toDNS1123Name(s string) string {
+	return strings.ToLower(util.GetDNS1123Name(strings.Replace(s, "_", "-", -1)))
+}
+
+// sortedKeys returns the keys of the map in alphabetical order, for deterministic conversions
+func sortedKeys(m map[string]interface{}) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// upgradeV200 upgrades the content of a devfile 2.0.0 to 2.1.0. Devfile 2.1.0 is a superset of
+// devfile 2.0.0 requiring the configuration of the endpoints, which is added empty.
+func upgradeV200(content map[string]interface{}, targetVersion string) {
+	content["schemaVersion"] = targetVersion
+
+	components, _ := toItemList(content["components"])
+	for _, component := range components {
+		container, _ := component["container"].(map[string]interface{})
+		endpoints, _ := toItemList(container["endpoints"])
+		for _, endpoint := range endpoints {
+			if _, ok := endpoint["configuration"]; !ok {
+				endpoint["configuration"] = map[string]interface{}{}
+			}
+		}
+	}
+}
+
+// downgradeV210 downgrades the content of a devfile 2.1.0 to 2.0.0, dropping the dockerfile
+// components which don't exist in devfile 2.0.0
+func downgradeV210(content map[string]interface{}, targetVersion string, warnings *validate.ValidationResult) {
+	content["schemaVersion"] = targetVersion
+
+	components, _ := toItemList(content["components"])
+	var converted []map[string]interface{}
+	for i, component := range components {
+		if dockerfile, ok := component["dockerfile"].(map[string]interface{}); ok {
+			warnings.AddWarning(validate.JSONPointer("components", i), CodeConversionDroppedElement, fmt.Sprintf("dockerfile component '%v' has no equivalent in devfile %s and was dropped", dockerfile["name"], targetVersion))
+			continue
+		}
+		converted = append(converted, component)
+	}
+	if len(converted) > 0 {
+		content["components"] = fromItemList(converted)
+	} else {
+		delete(content, "components")
+	}
+}
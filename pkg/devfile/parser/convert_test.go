@@ -0,0 +1,327 @@
+package parser
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/devfile/parser/pkg/devfile/parser/data"
+	v210 "github.com/devfile/parser/pkg/devfile/parser/data/2.1.0"
+	"github.com/devfile/parser/pkg/devfile/parser/data/common"
+	"github.com/devfile/parser/pkg/devfile/validate"
+)
+
+const v100ConvertDevfile = `apiVersion: 1.0.0
+metadata:
+  generateName: nodejs-
+projects:
+  - name: nodejs-web-app
+    source:
+      type: git
+      location: https://github.com/che-samples/web-nodejs-sample.git
+      tag: v1.0
+components:
+  - type: chePlugin
+    id: che-incubator/typescript/latest
+  - type: dockerimage
+    alias: nodejs
+    image: quay.io/eclipse/che-nodejs10-ubi:nightly
+    memoryLimit: 512Mi
+    mountSources: true
+    endpoints:
+      - name: nodejs
+        port: 3000
+        attributes:
+          public: "false"
+          protocol: http
+          discoverable: "false"
+    volumes:
+      - name: npm
+        containerPath: /opt/app-root/src/.npm-global
+    env:
+      - name: NODE_ENV
+        value: development
+  - type: kubernetes
+    reference: mongo-db.yaml
+    selector:
+      app: mongo
+commands:
+  - name: download dependencies
+    actions:
+      - type: exec
+        component: nodejs
+        command: npm install
+        workdir: ${CHE_PROJECTS_ROOT}/nodejs-web-app/app
+  - name: devRun
+    actions:
+      - type: exec
+        component: nodejs
+        command: node app.js
+    previewUrl:
+      port: 3000
+      path: /index.html
+  - name: debug
+    actions:
+      - type: vscode-launch
+        referenceContent: "{}"
+`
+
+// acmeDevfile is a devfile 2.1.0 extension adding a top-level field
+type acmeDevfile struct {
+	v210.Devfile210
+	Acme map[string]interface{} `json:"acme,omitempty"`
+}
+
+func TestConvert(t *testing.T) {
+
+	for _, targetVersion := range []string{"2.0.0", "2.1.0"} {
+		t.Run("convert 1.0.0 devfile to "+targetVersion, func(t *testing.T) {
+			d, err := parseInMemory([]byte(v100ConvertDevfile))
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			converted, warnings, err := Convert(d, targetVersion)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got := converted.Ctx.GetApiVersion(); got != targetVersion {
+				t.Errorf("expected schema version %s, got %s", targetVersion, got)
+			}
+			if err := validateDevfileData(converted, validate.ValidationOptions{}).ToError(); err != nil {
+				t.Errorf("unexpected validation error: %v", err)
+			}
+
+			if got := converted.Data.GetMetadata().Name; got != "nodejs" {
+				t.Errorf("expected name 'nodejs', got '%s'", got)
+			}
+
+			projects := converted.Data.GetProjects()
+			if len(projects) != 1 || projects[0].Git == nil || projects[0].Git.StartPoint != "v1.0" {
+				t.Errorf("expected a git project starting at tag v1.0, got %+v", projects)
+			}
+
+			components := converted.Data.GetComponents()
+			var names []string
+			for _, component := range components {
+				names = append(names, component.GetName())
+			}
+			if expected := []string{"nodejs", "mongo-db", "npm"}; !reflect.DeepEqual(names, expected) {
+				t.Fatalf("expected components %v, got %v", expected, names)
+			}
+
+			container := components[0].Container
+			if container == nil || container.MemoryLimit != "512Mi" || len(container.Env) != 1 {
+				t.Fatalf("unexpected container %+v", container)
+			}
+			expectedMounts := []common.VolumeMount{{Name: "npm", Path: "/opt/app-root/src/.npm-global"}}
+			if !reflect.DeepEqual(container.VolumeMounts, expectedMounts) {
+				t.Errorf("expected volume mounts %+v, got %+v", expectedMounts, container.VolumeMounts)
+			}
+			if len(container.Endpoints) != 1 {
+				t.Fatalf("expected 1 endpoint, got %+v", container.Endpoints)
+			}
+			endpoint := container.Endpoints[0]
+			if endpoint.TargetPort != 3000 || endpoint.Configuration == nil {
+				t.Fatalf("unexpected endpoint %+v", endpoint)
+			}
+			// the preview url of the run command makes the endpoint public
+			expectedConfiguration := common.Configuration{Public: true, Discoverable: false, Scheme: "http", Path: "/index.html"}
+			if !reflect.DeepEqual(*endpoint.Configuration, expectedConfiguration) {
+				t.Errorf("expected configuration %+v, got %+v", expectedConfiguration, *endpoint.Configuration)
+			}
+			if components[1].Kubernetes == nil || components[1].Kubernetes.Uri != "mongo-db.yaml" {
+				t.Errorf("expected kubernetes component referencing mongo-db.yaml, got %+v", components[1])
+			}
+
+			commands := converted.Data.GetCommands()
+			if len(commands) != 3 {
+				t.Fatalf("expected 3 commands, got %d", len(commands))
+			}
+			install := commands[0].Exec
+			if install == nil || install.Id != "download-dependencies" || install.Label != "download dependencies" ||
+				install.WorkingDir != "${PROJECTS_ROOT}/nodejs-web-app/app" || install.Component != "nodejs" {
+				t.Errorf("unexpected command %+v", install)
+			}
+			if run := commands[1].Exec; run == nil || run.Group == nil || run.Group.Kind != common.RunCommandGroupType || !run.Group.IsDefault {
+				t.Errorf("expected default run command, got %+v", run)
+			}
+			if debug := commands[2].VscodeLaunch; debug == nil || debug.Inlined != "{}" {
+				t.Errorf("expected inlined vscode launch command, got %+v", commands[2])
+			}
+
+			type warning struct {
+				path string
+				code string
+				line int
+			}
+			var got []warning
+			for _, w := range warnings.Errors {
+				if w.Severity != validate.SeverityWarning {
+					t.Errorf("expected warning, got %s: %s", w.Severity, w.Message)
+				}
+				line := 0
+				if w.Position != nil {
+					line = w.Position.Line
+				}
+				got = append(got, warning{w.Path, w.Code, line})
+			}
+			expected := []warning{
+				{"/metadata/generateName", CodeConversionChangedValue, 3},
+				{"/components/0", CodeConversionDroppedElement, 11},
+				{"/components/2", CodeConversionChangedValue, 31},
+				{"/components/2/selector", CodeConversionDroppedField, 33},
+				{"/commands/0/actions/0/workdir", CodeConversionChangedValue, 41},
+			}
+			if !reflect.DeepEqual(got, expected) {
+				t.Errorf("expected warnings %+v, got %+v", expected, got)
+			}
+		})
+	}
+
+	t.Run("upgrade 2.0.0 devfile to 2.1.0", func(t *testing.T) {
+		d, err := parseInMemory([]byte(`schemaVersion: 2.0.0
+components:
+  - container:
+      name: runtime
+      image: quay.io/nodejs
+      endpoints:
+        - name: http
+          targetPort: 3000
+`))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		converted, warnings, err := Convert(d, "2.1.0")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(warnings.Errors) != 0 {
+			t.Errorf("expected lossless upgrade, got warnings %v", warnings.Errors)
+		}
+		if got := converted.Ctx.GetApiVersion(); got != "2.1.0" {
+			t.Errorf("expected schema version 2.1.0, got %s", got)
+		}
+		if err := validateDevfileData(converted, validate.ValidationOptions{}).ToError(); err != nil {
+			t.Errorf("unexpected validation error: %v", err)
+		}
+	})
+
+	t.Run("downgrade 2.1.0 devfile to 2.0.0", func(t *testing.T) {
+		d, err := parseInMemory([]byte(`schemaVersion: 2.1.0
+components:
+  - container:
+      name: runtime
+      image: quay.io/nodejs
+  - dockerfile:
+      name: build
+      dockerfileLocation: Dockerfile
+      source:
+        sourceDir: src
+        location: https://github.com/example/app.git
+`))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		converted, warnings, err := Convert(d, "2.0.0")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if err := validateDevfileData(converted, validate.ValidationOptions{}).ToError(); err != nil {
+			t.Errorf("unexpected validation error: %v", err)
+		}
+		if components := converted.Data.GetComponents(); len(components) != 1 || components[0].Container == nil {
+			t.Errorf("expected only the container component, got %+v", components)
+		}
+		expected := []validate.ValidationError{{
+			Path:     "/components/1",
+			Code:     CodeConversionDroppedElement,
+			Severity: validate.SeverityWarning,
+			Message:  "dockerfile component 'build' has no equivalent in devfile 2.0.0 and was dropped",
+			Position: &validate.Position{Line: 6, Column: 5},
+		}}
+		if !reflect.DeepEqual(warnings.Errors, expected) {
+			t.Errorf("expected warnings %+v, got %+v", expected, warnings.Errors)
+		}
+	})
+
+	t.Run("downgrade edited 2.1.0 devfile", func(t *testing.T) {
+		d, err := parseInMemory([]byte(`schemaVersion: 2.1.0
+components:
+  - container:
+      name: runtime
+      image: quay.io/nodejs
+`))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		err = d.Data.AddComponents([]common.DevfileComponent{{
+			Dockerfile: &common.Dockerfile{Name: "build", DockerfileLocation: "Dockerfile", Source: &common.Source{SourceDir: "src"}},
+		}})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		_, warnings, err := Convert(d, "2.0.0")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(warnings.Errors) != 1 || warnings.Errors[0].Path != "/components/1" {
+			t.Fatalf("expected a warning for the dockerfile component, got %+v", warnings.Errors)
+		}
+		// the edited component is not in the devfile source
+		if warnings.Errors[0].Position != nil {
+			t.Errorf("expected no position, got %+v", warnings.Errors[0].Position)
+		}
+	})
+
+	t.Run("convert registered extension version", func(t *testing.T) {
+		// the extension adds a top-level field to devfile 2.1.0
+		converter := func(content map[string]interface{}, sourceVersion, targetVersion string, warnings *validate.ValidationResult) (map[string]interface{}, error) {
+			if _, ok := content["acme"]; ok {
+				delete(content, "acme")
+				warnings.AddWarning("/acme", CodeConversionDroppedField, "field 'acme' was dropped")
+			}
+			content["schemaVersion"] = targetVersion
+			return content, nil
+		}
+		err := data.RegisterVersion("2.1.0-convert", func() data.DevfileData { return &acmeDevfile{} }, v210.JsonSchema210, converter)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		d, err := parseInMemory([]byte(`schemaVersion: 2.1.0-convert
+acme:
+  team: tools
+components:
+  - container:
+      name: runtime
+      image: quay.io/nodejs
+`))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		converted, warnings, err := Convert(d, "2.1.0")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if got := converted.Ctx.GetApiVersion(); got != "2.1.0" {
+			t.Errorf("expected schema version 2.1.0, got %s", got)
+		}
+		if len(warnings.Errors) != 1 || warnings.Errors[0].Path != "/acme" {
+			t.Errorf("expected a warning for the extension field, got %+v", warnings.Errors)
+		}
+	})
+
+	t.Run("unsupported conversion", func(t *testing.T) {
+		d, err := parseInMemory([]byte("schemaVersion: 2.0.0\n"))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if _, _, err := Convert(d, "1.0.0"); err == nil {
+			t.Errorf("expected error converting 2.0.0 devfile to 1.0.0")
+		}
+	})
+}
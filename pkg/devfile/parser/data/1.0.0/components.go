@@ -87,10 +87,10 @@ func convertV1CommandToCommon(c Command) (d common.DevfileCommand) {
 
 		if action.Type == DevfileCommandTypeExec {
 			exec = common.Exec{
-				Attributes:  c.Attributes,
+				Attributes:  convertV1AttributesToCommon(c.Attributes),
 				CommandLine: action.Command,
 				Component:   action.Component,
-				Group:       getGroup(name),
+				Group:       GetGroup(name),
 				Id:          name,
 				WorkingDir:  action.Workdir,
 				// Env:
@@ -107,6 +107,18 @@ func convertV1CommandToCommon(c Command) (d common.DevfileCommand) {
 	}
 }
 
+// convertV1AttributesToCommon converts the string attributes into free-form attributes
+func convertV1AttributesToCommon(a Attributes) map[string]interface{} {
+	if a == nil {
+		return nil
+	}
+	attributes := make(map[string]interface{}, len(a))
+	for key, value := range a {
+		attributes[key] = value
+	}
+	return attributes
+}
+
 func convertV1ComponentToCommon(c Component) (component common.DevfileComponent) {
 
 	var endpoints []common.Endpoint
@@ -124,13 +136,14 @@ func convertV1ComponentToCommon(c Component) (component common.DevfileComponent)
 		volumes = append(volumes, convertV1VolumeToCommon(v))
 	}
 
+	mountSources := c.MountSources
 	container := common.Container{
 		Name:         c.Alias,
 		Endpoints:    endpoints,
 		Env:          envs,
 		Image:        c.ComponentDockerimage.Image,
 		MemoryLimit:  c.ComponentDockerimage.MemoryLimit,
-		MountSources: c.MountSources,
+		MountSources: &mountSources,
 		VolumeMounts: volumes,
 		Command:      c.Command,
 		Args:         c.Args,
@@ -203,7 +216,8 @@ func convertV1ProjectToCommon(p Project) common.DevfileProject {
 
 }
 
-func getGroup(name string) *common.Group {
+// GetGroup returns the default command group matching the name of a devfile 1.0.0 command, e.g. "devrun"
+func GetGroup(name string) *common.Group {
 
 	switch name {
 	case "devrun":
@@ -230,3 +244,68 @@ func getGroup(name string) *common.Group {
 
 	return nil
 }
+
+// unsupportedOperation returns the error for an operation which is not supported on devfile 1.0.0
+func (d *Devfile100) unsupportedOperation(operation string) error {
+	return &common.UnsupportedOperationError{Operation: operation, Version: string(d.ApiVersion)}
+}
+
+// SetMetadata is not supported on devfile 1.0.0
+func (d *Devfile100) SetMetadata(metadata common.DevfileMetadata) error {
+	return d.unsupportedOperation("SetMetadata")
+}
+
+// AddComponents is not supported on devfile 1.0.0
+func (d *Devfile100) AddComponents(components []common.DevfileComponent) error {
+	return d.unsupportedOperation("AddComponents")
+}
+
+// UpdateComponent is not supported on devfile 1.0.0
+func (d *Devfile100) UpdateComponent(component common.DevfileComponent) error {
+	return d.unsupportedOperation("UpdateComponent")
+}
+
+// DeleteComponent is not supported on devfile 1.0.0
+func (d *Devfile100) DeleteComponent(name string) error {
+	return d.unsupportedOperation("DeleteComponent")
+}
+
+// AddCommands is not supported on devfile 1.0.0
+func (d *Devfile100) AddCommands(commands []common.DevfileCommand) error {
+	return d.unsupportedOperation("AddCommands")
+}
+
+// UpdateCommand is not supported on devfile 1.0.0
+func (d *Devfile100) UpdateCommand(command common.DevfileCommand) error {
+	return d.unsupportedOperation("UpdateCommand")
+}
+
+// DeleteCommand is not supported on devfile 1.0.0
+func (d *Devfile100) DeleteCommand(id string) error {
+	return d.unsupportedOperation("DeleteCommand")
+}
+
+// AddProjects is not supported on devfile 1.0.0
+func (d *Devfile100) AddProjects(projects []common.DevfileProject) error {
+	return d.unsupportedOperation("AddProjects")
+}
+
+// UpdateProject is not supported on devfile 1.0.0
+func (d *Devfile100) UpdateProject(project common.DevfileProject) error {
+	return d.unsupportedOperation("UpdateProject")
+}
+
+// DeleteProject is not supported on devfile 1.0.0
+func (d *Devfile100) DeleteProject(name string) error {
+	return d.unsupportedOperation("DeleteProject")
+}
+
+// AddEvents is not supported on devfile 1.0.0
+func (d *Devfile100) AddEvents(events common.DevfileEvents) error {
+	return d.unsupportedOperation("AddEvents")
+}
+
+// UpdateEvents is not supported on devfile 1.0.0
+func (d *Devfile100) UpdateEvents(events common.DevfileEvents) error {
+	return d.unsupportedOperation("UpdateEvents")
+}
@@ -19,7 +19,7 @@ func (d *Devfile210) GetCommands() []common.DevfileCommand {
 		// we convert devfile command id to lowercase so that we can handle
 		// cases efficiently without being error prone
 		// we also convert the odo push commands from build-command and run-command flags
-		command.Exec.Id = strings.ToLower(command.Exec.Id)
+		command.SetId(strings.ToLower(command.GetId()))
 		commands = append(commands, command)
 	}
 
@@ -51,3 +51,181 @@ func (d *Devfile210) GetAliasedComponents() []common.DevfileComponent {
 	// V2 has name required in jsonSchema
 	return d.Components
 }
+
+// SetMetadata sets the metadata of the devfile
+func (d *Devfile210) SetMetadata(metadata common.DevfileMetadata) error {
+	d.Metadata = metadata
+	return nil
+}
+
+// AddComponents adds the slice of DevfileComponent objects to the devfile components,
+// it errors out without adding anything if one of the component names is already used
+func (d *Devfile210) AddComponents(components []common.DevfileComponent) error {
+	names := make(map[string]bool)
+	for _, component := range d.Components {
+		names[strings.ToLower(component.GetName())] = true
+	}
+	for _, component := range components {
+		name := strings.ToLower(component.GetName())
+		if names[name] {
+			return &common.FieldAlreadyExistError{Field: "component", Name: component.GetName()}
+		}
+		names[name] = true
+	}
+
+	d.Components = append(d.Components, components...)
+	return nil
+}
+
+// UpdateComponent replaces the devfile component with the same name
+func (d *Devfile210) UpdateComponent(component common.DevfileComponent) error {
+	for i := range d.Components {
+		if strings.EqualFold(d.Components[i].GetName(), component.GetName()) {
+			d.Components[i] = component
+			return nil
+		}
+	}
+	return &common.FieldNotFoundError{Field: "component", Name: component.GetName()}
+}
+
+// DeleteComponent removes the devfile component with the given name
+func (d *Devfile210) DeleteComponent(name string) error {
+	for i := range d.Components {
+		if strings.EqualFold(d.Components[i].GetName(), name) {
+			d.Components = append(d.Components[:i], d.Components[i+1:]...)
+			return nil
+		}
+	}
+	return &common.FieldNotFoundError{Field: "component", Name: name}
+}
+
+// AddCommands adds the slice of DevfileCommand objects to the devfile commands,
+// it errors out without adding anything if one of the command ids is already used
+func (d *Devfile210) AddCommands(commands []common.DevfileCommand) error {
+	ids := make(map[string]bool)
+	for _, command := range d.Commands {
+		ids[strings.ToLower(command.GetId())] = true
+	}
+	for _, command := range commands {
+		id := strings.ToLower(command.GetId())
+		if ids[id] {
+			return &common.FieldAlreadyExistError{Field: "command", Name: command.GetId()}
+		}
+		ids[id] = true
+	}
+
+	d.Commands = append(d.Commands, commands...)
+	return nil
+}
+
+// UpdateCommand replaces the devfile command with the same id
+func (d *Devfile210) UpdateCommand(command common.DevfileCommand) error {
+	for i := range d.Commands {
+		if strings.EqualFold(d.Commands[i].GetId(), command.GetId()) {
+			d.Commands[i] = command
+			return nil
+		}
+	}
+	return &common.FieldNotFoundError{Field: "command", Name: command.GetId()}
+}
+
+// DeleteCommand removes the devfile command with the given id
+func (d *Devfile210) DeleteCommand(id string) error {
+	for i := range d.Commands {
+		if strings.EqualFold(d.Commands[i].GetId(), id) {
+			d.Commands = append(d.Commands[:i], d.Commands[i+1:]...)
+			return nil
+		}
+	}
+	return &common.FieldNotFoundError{Field: "command", Name: id}
+}
+
+// AddProjects adds the slice of DevfileProject objects to the devfile projects,
+// it errors out without adding anything if one of the project names is already used
+func (d *Devfile210) AddProjects(projects []common.DevfileProject) error {
+	names := make(map[string]bool)
+	for _, project := range d.Projects {
+		names[strings.ToLower(project.Name)] = true
+	}
+	for _, project := range projects {
+		name := strings.ToLower(project.Name)
+		if names[name] {
+			return &common.FieldAlreadyExistError{Field: "project", Name: project.Name}
+		}
+		names[name] = true
+	}
+
+	d.Projects = append(d.Projects, projects...)
+	return nil
+}
+
+// UpdateProject replaces the devfile project with the same name
+func (d *Devfile210) UpdateProject(project common.DevfileProject) error {
+	for i := range d.Projects {
+		if strings.EqualFold(d.Projects[i].Name, project.Name) {
+			d.Projects[i] = project
+			return nil
+		}
+	}
+	return &common.FieldNotFoundError{Field: "project", Name: project.Name}
+}
+
+// DeleteProject removes the devfile project with the given name
+func (d *Devfile210) DeleteProject(name string) error {
+	for i := range d.Projects {
+		if strings.EqualFold(d.Projects[i].Name, name) {
+			d.Projects = append(d.Projects[:i], d.Projects[i+1:]...)
+			return nil
+		}
+	}
+	return &common.FieldNotFoundError{Field: "project", Name: name}
+}
+
+// AddEvents appends the command ids of the given events to the devfile events,
+// it errors out without adding anything if a command id is already bound to the same event
+func (d *Devfile210) AddEvents(events common.DevfileEvents) error {
+	for _, e := range []struct {
+		name     string
+		existing []string
+		added    []string
+	}{
+		{"preStart", d.Events.PreStart, events.PreStart},
+		{"postStart", d.Events.PostStart, events.PostStart},
+		{"preStop", d.Events.PreStop, events.PreStop},
+		{"postStop", d.Events.PostStop, events.PostStop},
+	} {
+		ids := make(map[string]bool)
+		for _, id := range e.existing {
+			ids[strings.ToLower(id)] = true
+		}
+		for _, id := range e.added {
+			if ids[strings.ToLower(id)] {
+				return &common.FieldAlreadyExistError{Field: e.name + " event", Name: id}
+			}
+			ids[strings.ToLower(id)] = true
+		}
+	}
+
+	d.Events.PreStart = append(d.Events.PreStart, events.PreStart...)
+	d.Events.PostStart = append(d.Events.PostStart, events.PostStart...)
+	d.Events.PreStop = append(d.Events.PreStop, events.PreStop...)
+	d.Events.PostStop = append(d.Events.PostStop, events.PostStop...)
+	return nil
+}
+
+// UpdateEvents replaces the devfile events which are set in the given events
+func (d *Devfile210) UpdateEvents(events common.DevfileEvents) error {
+	if len(events.PreStart) > 0 {
+		d.Events.PreStart = events.PreStart
+	}
+	if len(events.PostStart) > 0 {
+		d.Events.PostStart = events.PostStart
+	}
+	if len(events.PreStop) > 0 {
+		d.Events.PreStop = events.PreStop
+	}
+	if len(events.PostStop) > 0 {
+		d.Events.PostStop = events.PostStop
+	}
+	return nil
+}
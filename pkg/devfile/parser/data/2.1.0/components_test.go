@@ -52,3 +52,141 @@ func getTestDevfileData() (testDevfile Devfile210, commands []common.DevfileComm
 
 	return testDevfileobj, execCommands
 }
+
+func TestGetCommandsOfAllKinds(t *testing.T) {
+
+	testDevfile := Devfile210{
+		Commands: []common.DevfileCommand{
+			{Exec: &common.Exec{Id: "Build"}},
+			{Composite: &common.Composite{Id: "BuildAndRun", Commands: []string{"build", "run"}}},
+			{VscodeTask: &common.VscodeTask{Id: "Task"}},
+			{VscodeLaunch: &common.VscodeLaunch{Id: "Launch"}},
+		},
+	}
+
+	want := []string{"build", "buildandrun", "task", "launch"}
+	got := testDevfile.GetCommands()
+	if len(got) != len(want) {
+		t.Fatalf("expected %d commands, got %d", len(want), len(got))
+	}
+	for i, command := range got {
+		if command.GetId() != want[i] {
+			t.Errorf("want: '%s', got: '%s'", want[i], command.GetId())
+		}
+	}
+}
+
+func TestAddAndUpdateComponents(t *testing.T) {
+
+	d := &Devfile210{
+		Components: []common.DevfileComponent{
+			{Container: &common.Container{Name: "runtime", Image: "quay.io/nodejs"}},
+		},
+	}
+
+	err := d.AddComponents([]common.DevfileComponent{{Volume: &common.Volume{Name: "cache"}}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(d.GetComponents()) != 2 {
+		t.Errorf("expected 2 components, got %d", len(d.GetComponents()))
+	}
+
+	err = d.AddComponents([]common.DevfileComponent{{Volume: &common.Volume{Name: "data"}}, {Volume: &common.Volume{Name: "Runtime"}}})
+	if _, ok := err.(*common.FieldAlreadyExistError); !ok {
+		t.Errorf("expected a FieldAlreadyExistError, got '%v'", err)
+	}
+	if len(d.GetComponents()) != 2 {
+		t.Errorf("expected no component to be added on error, got %d components", len(d.GetComponents()))
+	}
+
+	err = d.UpdateComponent(common.DevfileComponent{Container: &common.Container{Name: "runtime", Image: "quay.io/nodejs:14"}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if d.GetComponents()[0].Container.Image != "quay.io/nodejs:14" {
+		t.Errorf("component not updated, got image '%s'", d.GetComponents()[0].Container.Image)
+	}
+
+	if err = d.DeleteComponent("cache"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err = d.DeleteComponent("cache"); err == nil {
+		t.Errorf("expected an error when deleting a missing component")
+	}
+	if _, ok := d.UpdateComponent(common.DevfileComponent{Volume: &common.Volume{Name: "missing"}}).(*common.FieldNotFoundError); !ok {
+		t.Errorf("expected a FieldNotFoundError when updating a missing component")
+	}
+}
+
+func TestAddAndUpdateCommands(t *testing.T) {
+
+	d := &Devfile210{}
+
+	err := d.AddCommands([]common.DevfileCommand{
+		{Exec: &common.Exec{Id: "build", CommandLine: "npm install"}},
+		{Composite: &common.Composite{Id: "all", Commands: []string{"build"}}},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	err = d.AddCommands([]common.DevfileCommand{{Exec: &common.Exec{Id: "BUILD"}}})
+	if _, ok := err.(*common.FieldAlreadyExistError); !ok {
+		t.Errorf("expected a FieldAlreadyExistError, got '%v'", err)
+	}
+
+	err = d.UpdateCommand(common.DevfileCommand{Exec: &common.Exec{Id: "build", CommandLine: "npm ci"}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if d.GetCommands()[0].Exec.CommandLine != "npm ci" {
+		t.Errorf("command not updated, got '%s'", d.GetCommands()[0].Exec.CommandLine)
+	}
+
+	if err = d.DeleteCommand("all"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(d.GetCommands()) != 1 {
+		t.Errorf("expected 1 command, got %d", len(d.GetCommands()))
+	}
+}
+
+func TestAddAndUpdateProjectsAndEvents(t *testing.T) {
+
+	d := &Devfile210{}
+
+	if err := d.AddProjects([]common.DevfileProject{{Name: "nodejs-starter"}}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, ok := d.AddProjects([]common.DevfileProject{{Name: "nodejs-starter"}}).(*common.FieldAlreadyExistError); !ok {
+		t.Errorf("expected a FieldAlreadyExistError when adding an existing project")
+	}
+	if err := d.UpdateProject(common.DevfileProject{Name: "nodejs-starter", ClonePath: "src"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if d.GetProjects()[0].ClonePath != "src" {
+		t.Errorf("project not updated")
+	}
+	if err := d.DeleteProject("nodejs-starter"); err != nil || len(d.GetProjects()) != 0 {
+		t.Errorf("project not deleted, error '%v'", err)
+	}
+
+	if err := d.AddEvents(common.DevfileEvents{PostStart: []string{"build"}}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, ok := d.AddEvents(common.DevfileEvents{PostStart: []string{"build"}}).(*common.FieldAlreadyExistError); !ok {
+		t.Errorf("expected a FieldAlreadyExistError when binding a command twice to an event")
+	}
+	if err := d.UpdateEvents(common.DevfileEvents{PreStop: []string{"clean"}}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	events := d.GetEvents()
+	if len(events.PostStart) != 1 || len(events.PreStop) != 1 {
+		t.Errorf("unexpected events '%v'", events)
+	}
+
+	if err := d.SetMetadata(common.DevfileMetadata{Name: "nodejs", Version: "1.0.0"}); err != nil || d.GetMetadata().Name != "nodejs" {
+		t.Errorf("metadata not set, error '%v'", err)
+	}
+}
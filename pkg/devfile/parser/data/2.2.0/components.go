@@ -0,0 +1,371 @@
+package version220
+
+import (
+	"strings"
+
+	"github.com/devfile/parser/pkg/devfile/parser/data/common"
+)
+
+// GetComponents returns the slice of DevfileComponent objects parsed from the Devfile
+func (d *Devfile220) GetComponents() []common.DevfileComponent {
+	return d.Components
+}
+
+// GetCommands returns the slice of DevfileCommand objects parsed from the Devfile
+func (d *Devfile220) GetCommands() []common.DevfileCommand {
+	var commands []common.DevfileCommand
+
+	for _, command := range d.Commands {
+		// we convert devfile command id to lowercase so that we can handle
+		// cases efficiently without being error prone
+		// we also convert the odo push commands from build-command and run-command flags
+		command.SetId(strings.ToLower(command.GetId()))
+		commands = append(commands, command)
+	}
+
+	return commands
+}
+
+// GetParent returns the  DevfileParent object parsed from devfile
+func (d *Devfile220) GetParent() common.DevfileParent {
+	return d.Parent
+}
+
+// GetProjects returns the DevfileProject Object parsed from devfile
+func (d *Devfile220) GetProjects() []common.DevfileProject {
+	return d.Projects
+}
+
+// GetMetadata returns the DevfileMetadata Object parsed from devfile
+func (d *Devfile220) GetMetadata() common.DevfileMetadata {
+	return d.Metadata
+}
+
+// GetEvents returns the Events Object parsed from devfile
+func (d *Devfile220) GetEvents() common.DevfileEvents {
+	return d.Events
+}
+
+// GetStarterProjects returns the DevfileStarterProject objects parsed from devfile
+func (d *Devfile220) GetStarterProjects() []common.DevfileStarterProject {
+	return d.StarterProjects
+}
+
+// GetVariables returns the variables parsed from devfile
+func (d *Devfile220) GetVariables() map[string]string {
+	return d.Variables
+}
+
+// GetAttributes returns the free-form attributes parsed from devfile
+func (d *Devfile220) GetAttributes() map[string]interface{} {
+	return d.Attributes
+}
+
+// GetAliasedComponents returns the slice of DevfileComponent objects that each have an alias
+func (d *Devfile220) GetAliasedComponents() []common.DevfileComponent {
+	// V2 has name required in jsonSchema
+	return d.Components
+}
+
+// SetMetadata sets the metadata of the devfile
+func (d *Devfile220) SetMetadata(metadata common.DevfileMetadata) error {
+	d.Metadata = metadata
+	return nil
+}
+
+// AddComponents adds the slice of DevfileComponent objects to the devfile components,
+// it errors out without adding anything if one of the component names is already used
+func (d *Devfile220) AddComponents(components []common.DevfileComponent) error {
+	names := make(map[string]bool)
+	for _, component := range d.Components {
+		names[strings.ToLower(component.GetName())] = true
+	}
+	for _, component := range components {
+		name := strings.ToLower(component.GetName())
+		if names[name] {
+			return &common.FieldAlreadyExistError{Field: "component", Name: component.GetName()}
+		}
+		names[name] = true
+	}
+
+	d.Components = append(d.Components, components...)
+	return nil
+}
+
+// UpdateComponent replaces the devfile component with the same name
+func (d *Devfile220) UpdateComponent(component common.DevfileComponent) error {
+	for i := range d.Components {
+		if strings.EqualFold(d.Components[i].GetName(), component.GetName()) {
+			d.Components[i] = component
+			return nil
+		}
+	}
+	return &common.FieldNotFoundError{Field: "component", Name: component.GetName()}
+}
+
+// DeleteComponent removes the devfile component with the given name
+func (d *Devfile220) DeleteComponent(name string) error {
+	for i := range d.Components {
+		if strings.EqualFold(d.Components[i].GetName(), name) {
+			d.Components = append(d.Components[:i], d.Components[i+1:]...)
+			return nil
+		}
+	}
+	return &common.FieldNotFoundError{Field: "component", Name: name}
+}
+
+// AddCommands adds the slice of DevfileCommand objects to the devfile commands,
+// it errors out without adding anything if one of the command ids is already used
+func (d *Devfile220) AddCommands(commands []common.DevfileCommand) error {
+	ids := make(map[string]bool)
+	for _, command := range d.Commands {
+		ids[strings.ToLower(command.GetId())] = true
+	}
+	for _, command := range commands {
+		id := strings.ToLower(command.GetId())
+		if ids[id] {
+			return &common.FieldAlreadyExistError{Field: "command", Name: command.GetId()}
+		}
+		ids[id] = true
+	}
+
+	d.Commands = append(d.Commands, commands...)
+	return nil
+}
+
+// UpdateCommand replaces the devfile command with the same id
+func (d *Devfile220) UpdateCommand(command common.DevfileCommand) error {
+	for i := range d.Commands {
+		if strings.EqualFold(d.Commands[i].GetId(), command.GetId()) {
+			d.Commands[i] = command
+			return nil
+		}
+	}
+	return &common.FieldNotFoundError{Field: "command", Name: command.GetId()}
+}
+
+// DeleteCommand removes the devfile command with the given id
+func (d *Devfile220) DeleteCommand(id string) error {
+	for i := range d.Commands {
+		if strings.EqualFold(d.Commands[i].GetId(), id) {
+			d.Commands = append(d.Commands[:i], d.Commands[i+1:]...)
+			return nil
+		}
+	}
+	return &common.FieldNotFoundError{Field: "command", Name: id}
+}
+
+// AddProjects adds the slice of DevfileProject objects to the devfile projects,
+// it errors out without adding anything if one of the project names is already used
+func (d *Devfile220) AddProjects(projects []common.DevfileProject) error {
+	names := make(map[string]bool)
+	for _, project := range d.Projects {
+		names[strings.ToLower(project.Name)] = true
+	}
+	for _, project := range projects {
+		name := strings.ToLower(project.Name)
+		if names[name] {
+			return &common.FieldAlreadyExistError{Field: "project", Name: project.Name}
+		}
+		names[name] = true
+	}
+
+	d.Projects = append(d.Projects, projects...)
+	return nil
+}
+
+// UpdateProject replaces the devfile project with the same name
+func (d *Devfile220) UpdateProject(project common.DevfileProject) error {
+	for i := range d.Projects {
+		if strings.EqualFold(d.Projects[i].Name, project.Name) {
+			d.Projects[i] = project
+			return nil
+		}
+	}
+	return &common.FieldNotFoundError{Field: "project", Name: project.Name}
+}
+
+// DeleteProject removes the devfile project with the given name
+func (d *Devfile220) DeleteProject(name string) error {
+	for i := range d.Projects {
+		if strings.EqualFold(d.Projects[i].Name, name) {
+			d.Projects = append(d.Projects[:i], d.Projects[i+1:]...)
+			return nil
+		}
+	}
+	return &common.FieldNotFoundError{Field: "project", Name: name}
+}
+
+// AddStarterProjects adds the slice of DevfileStarterProject objects to the devfile starter projects,
+// it errors out without adding anything if one of the starter project names is already used
+func (d *Devfile220) AddStarterProjects(starterProjects []common.DevfileStarterProject) error {
+	names := make(map[string]bool)
+	for _, starterProject := range d.StarterProjects {
+		names[strings.ToLower(starterProject.Name)] = true
+	}
+	for _, starterProject := range starterProjects {
+		name := strings.ToLower(starterProject.Name)
+		if names[name] {
+			return &common.FieldAlreadyExistError{Field: "starter project", Name: starterProject.Name}
+		}
+		names[name] = true
+	}
+
+	d.StarterProjects = append(d.StarterProjects, starterProjects...)
+	return nil
+}
+
+// UpdateStarterProject replaces the devfile starter project with the same name
+func (d *Devfile220) UpdateStarterProject(starterProject common.DevfileStarterProject) error {
+	for i := range d.StarterProjects {
+		if strings.EqualFold(d.StarterProjects[i].Name, starterProject.Name) {
+			d.StarterProjects[i] = starterProject
+			return nil
+		}
+	}
+	return &common.FieldNotFoundError{Field: "starter project", Name: starterProject.Name}
+}
+
+// DeleteStarterProject removes the devfile starter project with the given name
+func (d *Devfile220) DeleteStarterProject(name string) error {
+	for i := range d.StarterProjects {
+		if strings.EqualFold(d.StarterProjects[i].Name, name) {
+			d.StarterProjects = append(d.StarterProjects[:i], d.StarterProjects[i+1:]...)
+			return nil
+		}
+	}
+	return &common.FieldNotFoundError{Field: "starter project", Name: name}
+}
+
+// AddVariables adds the variables to the devfile variables,
+// it errors out without adding anything if one of the variables is already defined
+func (d *Devfile220) AddVariables(variables map[string]string) error {
+	for name := range variables {
+		if _, ok := d.Variables[name]; ok {
+			return &common.FieldAlreadyExistError{Field: "variable", Name: name}
+		}
+	}
+
+	if d.Variables == nil {
+		d.Variables = make(map[string]string)
+	}
+	for name, value := range variables {
+		d.Variables[name] = value
+	}
+	return nil
+}
+
+// UpdateVariables replaces the value of the devfile variables,
+// it errors out without updating anything if one of the variables is not defined
+func (d *Devfile220) UpdateVariables(variables map[string]string) error {
+	for name := range variables {
+		if _, ok := d.Variables[name]; !ok {
+			return &common.FieldNotFoundError{Field: "variable", Name: name}
+		}
+	}
+
+	for name, value := range variables {
+		d.Variables[name] = value
+	}
+	return nil
+}
+
+// DeleteVariable removes the devfile variable with the given name
+func (d *Devfile220) DeleteVariable(name string) error {
+	if _, ok := d.Variables[name]; !ok {
+		return &common.FieldNotFoundError{Field: "variable", Name: name}
+	}
+	delete(d.Variables, name)
+	return nil
+}
+
+// AddAttributes adds the free-form attributes to the devfile attributes,
+// it errors out without adding anything if one of the attributes is already set
+func (d *Devfile220) AddAttributes(attributes map[string]interface{}) error {
+	for key := range attributes {
+		if _, ok := d.Attributes[key]; ok {
+			return &common.FieldAlreadyExistError{Field: "attribute", Name: key}
+		}
+	}
+
+	if d.Attributes == nil {
+		d.Attributes = make(map[string]interface{})
+	}
+	for key, value := range attributes {
+		d.Attributes[key] = value
+	}
+	return nil
+}
+
+// UpdateAttributes replaces the value of the devfile attributes,
+// it errors out without updating anything if one of the attributes is not set
+func (d *Devfile220) UpdateAttributes(attributes map[string]interface{}) error {
+	for key := range attributes {
+		if _, ok := d.Attributes[key]; !ok {
+			return &common.FieldNotFoundError{Field: "attribute", Name: key}
+		}
+	}
+
+	for key, value := range attributes {
+		d.Attributes[key] = value
+	}
+	return nil
+}
+
+// DeleteAttribute removes the devfile attribute with the given key
+func (d *Devfile220) DeleteAttribute(key string) error {
+	if _, ok := d.Attributes[key]; !ok {
+		return &common.FieldNotFoundError{Field: "attribute", Name: key}
+	}
+	delete(d.Attributes, key)
+	return nil
+}
+
+// AddEvents appends the command ids of the given events to the devfile events,
+// it errors out without adding anything if a command id is already bound to the same event
+func (d *Devfile220) AddEvents(events common.DevfileEvents) error {
+	for _, e := range []struct {
+		name     string
+		existing []string
+		added    []string
+	}{
+		{"preStart", d.Events.PreStart, events.PreStart},
+		{"postStart", d.Events.PostStart, events.PostStart},
+		{"preStop", d.Events.PreStop, events.PreStop},
+		{"postStop", d.Events.PostStop, events.PostStop},
+	} {
+		ids := make(map[string]bool)
+		for _, id := range e.existing {
+			ids[strings.ToLower(id)] = true
+		}
+		for _, id := range e.added {
+			if ids[strings.ToLower(id)] {
+				return &common.FieldAlreadyExistError{Field: e.name + " event", Name: id}
+			}
+			ids[strings.ToLower(id)] = true
+		}
+	}
+
+	d.Events.PreStart = append(d.Events.PreStart, events.PreStart...)
+	d.Events.PostStart = append(d.Events.PostStart, events.PostStart...)
+	d.Events.PreStop = append(d.Events.PreStop, events.PreStop...)
+	d.Events.PostStop = append(d.Events.PostStop, events.PostStop...)
+	return nil
+}
+
+// UpdateEvents replaces the devfile events which are set in the given events
+func (d *Devfile220) UpdateEvents(events common.DevfileEvents) error {
+	if len(events.PreStart) > 0 {
+		d.Events.PreStart = events.PreStart
+	}
+	if len(events.PostStart) > 0 {
+		d.Events.PostStart = events.PostStart
+	}
+	if len(events.PreStop) > 0 {
+		d.Events.PreStop = events.PreStop
+	}
+	if len(events.PostStop) > 0 {
+		d.Events.PostStop = events.PostStop
+	}
+	return nil
+}
@@ -0,0 +1,271 @@
+package version220
+
+import (
+	"testing"
+
+	common "github.com/devfile/parser/pkg/devfile/parser/data/common"
+)
+
+func TestGetCommands(t *testing.T) {
+
+	testDevfile, execCommands := getTestDevfileData()
+
+	got := testDevfile.GetCommands()
+	want := execCommands
+
+	for i, command := range got {
+		if command.Exec != want[i].Exec {
+			t.Error("Commands returned don't match expected commands")
+		}
+	}
+
+}
+
+func getTestDevfileData() (testDevfile Devfile220, commands []common.DevfileCommand) {
+
+	command := "ls -la"
+	component := "alias1"
+	debugCommand := "nodemon --inspect={DEBUG_PORT}"
+	debugComponent := "alias2"
+	workDir := "/root"
+
+	execCommands := []common.DevfileCommand{
+		{
+			Exec: &common.Exec{
+				CommandLine: command,
+				Component:   component,
+				WorkingDir:  workDir,
+			},
+		},
+		{
+			Exec: &common.Exec{
+				CommandLine: debugCommand,
+				Component:   debugComponent,
+				WorkingDir:  workDir,
+			},
+		},
+	}
+
+	testDevfileobj := Devfile220{
+		Commands: execCommands,
+	}
+
+	return testDevfileobj, execCommands
+}
+
+func TestGetCommandsOfAllKinds(t *testing.T) {
+
+	testDevfile := Devfile220{
+		Commands: []common.DevfileCommand{
+			{Exec: &common.Exec{Id: "Build"}},
+			{Composite: &common.Composite{Id: "BuildAndRun", Commands: []string{"build", "run"}}},
+			{VscodeTask: &common.VscodeTask{Id: "Task"}},
+			{VscodeLaunch: &common.VscodeLaunch{Id: "Launch"}},
+			{Apply: &common.Apply{Id: "Deploy", Component: "image"}},
+		},
+	}
+
+	want := []string{"build", "buildandrun", "task", "launch", "deploy"}
+	got := testDevfile.GetCommands()
+	if len(got) != len(want) {
+		t.Fatalf("expected %d commands, got %d", len(want), len(got))
+	}
+	for i, command := range got {
+		if command.GetId() != want[i] {
+			t.Errorf("want: '%s', got: '%s'", want[i], command.GetId())
+		}
+	}
+}
+
+func TestAddAndUpdateComponents(t *testing.T) {
+
+	d := &Devfile220{
+		Components: []common.DevfileComponent{
+			{Container: &common.Container{Name: "runtime", Image: "quay.io/nodejs"}},
+		},
+	}
+
+	err := d.AddComponents([]common.DevfileComponent{{Volume: &common.Volume{Name: "cache"}}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(d.GetComponents()) != 2 {
+		t.Errorf("expected 2 components, got %d", len(d.GetComponents()))
+	}
+
+	err = d.AddComponents([]common.DevfileComponent{{Volume: &common.Volume{Name: "data"}}, {Volume: &common.Volume{Name: "Runtime"}}})
+	if _, ok := err.(*common.FieldAlreadyExistError); !ok {
+		t.Errorf("expected a FieldAlreadyExistError, got '%v'", err)
+	}
+	if len(d.GetComponents()) != 2 {
+		t.Errorf("expected no component to be added on error, got %d components", len(d.GetComponents()))
+	}
+
+	err = d.UpdateComponent(common.DevfileComponent{Container: &common.Container{Name: "runtime", Image: "quay.io/nodejs:14"}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if d.GetComponents()[0].Container.Image != "quay.io/nodejs:14" {
+		t.Errorf("component not updated, got image '%s'", d.GetComponents()[0].Container.Image)
+	}
+
+	if err = d.DeleteComponent("cache"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err = d.DeleteComponent("cache"); err == nil {
+		t.Errorf("expected an error when deleting a missing component")
+	}
+	if _, ok := d.UpdateComponent(common.DevfileComponent{Volume: &common.Volume{Name: "missing"}}).(*common.FieldNotFoundError); !ok {
+		t.Errorf("expected a FieldNotFoundError when updating a missing component")
+	}
+}
+
+func TestAddAndUpdateCommands(t *testing.T) {
+
+	d := &Devfile220{}
+
+	err := d.AddCommands([]common.DevfileCommand{
+		{Exec: &common.Exec{Id: "build", CommandLine: "npm install"}},
+		{Composite: &common.Composite{Id: "all", Commands: []string{"build"}}},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	err = d.AddCommands([]common.DevfileCommand{{Exec: &common.Exec{Id: "BUILD"}}})
+	if _, ok := err.(*common.FieldAlreadyExistError); !ok {
+		t.Errorf("expected a FieldAlreadyExistError, got '%v'", err)
+	}
+
+	err = d.UpdateCommand(common.DevfileCommand{Exec: &common.Exec{Id: "build", CommandLine: "npm ci"}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if d.GetCommands()[0].Exec.CommandLine != "npm ci" {
+		t.Errorf("command not updated, got '%s'", d.GetCommands()[0].Exec.CommandLine)
+	}
+
+	if err = d.DeleteCommand("all"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(d.GetCommands()) != 1 {
+		t.Errorf("expected 1 command, got %d", len(d.GetCommands()))
+	}
+}
+
+func TestAddAndUpdateProjectsAndEvents(t *testing.T) {
+
+	d := &Devfile220{}
+
+	if err := d.AddProjects([]common.DevfileProject{{Name: "nodejs-starter"}}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, ok := d.AddProjects([]common.DevfileProject{{Name: "nodejs-starter"}}).(*common.FieldAlreadyExistError); !ok {
+		t.Errorf("expected a FieldAlreadyExistError when adding an existing project")
+	}
+	if err := d.UpdateProject(common.DevfileProject{Name: "nodejs-starter", ClonePath: "src"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if d.GetProjects()[0].ClonePath != "src" {
+		t.Errorf("project not updated")
+	}
+	if err := d.DeleteProject("nodejs-starter"); err != nil || len(d.GetProjects()) != 0 {
+		t.Errorf("project not deleted, error '%v'", err)
+	}
+
+	if err := d.AddEvents(common.DevfileEvents{PostStart: []string{"build"}}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, ok := d.AddEvents(common.DevfileEvents{PostStart: []string{"build"}}).(*common.FieldAlreadyExistError); !ok {
+		t.Errorf("expected a FieldAlreadyExistError when binding a command twice to an event")
+	}
+	if err := d.UpdateEvents(common.DevfileEvents{PreStop: []string{"clean"}}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	events := d.GetEvents()
+	if len(events.PostStart) != 1 || len(events.PreStop) != 1 {
+		t.Errorf("unexpected events '%v'", events)
+	}
+
+	if err := d.SetMetadata(common.DevfileMetadata{Name: "nodejs", Version: "1.0.0"}); err != nil || d.GetMetadata().Name != "nodejs" {
+		t.Errorf("metadata not set, error '%v'", err)
+	}
+}
+
+func TestGetVariablesAndStarterProjects(t *testing.T) {
+
+	d := &Devfile220{
+		Variables:       map[string]string{"version": "14"},
+		Attributes:      map[string]interface{}{"alpha.build-timeout": "10m"},
+		StarterProjects: []common.DevfileStarterProject{{Name: "nodejs-starter", SubDir: "app"}},
+	}
+
+	if d.GetVariables()["version"] != "14" {
+		t.Errorf("unexpected variables '%v'", d.GetVariables())
+	}
+	if d.GetAttributes()["alpha.build-timeout"] != "10m" {
+		t.Errorf("unexpected attributes '%v'", d.GetAttributes())
+	}
+	if starterProjects := d.GetStarterProjects(); len(starterProjects) != 1 || starterProjects[0].SubDir != "app" {
+		t.Errorf("unexpected starter projects '%v'", starterProjects)
+	}
+}
+
+func TestAddUpdateDeleteStarterProjectsVariablesAndAttributes(t *testing.T) {
+
+	d := &Devfile220{}
+
+	if err := d.AddStarterProjects([]common.DevfileStarterProject{{Name: "nodejs-starter"}}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, ok := d.AddStarterProjects([]common.DevfileStarterProject{{Name: "Nodejs-Starter"}}).(*common.FieldAlreadyExistError); !ok {
+		t.Errorf("expected a FieldAlreadyExistError when adding an existing starter project")
+	}
+	if err := d.UpdateStarterProject(common.DevfileStarterProject{Name: "nodejs-starter", SubDir: "app"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if d.GetStarterProjects()[0].SubDir != "app" {
+		t.Errorf("starter project not updated")
+	}
+	if _, ok := d.UpdateStarterProject(common.DevfileStarterProject{Name: "missing"}).(*common.FieldNotFoundError); !ok {
+		t.Errorf("expected a FieldNotFoundError when updating a missing starter project")
+	}
+	if err := d.DeleteStarterProject("nodejs-starter"); err != nil || len(d.GetStarterProjects()) != 0 {
+		t.Errorf("starter project not deleted, error '%v'", err)
+	}
+
+	if err := d.AddVariables(map[string]string{"version": "14"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, ok := d.AddVariables(map[string]string{"version": "16", "mode": "dev"}).(*common.FieldAlreadyExistError); !ok || len(d.GetVariables()) != 1 {
+		t.Errorf("expected a FieldAlreadyExistError adding nothing when adding an existing variable")
+	}
+	if _, ok := d.UpdateVariables(map[string]string{"version": "16", "mode": "dev"}).(*common.FieldNotFoundError); !ok || d.GetVariables()["version"] != "14" {
+		t.Errorf("expected a FieldNotFoundError updating nothing when updating a missing variable")
+	}
+	if err := d.UpdateVariables(map[string]string{"version": "16"}); err != nil || d.GetVariables()["version"] != "16" {
+		t.Errorf("variable not updated, error '%v'", err)
+	}
+	if err := d.DeleteVariable("version"); err != nil || len(d.GetVariables()) != 0 {
+		t.Errorf("variable not deleted, error '%v'", err)
+	}
+	if _, ok := d.DeleteVariable("version").(*common.FieldNotFoundError); !ok {
+		t.Errorf("expected a FieldNotFoundError when deleting a missing variable")
+	}
+
+	if err := d.AddAttributes(map[string]interface{}{"alpha.build-timeout": "10m"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, ok := d.AddAttributes(map[string]interface{}{"alpha.build-timeout": "5m"}).(*common.FieldAlreadyExistError); !ok {
+		t.Errorf("expected a FieldAlreadyExistError when adding an existing attribute")
+	}
+	if err := d.UpdateAttributes(map[string]interface{}{"alpha.build-timeout": 300}); err != nil || d.GetAttributes()["alpha.build-timeout"] != 300 {
+		t.Errorf("attribute not updated, error '%v'", err)
+	}
+	if _, ok := d.UpdateAttributes(map[string]interface{}{"missing": true}).(*common.FieldNotFoundError); !ok {
+		t.Errorf("expected a FieldNotFoundError when updating a missing attribute")
+	}
+	if err := d.DeleteAttribute("alpha.build-timeout"); err != nil || len(d.GetAttributes()) != 0 {
+		t.Errorf("attribute not deleted, error '%v'", err)
+	}
+}
@@ -0,0 +1,1430 @@
+package version220
+
+const JsonSchema220 = `{
+	"description": "Devfile schema.",
+	"properties": {
+		"commands": {
+			"description": "Predefined, ready-to-use, workspace-related commands",
+			"items": {
+				"properties": {
+					"id": {
+						"description": "Mandatory identifier that allows referencing this command in composite commands, from a parent, or in events.",
+						"type": "string"
+					},
+					"composite": {
+						"description": "Composite command",
+						"properties": {
+							"attributes": {
+								"description": "Optional map of free-form additional command attributes",
+								"type": "object"
+							},
+							"commands": {
+								"description": "The commands that comprise this composite command",
+								"items": {
+									"type": "string"
+								},
+								"type": "array"
+							},
+							"group": {
+								"description": "Defines the group this command is part of",
+								"properties": {
+									"isDefault": {
+										"description": "Identifies the default command for a given group kind",
+										"type": "boolean"
+									},
+									"kind": {
+										"description": "Kind of group the command is part of",
+										"enum": [
+											"build",
+											"run",
+											"test",
+											"debug",
+											"deploy"
+										],
+										"type": "string"
+									}
+								},
+								"required": [
+									"kind"
+								],
+								"type": "object"
+							},
+							"label": {
+								"description": "Optional label that provides a label for this command to be used in Editor UI menus for example",
+								"type": "string"
+							},
+							"parallel": {
+								"type": "boolean"
+							}
+						},
+						"type": "object"
+					},
+					"custom": {
+						"description": "Custom command",
+						"properties": {
+							"attributes": {
+								"description": "Optional map of free-form additional command attributes",
+								"type": "object"
+							},
+							"commandClass": {
+								"type": "string"
+							},
+							"embeddedResource": {
+								"type": "object"
+							},
+							"group": {
+								"description": "Defines the group this command is part of",
+								"properties": {
+									"isDefault": {
+										"description": "Identifies the default command for a given group kind",
+										"type": "boolean"
+									},
+									"kind": {
+										"description": "Kind of group the command is part of",
+										"enum": [
+											"build",
+											"run",
+											"test",
+											"debug",
+											"deploy"
+										],
+										"type": "string"
+									}
+								},
+								"required": [
+									"kind"
+								],
+								"type": "object"
+							},
+							"label": {
+								"description": "Optional label that provides a label for this command to be used in Editor UI menus for example",
+								"type": "string"
+							}
+						},
+						"required": [
+							"commandClass",
+							"embeddedResource"
+						],
+						"type": "object"
+					},
+					"exec": {
+						"description": "Exec command",
+						"properties": {
+							"attributes": {
+								"description": "Optional map of free-form additional command attributes",
+								"type": "object"
+							},
+							"commandLine": {
+								"description": "The actual command-line string",
+								"type": "string"
+							},
+							"component": {
+								"description": "Describes component to which given action relates",
+								"type": "string"
+							},
+							"env": {
+								"description": "Optional list of environment variables that have to be set before running the command",
+								"items": {
+									"properties": {
+										"name": {
+											"type": "string"
+										},
+										"value": {
+											"type": "string"
+										}
+									},
+									"required": [
+										"name",
+										"value"
+									],
+									"type": "object"
+								},
+								"type": "array"
+							},
+							"group": {
+								"description": "Defines the group this command is part of",
+								"properties": {
+									"isDefault": {
+										"description": "Identifies the default command for a given group kind",
+										"type": "boolean"
+									},
+									"kind": {
+										"description": "Kind of group the command is part of",
+										"enum": [
+											"build",
+											"run",
+											"test",
+											"debug",
+											"deploy"
+										],
+										"type": "string"
+									}
+								},
+								"required": [
+									"kind"
+								],
+								"type": "object"
+							},
+							"label": {
+								"description": "Optional label that provides a label for this command to be used in Editor UI menus for example",
+								"type": "string"
+							},
+							"workingDir": {
+								"description": "Working directory where the command should be executed",
+								"type": "string"
+							}
+						},
+						"required": [
+							"commandLine"
+						],
+						"type": "object"
+					},
+					"type": {
+						"description": "Type of workspace command",
+						"enum": [
+							"Exec",
+							"Apply",
+							"VscodeTask",
+							"VscodeLaunch",
+							"Custom"
+						],
+						"type": "string"
+					},
+					"vscodeLaunch": {
+						"description": "VscodeLaunch command",
+						"properties": {
+							"attributes": {
+								"description": "Optional map of free-form additional command attributes",
+								"type": "object"
+							},
+							"group": {
+								"description": "Defines the group this command is part of",
+								"properties": {
+									"isDefault": {
+										"description": "Identifies the default command for a given group kind",
+										"type": "boolean"
+									},
+									"kind": {
+										"description": "Kind of group the command is part of",
+										"enum": [
+											"build",
+											"run",
+											"test",
+											"debug",
+											"deploy"
+										],
+										"type": "string"
+									}
+								},
+								"required": [
+									"kind"
+								],
+								"type": "object"
+							},
+							"inlined": {
+								"description": "Embedded content of the vscode configuration file",
+								"type": "string"
+							},
+							"locationType": {
+								"description": "Type of Vscode configuration command location",
+								"type": "string"
+							},
+							"url": {
+								"description": "Location as an absolute of relative URL",
+								"type": "string"
+							},
+							"uri": {
+								"description": "Location as an absolute of relative URI the VsCode configuration will be fetched from",
+								"type": "string"
+							}
+						},
+						"type": "object"
+					},
+					"vscodeTask": {
+						"description": "VscodeTask command",
+						"properties": {
+							"attributes": {
+								"description": "Optional map of free-form additional command attributes",
+								"type": "object"
+							},
+							"group": {
+								"description": "Defines the group this command is part of",
+								"properties": {
+									"isDefault": {
+										"description": "Identifies the default command for a given group kind",
+										"type": "boolean"
+									},
+									"kind": {
+										"description": "Kind of group the command is part of",
+										"enum": [
+											"build",
+											"run",
+											"test",
+											"debug",
+											"deploy"
+										],
+										"type": "string"
+									}
+								},
+								"required": [
+									"kind"
+								],
+								"type": "object"
+							},
+							"inlined": {
+								"description": "Embedded content of the vscode configuration file",
+								"type": "string"
+							},
+							"locationType": {
+								"description": "Type of Vscode configuration command location",
+								"type": "string"
+							},
+							"url": {
+								"description": "Location as an absolute of relative URL",
+								"type": "string"
+							},
+							"uri": {
+								"description": "Location as an absolute of relative URI the VsCode configuration will be fetched from",
+								"type": "string"
+							}
+						},
+						"type": "object"
+					},
+					"apply": {
+						"description": "Command that consists in applying a given component definition, typically bound to a devfile event",
+						"properties": {
+							"attributes": {
+								"description": "Optional map of free-form additional command attributes",
+								"type": "object"
+							},
+							"component": {
+								"description": "Describes component that will be applied",
+								"type": "string"
+							},
+							"group": {
+								"description": "Defines the group this command is part of",
+								"properties": {
+									"isDefault": {
+										"description": "Identifies the default command for a given group kind",
+										"type": "boolean"
+									},
+									"kind": {
+										"description": "Kind of group the command is part of",
+										"enum": [
+											"build",
+											"run",
+											"test",
+											"debug",
+											"deploy"
+										],
+										"type": "string"
+									}
+								},
+								"required": [
+									"kind"
+								],
+								"type": "object"
+							},
+							"label": {
+								"description": "Optional label that provides a label for this command to be used in Editor UI menus for example",
+								"type": "string"
+							}
+						},
+						"required": [
+							"component"
+						],
+						"type": "object"
+					}
+				},
+				"required": [
+					"id"
+				],
+				"type": "object"
+			},
+			"type": "array"
+		},
+		"components": {
+			"description": "List of the workspace components, such as editor and plugins, user-provided containers, or other types of components",
+			"items": {
+				"properties": {
+					"name": {
+						"description": "Mandatory name that allows referencing the component from other elements (such as commands) or from an external devfile that may reference this component through a parent or a plugin.",
+						"type": "string"
+					},
+					"cheEditor": {
+						"description": "CheEditor component",
+						"properties": {
+							"locationType": {
+								"description": "Type of plugin location",
+								"enum": [
+									"RegistryEntry",
+									"Uri"
+								],
+								"type": "string"
+							},
+							"memoryLimit": {
+								"type": "string"
+							},
+							"registryEntry": {
+								"description": "Location of an entry inside a plugin registry",
+								"properties": {
+									"baseUrl": {
+										"type": "string"
+									},
+									"id": {
+										"type": "string"
+									}
+								},
+								"required": [
+									"id"
+								],
+								"type": "object"
+							},
+							"uri": {
+								"description": "Location defined as an URI",
+								"type": "string"
+							}
+						},
+						"type": "object"
+					},
+					"chePlugin": {
+						"description": "ChePlugin component",
+						"properties": {
+							"locationType": {
+								"description": "Type of plugin location",
+								"enum": [
+									"RegistryEntry",
+									"Uri"
+								],
+								"type": "string"
+							},
+							"memoryLimit": {
+								"type": "string"
+							},
+							"registryEntry": {
+								"description": "Location of an entry inside a plugin registry",
+								"properties": {
+									"baseUrl": {
+										"type": "string"
+									},
+									"id": {
+										"type": "string"
+									}
+								},
+								"required": [
+									"id"
+								],
+								"type": "object"
+							},
+							"uri": {
+								"description": "Location defined as an URI",
+								"type": "string"
+							}
+						},
+						"type": "object"
+					},
+					"container": {
+						"description": "Container component",
+						"properties": {
+							"endpoints": {
+								"items": {
+									"properties": {
+										"attributes": {
+											"additionalProperties": {
+												"type": "string"
+											},
+											"type": "object"
+										},
+										"configuration": {
+											"properties": {
+												"cookiesAuthEnabled": {
+													"type": "boolean"
+												},
+												"discoverable": {
+													"type": "boolean"
+												},
+												"path": {
+													"type": "string"
+												},
+												"protocol": {
+													"description": "The is the low-level protocol of traffic coming through this endpoint. Default value is \"tcp\"",
+													"type": "string"
+												},
+												"public": {
+													"type": "boolean"
+												},
+												"scheme": {
+													"description": "The is the URL scheme to use when accessing the endpoint. Default value is \"http\"",
+													"type": "string"
+												},
+												"secure": {
+													"type": "boolean"
+												},
+												"type": {
+													"enum": [
+														"ide",
+														"terminal",
+														"ide-dev"
+													],
+													"type": "string"
+												}
+											},
+											"type": "object"
+										},
+										"name": {
+											"type": "string"
+										},
+										"targetPort": {
+											"type": "integer"
+										},
+										"exposure": {
+											"description": "Describes how the endpoint should be exposed on the network. public, internal or none. Default value is \"public\"",
+											"enum": [
+												"public",
+												"internal",
+												"none"
+											],
+											"type": "string"
+										},
+										"protocol": {
+											"description": "Describes the application and transport protocols of the traffic that will go through this endpoint. Default value is \"http\"",
+											"enum": [
+												"http",
+												"https",
+												"ws",
+												"wss",
+												"tcp",
+												"udp"
+											],
+											"type": "string"
+										},
+										"secure": {
+											"description": "Describes whether the endpoint should be secured and protected by some authentication process. This requires a protocol of https or wss.",
+											"type": "boolean"
+										},
+										"path": {
+											"description": "Path of the endpoint URL",
+											"type": "string"
+										}
+									},
+									"required": [
+										"name",
+										"targetPort"
+									],
+									"type": "object"
+								},
+								"type": "array"
+							},
+							"env": {
+								"description": "Environment variables used in this container",
+								"items": {
+									"properties": {
+										"name": {
+											"type": "string"
+										},
+										"value": {
+											"type": "string"
+										}
+									},
+									"required": [
+										"name",
+										"value"
+									],
+									"type": "object"
+								},
+								"type": "array"
+							},
+							"image": {
+								"type": "string"
+							},
+							"memoryLimit": {
+								"type": "string"
+							},
+							"mountSources": {
+								"type": "boolean"
+							},
+							"sourceMapping": {
+								"description": "Optional specification of the path in the container where project sources should be transferred/mounted when mountSources is true. When omitted, the value of the PROJECTS_ROOT environment variable is used.",
+								"type": "string"
+							},
+							"volumeMounts": {
+								"description": "List of volumes mounts that should be mounted is this container.",
+								"items": {
+									"description": "Volume that should be mounted to a component container",
+									"properties": {
+										"name": {
+											"description": "The volume mount name is the name of an existing Volume component. If no corresponding Volume component exist it is implicitly added. If several containers mount the same volume name then they will reuse the same volume and will be able to access to the same files.",
+											"type": "string"
+										},
+										"path": {
+											"description": "The path in the component container where the volume should be mounted",
+											"type": "string"
+										}
+									},
+									"required": [
+										"name",
+										"path"
+									],
+									"type": "object"
+								},
+								"type": "array"
+							},
+							"annotation": {
+								"description": "Annotations that should be added to specific resources for this container",
+								"properties": {
+									"deployment": {
+										"additionalProperties": {
+											"type": "string"
+										},
+										"description": "Annotations to be added to deployment",
+										"type": "object"
+									},
+									"service": {
+										"additionalProperties": {
+											"type": "string"
+										},
+										"description": "Annotations to be added to service",
+										"type": "object"
+									}
+								},
+								"type": "object"
+							},
+							"cpuLimit": {
+								"type": "string"
+							},
+							"cpuRequest": {
+								"type": "string"
+							},
+							"memoryRequest": {
+								"type": "string"
+							}
+						},
+						"required": [
+							"image"
+						],
+						"type": "object"
+					},
+					"custom": {
+						"description": "Custom component",
+						"properties": {
+							"componentClass": {
+								"type": "string"
+							},
+							"embeddedResource": {
+								"type": "object"
+							}
+						},
+						"required": [
+							"componentClass",
+							"embeddedResource"
+						],
+						"type": "object"
+					},
+					"kubernetes": {
+						"description": "Kubernetes component",
+						"properties": {
+							"endpoints": {
+								"items": {
+									"properties": {
+										"attributes": {
+											"additionalProperties": {
+												"type": "string"
+											},
+											"type": "object"
+										},
+										"configuration": {
+											"properties": {
+												"cookiesAuthEnabled": {
+													"type": "boolean"
+												},
+												"discoverable": {
+													"type": "boolean"
+												},
+												"path": {
+													"type": "string"
+												},
+												"protocol": {
+													"description": "The is the low-level protocol of traffic coming through this endpoint. Default value is \"tcp\"",
+													"type": "string"
+												},
+												"public": {
+													"type": "boolean"
+												},
+												"scheme": {
+													"description": "The is the URL scheme to use when accessing the endpoint. Default value is \"http\"",
+													"type": "string"
+												},
+												"secure": {
+													"type": "boolean"
+												},
+												"type": {
+													"enum": [
+														"ide",
+														"terminal",
+														"ide-dev"
+													],
+													"type": "string"
+												}
+											},
+											"type": "object"
+										},
+										"name": {
+											"type": "string"
+										},
+										"targetPort": {
+											"type": "integer"
+										},
+										"exposure": {
+											"description": "Describes how the endpoint should be exposed on the network. public, internal or none. Default value is \"public\"",
+											"enum": [
+												"public",
+												"internal",
+												"none"
+											],
+											"type": "string"
+										},
+										"protocol": {
+											"description": "Describes the application and transport protocols of the traffic that will go through this endpoint. Default value is \"http\"",
+											"enum": [
+												"http",
+												"https",
+												"ws",
+												"wss",
+												"tcp",
+												"udp"
+											],
+											"type": "string"
+										},
+										"secure": {
+											"description": "Describes whether the endpoint should be secured and protected by some authentication process. This requires a protocol of https or wss.",
+											"type": "boolean"
+										},
+										"path": {
+											"description": "Path of the endpoint URL",
+											"type": "string"
+										}
+									},
+									"required": [
+										"name",
+										"targetPort"
+									],
+									"type": "object"
+								},
+								"type": "array"
+							},
+							"inlined": {
+								"description": "Reference to the plugin definition",
+								"type": "string"
+							},
+							"locationType": {
+								"description": "Type of Kubernetes-like location",
+								"type": "string"
+							},
+							"url": {
+								"description": "Location in a plugin registry",
+								"type": "string"
+							},
+							"uri": {
+								"description": "Location in a file fetched from a uri.",
+								"type": "string"
+							}
+						},
+						"type": "object"
+					},
+					"openshift": {
+						"description": "Openshift component",
+						"properties": {
+							"endpoints": {
+								"items": {
+									"properties": {
+										"attributes": {
+											"additionalProperties": {
+												"type": "string"
+											},
+											"type": "object"
+										},
+										"configuration": {
+											"properties": {
+												"cookiesAuthEnabled": {
+													"type": "boolean"
+												},
+												"discoverable": {
+													"type": "boolean"
+												},
+												"path": {
+													"type": "string"
+												},
+												"protocol": {
+													"description": "The is the low-level protocol of traffic coming through this endpoint. Default value is \"tcp\"",
+													"type": "string"
+												},
+												"public": {
+													"type": "boolean"
+												},
+												"scheme": {
+													"description": "The is the URL scheme to use when accessing the endpoint. Default value is \"http\"",
+													"type": "string"
+												},
+												"secure": {
+													"type": "boolean"
+												},
+												"type": {
+													"enum": [
+														"ide",
+														"terminal",
+														"ide-dev"
+													],
+													"type": "string"
+												}
+											},
+											"type": "object"
+										},
+										"name": {
+											"type": "string"
+										},
+										"targetPort": {
+											"type": "integer"
+										},
+										"exposure": {
+											"description": "Describes how the endpoint should be exposed on the network. public, internal or none. Default value is \"public\"",
+											"enum": [
+												"public",
+												"internal",
+												"none"
+											],
+											"type": "string"
+										},
+										"protocol": {
+											"description": "Describes the application and transport protocols of the traffic that will go through this endpoint. Default value is \"http\"",
+											"enum": [
+												"http",
+												"https",
+												"ws",
+												"wss",
+												"tcp",
+												"udp"
+											],
+											"type": "string"
+										},
+										"secure": {
+											"description": "Describes whether the endpoint should be secured and protected by some authentication process. This requires a protocol of https or wss.",
+											"type": "boolean"
+										},
+										"path": {
+											"description": "Path of the endpoint URL",
+											"type": "string"
+										}
+									},
+									"required": [
+										"name",
+										"targetPort"
+									],
+									"type": "object"
+								},
+								"type": "array"
+							},
+							"inlined": {
+								"description": "Reference to the plugin definition",
+								"type": "string"
+							},
+							"locationType": {
+								"description": "Type of Kubernetes-like location",
+								"type": "string"
+							},
+							"url": {
+								"description": "Location in a plugin registry",
+								"type": "string"
+							},
+							"uri": {
+								"description": "Location in a file fetched from a uri.",
+								"type": "string"
+							}
+						},
+						"type": "object"
+					},
+					"type": {
+						"description": "Type of project source",
+						"enum": [
+							"Container",
+							"Kubernetes",
+							"Openshift",
+							"CheEditor",
+							"Volume",
+							"ChePlugin",
+							"Custom",
+							"Dockerfile",
+							"Image"
+						],
+						"type": "string"
+					},
+					"volume": {
+						"description": "Volume component",
+						"properties": {
+							"size": {
+								"description": "Size of the volume",
+								"type": "string"
+							}
+						},
+						"type": "object"
+					},
+					"attributes": {
+						"description": "Map of implementation-dependant free-form YAML attributes",
+						"type": "object"
+					},
+					"dockerfile": {
+						"description": "Dockerfile component",
+						"properties": {
+							"source": {
+								"sourceDir": {
+									"description": "path of source directory to establish build context",
+									"type": "string"
+								},
+								"location": {
+									"description": "location of the source code repostory",
+									"type": "string"
+								},
+								"type": "object"
+							},
+							"dockerfileLocation": {
+								"description": "path to dockerfile",
+								"type": "string"
+							},
+							"destination": {
+								"description": "path to registry where the build image is to be pushed",
+								"type": "string"
+							}
+						},
+						"required": [
+							"dockerfileLocation",
+							"source"
+						],
+						"type": "object"
+					},
+					"image": {
+						"description": "Allows specifying the definition of an image for outer loop builds",
+						"properties": {
+							"dockerfile": {
+								"description": "Allows specifying dockerfile type build",
+								"properties": {
+									"args": {
+										"description": "The arguments to supply to the dockerfile build.",
+										"items": {
+											"type": "string"
+										},
+										"type": "array"
+									},
+									"buildContext": {
+										"description": "Path of source directory to establish build context. Defaults to ${PROJECT_ROOT} in the container",
+										"type": "string"
+									},
+									"rootRequired": {
+										"description": "Specify if a privileged builder pod is required.",
+										"type": "boolean"
+									},
+									"uri": {
+										"description": "URI Reference of a Dockerfile. It can be a full URL or a relative URI from the current devfile as the base URI.",
+										"type": "string"
+									}
+								},
+								"required": [
+									"uri"
+								],
+								"type": "object"
+							},
+							"imageName": {
+								"description": "Name of the image for the resulting outerloop build",
+								"type": "string"
+							}
+						},
+						"required": [
+							"imageName"
+						],
+						"type": "object"
+					}
+				},
+				"required": [
+					"name"
+				],
+				"type": "object"
+			},
+			"type": "array"
+		},
+		"events": {
+			"description": "Bindings of commands to events. Each command is referred-to by its name.",
+			"properties": {
+				"postStart": {
+					"description": "Names of commands that should be executed after the workspace is completely started. In the case of Che-Theia, these commands should be executed after all plugins and extensions have started, including project cloning. This means that those commands are not triggered until the user opens the IDE in his browser.",
+					"items": {
+						"type": "string"
+					},
+					"type": "array"
+				},
+				"postStop": {
+					"description": "Names of commands that should be executed after stopping the workspace.",
+					"items": {
+						"type": "string"
+					},
+					"type": "array"
+				},
+				"preStart": {
+					"description": "Names of commands that should be executed before the workspace start. Kubernetes-wise, these commands would typically be executed in init containers of the workspace POD.",
+					"items": {
+						"type": "string"
+					},
+					"type": "array"
+				},
+				"preStop": {
+					"description": "Names of commands that should be executed before stopping the workspace.",
+					"items": {
+						"type": "string"
+					},
+					"type": "array"
+				}
+			},
+			"type": "object"
+		},
+		"parent": {
+			"description": "Parent workspace template",
+			"properties": {
+				"kubernetes": {
+					"description": "Reference to a Kubernetes CRD of type DevWorkspaceTemplate",
+					"properties": {
+						"name": {
+							"type": "string"
+						},
+						"namespace": {
+							"type": "string"
+						}
+					},
+					"required": [
+						"name"
+					],
+					"type": "object"
+				},
+				"locationType": {
+					"description": "Type of parent location",
+					"enum": [
+						"Uri",
+						"RegistryEntry",
+						"Kubernetes"
+					],
+					"type": "string"
+				},
+				"registryEntry": {
+					"description": "Entry in a registry (base URL + ID) that contains a Devfile yaml file",
+					"properties": {
+						"baseUrl": {
+							"type": "string"
+						},
+						"id": {
+							"type": "string"
+						}
+					},
+					"required": [
+						"id"
+					],
+					"type": "object"
+				},
+				"uri": {
+					"description": "Uri of a Devfile yaml file",
+					"type": "string"
+				}
+			},
+			"type": "object"
+		},
+		"projects": {
+			"description": "Projects worked on in the workspace, containing names and sources locations",
+			"items": {
+				"properties": {
+					"clonePath": {
+						"description": "Path relative to the root of the projects to which this project should be cloned into. This is a unix-style relative path (i.e. uses forward slashes). The path is invalid if it is absolute or tries to escape the project root through the usage of '..'. If not specified, defaults to the project name.",
+						"type": "string"
+					},
+					"custom": {
+						"description": "Project's Custom source",
+						"properties": {
+							"embeddedResource": {
+								"type": "object"
+							},
+							"projectSourceClass": {
+								"type": "string"
+							}
+						},
+						"required": [
+							"embeddedResource",
+							"projectSourceClass"
+						],
+						"type": "object"
+					},
+					"git": {
+						"description": "Project's Git source",
+						"properties": {
+							"branch": {
+								"description": "The branch to check",
+								"type": "string"
+							},
+							"checkoutFrom": {
+								"description": "Defines from what the project should be checked out. Required if there are more than one remote configured",
+								"properties": {
+									"remote": {
+										"description": "The remote name should be used as init. Required if there are more than one remote configured",
+										"type": "string"
+									},
+									"revision": {
+										"description": "The revision to checkout from. Should be branch name, tag or commit id. Default branch is used if missing or specified revision is not found.",
+										"type": "string"
+									}
+								},
+								"type": "object"
+							},
+							"location": {
+								"description": "Project's source location address. Should be URL for git and github located projects, or; file:// for zip",
+								"type": "string"
+							},
+							"remotes": {
+								"additionalProperties": {
+									"type": "string"
+								},
+								"description": "The remotes map which should be initialized in the git project. Must have at least one remote configured",
+								"type": "object"
+							},
+							"sparseCheckoutDir": {
+								"description": "Part of project to populate in the working directory.",
+								"type": "string"
+							},
+							"startPoint": {
+								"description": "The tag or commit id to reset the checked out branch to",
+								"type": "string"
+							}
+						},
+						"anyOf": [
+							{
+								"required": [
+									"location"
+								]
+							},
+							{
+								"required": [
+									"remotes"
+								]
+							}
+						],
+						"type": "object"
+					},
+					"github": {
+						"description": "Project's GitHub source",
+						"properties": {
+							"branch": {
+								"description": "The branch to check",
+								"type": "string"
+							},
+							"checkoutFrom": {
+								"description": "Defines from what the project should be checked out. Required if there are more than one remote configured",
+								"properties": {
+									"remote": {
+										"description": "The remote name should be used as init. Required if there are more than one remote configured",
+										"type": "string"
+									},
+									"revision": {
+										"description": "The revision to checkout from. Should be branch name, tag or commit id. Default branch is used if missing or specified revision is not found.",
+										"type": "string"
+									}
+								},
+								"type": "object"
+							},
+							"location": {
+								"description": "Project's source location address. Should be URL for git and github located projects, or; file:// for zip",
+								"type": "string"
+							},
+							"remotes": {
+								"additionalProperties": {
+									"type": "string"
+								},
+								"description": "The remotes map which should be initialized in the git project. Must have at least one remote configured",
+								"type": "object"
+							},
+							"sparseCheckoutDir": {
+								"description": "Part of project to populate in the working directory.",
+								"type": "string"
+							},
+							"startPoint": {
+								"description": "The tag or commit id to reset the checked out branch to",
+								"type": "string"
+							}
+						},
+						"anyOf": [
+							{
+								"required": [
+									"location"
+								]
+							},
+							{
+								"required": [
+									"remotes"
+								]
+							}
+						],
+						"type": "object"
+					},
+					"name": {
+						"description": "Project name",
+						"type": "string"
+					},
+					"sourceType": {
+						"description": "Type of project source",
+						"enum": [
+							"Git",
+							"Github",
+							"Zip",
+							"Custom"
+						],
+						"type": "string"
+					},
+					"zip": {
+						"description": "Project's Zip source",
+						"properties": {
+							"location": {
+								"description": "Project's source location address. Should be URL for git and github located projects, or; file:// for zip",
+								"type": "string"
+							},
+							"sparseCheckoutDir": {
+								"description": "Part of project to populate in the working directory.",
+								"type": "string"
+							}
+						},
+						"required": [
+							"location"
+						],
+						"type": "object"
+					}
+				},
+				"required": [
+					"name"
+				],
+				"type": "object"
+			},
+			"type": "array"
+		},
+		"metadata": {
+			"type": "object",
+			"description": "Optional metadata",
+			"properties": {
+				"version": {
+					"type": "string",
+					"description": "Optional semver-compatible version",
+					"pattern": "^([0-9]+)\\.([0-9]+)\\.([0-9]+)(\\-[0-9a-z-]+(\\.[0-9a-z-]+)*)?(\\+[0-9A-Za-z-]+(\\.[0-9A-Za-z-]+)*)?$"
+				},
+				"name": {
+					"type": "string",
+					"description": "Optional devfile name"
+				},
+				"alpha.deployment-manifest": {
+					"type": "string",
+					"description": "Optional URL to remote Deployment Manifest"
+				},
+				"displayName": {
+					"type": "string",
+					"description": "Optional devfile display name"
+				},
+				"description": {
+					"type": "string",
+					"description": "Optional devfile description"
+				},
+				"tags": {
+					"type": "array",
+					"description": "Optional devfile tags",
+					"items": {
+						"type": "string"
+					}
+				},
+				"icon": {
+					"type": "string",
+					"description": "Optional devfile icon, can be a URI or a relative path in the project"
+				},
+				"projectType": {
+					"type": "string",
+					"description": "Optional devfile project type"
+				},
+				"language": {
+					"type": "string",
+					"description": "Optional devfile language"
+				},
+				"website": {
+					"type": "string",
+					"description": "Optional devfile website"
+				}
+			}
+		},
+		"schemaVersion": {
+			"type": "string",
+			"description": "Devfile schema version",
+			"pattern": "^([2-9]+)\\.([0-9]+)\\.([0-9]+)(\\-[0-9a-z-]+(\\.[0-9a-z-]+)*)?(\\+[0-9A-Za-z-]+(\\.[0-9A-Za-z-]+)*)?$"
+		},
+		"attributes": {
+			"description": "Map of implementation-dependant free-form YAML attributes",
+			"type": "object"
+		},
+		"variables": {
+			"additionalProperties": {
+				"type": "string"
+			},
+			"description": "Map of key-value variables used for string replacement in the devfile. Values can be referenced via {{variable-key}} to replace the corresponding value in string fields in the devfile.",
+			"type": "object"
+		},
+		"starterProjects": {
+			"description": "StarterProjects is a project that can be used as a starting point when bootstrapping new projects",
+			"items": {
+				"properties": {
+					"description": {
+						"description": "Description of a starter project",
+						"type": "string"
+					},
+					"git": {
+						"description": "Project's Git source",
+						"properties": {
+							"branch": {
+								"description": "The branch to check",
+								"type": "string"
+							},
+							"checkoutFrom": {
+								"description": "Defines from what the project should be checked out. Required if there are more than one remote configured",
+								"properties": {
+									"remote": {
+										"description": "The remote name should be used as init. Required if there are more than one remote configured",
+										"type": "string"
+									},
+									"revision": {
+										"description": "The revision to checkout from. Should be branch name, tag or commit id. Default branch is used if missing or specified revision is not found.",
+										"type": "string"
+									}
+								},
+								"type": "object"
+							},
+							"location": {
+								"description": "Project's source location address. Should be URL for git and github located projects, or; file:// for zip",
+								"type": "string"
+							},
+							"remotes": {
+								"additionalProperties": {
+									"type": "string"
+								},
+								"description": "The remotes map which should be initialized in the git project. Must have at least one remote configured",
+								"type": "object"
+							},
+							"sparseCheckoutDir": {
+								"description": "Part of project to populate in the working directory.",
+								"type": "string"
+							},
+							"startPoint": {
+								"description": "The tag or commit id to reset the checked out branch to",
+								"type": "string"
+							}
+						},
+						"anyOf": [
+							{
+								"required": [
+									"location"
+								]
+							},
+							{
+								"required": [
+									"remotes"
+								]
+							}
+						],
+						"type": "object"
+					},
+					"github": {
+						"description": "Project's GitHub source",
+						"properties": {
+							"branch": {
+								"description": "The branch to check",
+								"type": "string"
+							},
+							"checkoutFrom": {
+								"description": "Defines from what the project should be checked out. Required if there are more than one remote configured",
+								"properties": {
+									"remote": {
+										"description": "The remote name should be used as init. Required if there are more than one remote configured",
+										"type": "string"
+									},
+									"revision": {
+										"description": "The revision to checkout from. Should be branch name, tag or commit id. Default branch is used if missing or specified revision is not found.",
+										"type": "string"
+									}
+								},
+								"type": "object"
+							},
+							"location": {
+								"description": "Project's source location address. Should be URL for git and github located projects, or; file:// for zip",
+								"type": "string"
+							},
+							"remotes": {
+								"additionalProperties": {
+									"type": "string"
+								},
+								"description": "The remotes map which should be initialized in the git project. Must have at least one remote configured",
+								"type": "object"
+							},
+							"sparseCheckoutDir": {
+								"description": "Part of project to populate in the working directory.",
+								"type": "string"
+							},
+							"startPoint": {
+								"description": "The tag or commit id to reset the checked out branch to",
+								"type": "string"
+							}
+						},
+						"anyOf": [
+							{
+								"required": [
+									"location"
+								]
+							},
+							{
+								"required": [
+									"remotes"
+								]
+							}
+						],
+						"type": "object"
+					},
+					"name": {
+						"description": "Project name",
+						"type": "string"
+					},
+					"subDir": {
+						"description": "Sub-directory from a starter project to be used as root for starter project.",
+						"type": "string"
+					},
+					"zip": {
+						"description": "Project's Zip source",
+						"properties": {
+							"location": {
+								"description": "Project's source location address. Should be URL for git and github located projects, or; file:// for zip",
+								"type": "string"
+							},
+							"sparseCheckoutDir": {
+								"description": "Part of project to populate in the working directory.",
+								"type": "string"
+							}
+						},
+						"required": [
+							"location"
+						],
+						"type": "object"
+					}
+				},
+				"required": [
+					"name"
+				],
+				"type": "object"
+			},
+			"type": "array"
+		}
+	},
+	"type": "object",
+	"required": [
+		"schemaVersion"
+	]
+}`
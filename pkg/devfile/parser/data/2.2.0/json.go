@@ -0,0 +1,42 @@
+package version220
+
+import "encoding/json"
+
+// MarshalJSON encodes the devfile with the names of its components and the ids of its commands set
+// at the top level of each item, as laid out by the 2.2.0 schema, e.g. { "name": "runtime", "container": { ... } }
+func (d Devfile220) MarshalJSON() ([]byte, error) {
+	type devfile Devfile220
+	content, err := json.Marshal(devfile(d))
+	if err != nil {
+		return nil, err
+	}
+
+	var m map[string]interface{}
+	if err := json.Unmarshal(content, &m); err != nil {
+		return nil, err
+	}
+	liftItemKeys(m["components"], "name")
+	liftItemKeys(m["commands"], "id")
+	return json.Marshal(m)
+}
+
+// liftItemKeys moves the given key of the items from their kind, e.g. "container", to their top level
+func liftItemKeys(items interface{}, key string) {
+	list, _ := items.([]interface{})
+	for _, value := range list {
+		item, ok := value.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		for kind, member := range item {
+			fields, ok := member.(map[string]interface{})
+			if !ok || kind == "attributes" {
+				continue
+			}
+			if k, ok := fields[key]; ok {
+				item[key] = k
+				delete(fields, key)
+			}
+		}
+	}
+}
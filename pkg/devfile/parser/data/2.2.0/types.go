@@ -0,0 +1,50 @@
+package version220
+
+import "github.com/devfile/parser/pkg/devfile/parser/data/common"
+
+// CommandGroupType describes the kind of command group.
+// +kubebuilder:validation:Enum=build;run;test;debug;deploy
+type CommandGroupType string
+
+const (
+	BuildCommandGroupType  CommandGroupType = "build"
+	RunCommandGroupType    CommandGroupType = "run"
+	TestCommandGroupType   CommandGroupType = "test"
+	DebugCommandGroupType  CommandGroupType = "debug"
+	DeployCommandGroupType CommandGroupType = "deploy"
+)
+
+// Devfile220 Devfile schema.
+type Devfile220 struct {
+
+	// Map of implementation-dependant free-form YAML attributes
+	Attributes map[string]interface{} `json:"attributes,omitempty"`
+
+	// Predefined, ready-to-use, workspace-related commands
+	Commands []common.DevfileCommand `json:"commands,omitempty"`
+
+	// List of the workspace components, such as editor and plugins, user-provided containers, or other types of components
+	Components []common.DevfileComponent `json:"components,omitempty"`
+
+	// Bindings of commands to events. Each command is referred-to by its name.
+	Events common.DevfileEvents `json:"events,omitempty"`
+
+	// Optional metadata
+	Metadata common.DevfileMetadata `json:"metadata,omitempty"`
+
+	// Parent workspace template
+	Parent common.DevfileParent `json:"parent,omitempty"`
+
+	// Projects worked on in the workspace, containing names and sources locations
+	Projects []common.DevfileProject `json:"projects,omitempty"`
+
+	// Devfile schema version
+	SchemaVersion string `json:"schemaVersion"`
+
+	// StarterProjects is a project that can be used as a starting point when bootstrapping new projects
+	StarterProjects []common.DevfileStarterProject `json:"starterProjects,omitempty"`
+
+	// Map of key-value variables used for string replacement in the devfile. Values can be referenced via {{variable-key}}
+	// to replace the corresponding value in string fields in the devfile.
+	Variables map[string]string `json:"variables,omitempty"`
+}
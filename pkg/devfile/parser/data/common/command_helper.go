@@ -0,0 +1,106 @@
+package common
+
+import "encoding/json"
+
+// GetCommandType returns the kind of the command
+func (dc DevfileCommand) GetCommandType() DevfileCommandType {
+	switch {
+	case dc.Exec != nil:
+		return ExecCommandType
+	case dc.Composite != nil:
+		return CompositeCommandType
+	case dc.VscodeTask != nil:
+		return VscodeTaskCommandType
+	case dc.VscodeLaunch != nil:
+		return VscodeLaunchCommandType
+	case dc.Apply != nil:
+		return ApplyCommandType
+	}
+	return UnknownCommandType
+}
+
+// GetId returns the id of the command, whatever its kind
+func (dc DevfileCommand) GetId() string {
+	switch {
+	case dc.Exec != nil:
+		return dc.Exec.Id
+	case dc.Composite != nil:
+		return dc.Composite.Id
+	case dc.VscodeTask != nil:
+		return dc.VscodeTask.Id
+	case dc.VscodeLaunch != nil:
+		return dc.VscodeLaunch.Id
+	case dc.Apply != nil:
+		return dc.Apply.Id
+	}
+	return ""
+}
+
+// SetId sets the id of the command, whatever its kind
+func (dc DevfileCommand) SetId(id string) {
+	switch {
+	case dc.Exec != nil:
+		dc.Exec.Id = id
+	case dc.Composite != nil:
+		dc.Composite.Id = id
+	case dc.VscodeTask != nil:
+		dc.VscodeTask.Id = id
+	case dc.VscodeLaunch != nil:
+		dc.VscodeLaunch.Id = id
+	case dc.Apply != nil:
+		dc.Apply.Id = id
+	}
+}
+
+// GetGroup returns the group the command belongs to, or nil if the command has no group
+func (dc DevfileCommand) GetGroup() *Group {
+	switch {
+	case dc.Exec != nil:
+		return dc.Exec.Group
+	case dc.Composite != nil:
+		return dc.Composite.Group
+	case dc.VscodeTask != nil:
+		return dc.VscodeTask.Group
+	case dc.VscodeLaunch != nil:
+		return dc.VscodeLaunch.Group
+	case dc.Apply != nil:
+		return dc.Apply.Group
+	}
+	return nil
+}
+
+// GetAttributes returns the free-form attributes of the command
+func (dc DevfileCommand) GetAttributes() map[string]interface{} {
+	switch {
+	case dc.Exec != nil:
+		return dc.Exec.Attributes
+	case dc.Composite != nil:
+		return dc.Composite.Attributes
+	case dc.VscodeTask != nil:
+		return dc.VscodeTask.Attributes
+	case dc.VscodeLaunch != nil:
+		return dc.VscodeLaunch.Attributes
+	case dc.Apply != nil:
+		return dc.Apply.Attributes
+	}
+	return nil
+}
+
+// UnmarshalJSON decodes a command identified either inside its kind, or at its top level as laid out
+// by devfile 2.2.0, e.g. { "id": "build", "exec": { ... } }
+func (dc *DevfileCommand) UnmarshalJSON(data []byte) error {
+	type command DevfileCommand
+	var identified struct {
+		Id string `json:"id"`
+	}
+	if err := json.Unmarshal(data, (*command)(dc)); err != nil {
+		return err
+	}
+	if err := json.Unmarshal(data, &identified); err != nil {
+		return err
+	}
+	if identified.Id != "" {
+		dc.SetId(identified.Id)
+	}
+	return nil
+}
@@ -0,0 +1,109 @@
+package common
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestDevfileCommandAccessors(t *testing.T) {
+
+	group := &Group{Kind: BuildCommandGroupType, IsDefault: true}
+	attributes := map[string]interface{}{"key": "value"}
+
+	tests := []struct {
+		name     string
+		command  DevfileCommand
+		wantType DevfileCommandType
+		wantId   string
+	}{
+		{
+			name:     "exec command",
+			command:  DevfileCommand{Exec: &Exec{Id: "exec", Group: group, Attributes: attributes}},
+			wantType: ExecCommandType,
+			wantId:   "exec",
+		},
+		{
+			name:     "composite command",
+			command:  DevfileCommand{Composite: &Composite{Id: "composite", Group: group, Attributes: attributes}},
+			wantType: CompositeCommandType,
+			wantId:   "composite",
+		},
+		{
+			name:     "vscode task command",
+			command:  DevfileCommand{VscodeTask: &VscodeTask{Id: "task", Group: group, Attributes: attributes}},
+			wantType: VscodeTaskCommandType,
+			wantId:   "task",
+		},
+		{
+			name:     "vscode launch command",
+			command:  DevfileCommand{VscodeLaunch: &VscodeLaunch{Id: "launch", Group: group, Attributes: attributes}},
+			wantType: VscodeLaunchCommandType,
+			wantId:   "launch",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.command.GetCommandType(); got != tt.wantType {
+				t.Errorf("want type: '%s', got: '%s'", tt.wantType, got)
+			}
+			if got := tt.command.GetId(); got != tt.wantId {
+				t.Errorf("want id: '%s', got: '%s'", tt.wantId, got)
+			}
+			if got := tt.command.GetGroup(); got != group {
+				t.Errorf("want group: '%v', got: '%v'", group, got)
+			}
+			if got := tt.command.GetAttributes(); !reflect.DeepEqual(got, attributes) {
+				t.Errorf("want attributes: '%v', got: '%v'", attributes, got)
+			}
+
+			tt.command.SetId("renamed")
+			if got := tt.command.GetId(); got != "renamed" {
+				t.Errorf("want id: 'renamed', got: '%s'", got)
+			}
+		})
+	}
+
+	t.Run("empty command", func(t *testing.T) {
+		command := DevfileCommand{}
+		if command.GetCommandType() != UnknownCommandType || command.GetId() != "" || command.GetGroup() != nil {
+			t.Errorf("expected an empty command to have no type, id or group")
+		}
+	})
+}
+
+func TestDevfileCommandUnmarshalJSON(t *testing.T) {
+
+	tests := []struct {
+		name    string
+		content string
+		want    string
+	}{
+		{
+			name:    "command identified inside its kind",
+			content: `{"exec": {"id": "build", "commandLine": "npm install"}}`,
+			want:    "build",
+		},
+		{
+			name:    "devfile 2.2.0 command identified at its top level",
+			content: `{"id": "build", "exec": {"commandLine": "npm install"}}`,
+			want:    "build",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var command DevfileCommand
+			if err := json.Unmarshal([]byte(tt.content), &command); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got := command.GetId(); got != tt.want {
+				t.Errorf("want id: '%s', got: '%s'", tt.want, got)
+			}
+			if command.Exec.CommandLine != "npm install" {
+				t.Errorf("want command line: 'npm install', got: '%s'", command.Exec.CommandLine)
+			}
+		})
+	}
+}
@@ -0,0 +1,96 @@
+package common
+
+import "encoding/json"
+
+// DefaultMountSources is whether the project sources are mounted in a container when mountSources is omitted
+const DefaultMountSources = true
+
+// GetComponentType returns the kind of the component
+func (dc DevfileComponent) GetComponentType() DevfileComponentType {
+	switch {
+	case dc.Container != nil:
+		return ContainerComponentType
+	case dc.Kubernetes != nil:
+		return KubernetesComponentType
+	case dc.Openshift != nil:
+		return OpenshiftComponentType
+	case dc.Plugin != nil:
+		return PluginComponentType
+	case dc.Volume != nil:
+		return VolumeComponentType
+	case dc.Dockerfile != nil:
+		return DockerfileComponentType
+	case dc.Image != nil:
+		return ImageComponentType
+	}
+	return CustomComponentType
+}
+
+// GetName returns the name of the component, whatever its kind
+func (dc DevfileComponent) GetName() string {
+	switch {
+	case dc.Container != nil:
+		return dc.Container.Name
+	case dc.Kubernetes != nil:
+		return dc.Kubernetes.Name
+	case dc.Openshift != nil:
+		return dc.Openshift.Name
+	case dc.Plugin != nil:
+		return dc.Plugin.Name
+	case dc.Volume != nil:
+		return dc.Volume.Name
+	case dc.Dockerfile != nil:
+		return dc.Dockerfile.Name
+	case dc.Image != nil:
+		return dc.Image.Name
+	}
+	return ""
+}
+
+// GetMountSources returns whether the project sources are mounted in the container,
+// DefaultMountSources when mountSources is omitted
+func (c Container) GetMountSources() bool {
+	if c.MountSources == nil {
+		return DefaultMountSources
+	}
+	return *c.MountSources
+}
+
+// SetName sets the name of the component, whatever its kind
+func (dc DevfileComponent) SetName(name string) {
+	switch {
+	case dc.Container != nil:
+		dc.Container.Name = name
+	case dc.Kubernetes != nil:
+		dc.Kubernetes.Name = name
+	case dc.Openshift != nil:
+		dc.Openshift.Name = name
+	case dc.Plugin != nil:
+		dc.Plugin.Name = name
+	case dc.Volume != nil:
+		dc.Volume.Name = name
+	case dc.Dockerfile != nil:
+		dc.Dockerfile.Name = name
+	case dc.Image != nil:
+		dc.Image.Name = name
+	}
+}
+
+// UnmarshalJSON decodes a component named either inside its kind, or at its top level as laid out
+// by devfile 2.2.0, e.g. { "name": "runtime", "container": { ... } }
+func (dc *DevfileComponent) UnmarshalJSON(data []byte) error {
+	type component DevfileComponent
+	var named struct {
+		Name string `json:"name"`
+	}
+	if err := json.Unmarshal(data, (*component)(dc)); err != nil {
+		return err
+	}
+	if err := json.Unmarshal(data, &named); err != nil {
+		return err
+	}
+	if named.Name != "" {
+		dc.SetName(named.Name)
+	}
+	return nil
+}
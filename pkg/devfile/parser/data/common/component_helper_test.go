@@ -0,0 +1,122 @@
+package common
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestDevfileComponentAccessors(t *testing.T) {
+
+	tests := []struct {
+		name      string
+		component DevfileComponent
+		wantType  DevfileComponentType
+		wantName  string
+	}{
+		{
+			name:      "container component",
+			component: DevfileComponent{Container: &Container{Name: "runtime"}},
+			wantType:  ContainerComponentType,
+			wantName:  "runtime",
+		},
+		{
+			name:      "volume component",
+			component: DevfileComponent{Volume: &Volume{Name: "cache"}},
+			wantType:  VolumeComponentType,
+			wantName:  "cache",
+		},
+		{
+			name:      "dockerfile component",
+			component: DevfileComponent{Dockerfile: &Dockerfile{Name: "build"}},
+			wantType:  DockerfileComponentType,
+			wantName:  "build",
+		},
+		{
+			name:      "plugin component",
+			component: DevfileComponent{Plugin: &Plugin{Name: "java"}},
+			wantType:  PluginComponentType,
+			wantName:  "java",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.component.GetComponentType(); got != tt.wantType {
+				t.Errorf("want type: '%s', got: '%s'", tt.wantType, got)
+			}
+			if got := tt.component.GetName(); got != tt.wantName {
+				t.Errorf("want name: '%s', got: '%s'", tt.wantName, got)
+			}
+		})
+	}
+}
+
+func TestDevfileComponentUnmarshalJSON(t *testing.T) {
+
+	tests := []struct {
+		name    string
+		content string
+		want    string
+	}{
+		{
+			name:    "component named inside its kind",
+			content: `{"container": {"name": "runtime", "image": "quay.io/nodejs"}}`,
+			want:    "runtime",
+		},
+		{
+			name:    "devfile 2.2.0 component named at its top level",
+			content: `{"name": "runtime", "container": {"image": "quay.io/nodejs"}}`,
+			want:    "runtime",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var component DevfileComponent
+			if err := json.Unmarshal([]byte(tt.content), &component); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got := component.GetName(); got != tt.want {
+				t.Errorf("want name: '%s', got: '%s'", tt.want, got)
+			}
+			if component.Container.Image != "quay.io/nodejs" {
+				t.Errorf("want image: 'quay.io/nodejs', got: '%s'", component.Container.Image)
+			}
+		})
+	}
+}
+
+func TestContainerGetMountSources(t *testing.T) {
+
+	mountSources, noMountSources := true, false
+
+	tests := []struct {
+		name      string
+		container Container
+		want      bool
+	}{
+		{
+			name:      "Case 1: mountSources omitted",
+			container: Container{},
+			want:      DefaultMountSources,
+		},
+		{
+			name:      "Case 2: mountSources true",
+			container: Container{MountSources: &mountSources},
+			want:      true,
+		},
+		{
+			name:      "Case 3: mountSources false",
+			container: Container{MountSources: &noMountSources},
+			want:      false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.container.GetMountSources(); got != tt.want {
+				t.Errorf("want: %v, got: %v", tt.want, got)
+			}
+		})
+	}
+}
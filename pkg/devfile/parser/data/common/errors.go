@@ -0,0 +1,42 @@
+package common
+
+import "fmt"
+
+// FieldAlreadyExistError error returned when adding a field which already exists in the devfile
+type FieldAlreadyExistError struct {
+	// Field which already exists, e.g. component, command or project
+	Field string
+
+	// Name or id of the field
+	Name string
+}
+
+func (e *FieldAlreadyExistError) Error() string {
+	return fmt.Sprintf("%s '%s' already exists in devfile", e.Field, e.Name)
+}
+
+// FieldNotFoundError error returned when updating or deleting a field which is not present in the devfile
+type FieldNotFoundError struct {
+	// Field which is not found, e.g. component, command or project
+	Field string
+
+	// Name or id of the field
+	Name string
+}
+
+func (e *FieldNotFoundError) Error() string {
+	return fmt.Sprintf("%s '%s' is not found in devfile", e.Field, e.Name)
+}
+
+// UnsupportedOperationError error returned when an operation is not supported for the devfile version
+type UnsupportedOperationError struct {
+	// Operation which is not supported, e.g. AddComponents
+	Operation string
+
+	// Version of the devfile
+	Version string
+}
+
+func (e *UnsupportedOperationError) Error() string {
+	return fmt.Sprintf("%s is not supported for devfile version '%s'", e.Operation, e.Version)
+}
@@ -0,0 +1,35 @@
+package common
+
+import "sort"
+
+// GetLocation returns the location of the git source, or the URL of the remote the project is checked
+// out from when the source lists its remotes instead, as in devfile 2.2.0
+func (g Git) GetLocation() string {
+	return getRemoteLocation(g.Location, g.Remotes, g.CheckoutFrom)
+}
+
+// GetLocation returns the location of the GitHub source, or the URL of the remote the project is checked
+// out from when the source lists its remotes instead, as in devfile 2.2.0
+func (g Github) GetLocation() string {
+	return getRemoteLocation(g.Location, g.Remotes, g.CheckoutFrom)
+}
+
+// getRemoteLocation returns the location, or the URL of the checkout remote. Without a checkout remote,
+// the "origin" remote is used, or the first remote by name.
+func getRemoteLocation(location string, remotes map[string]string, checkoutFrom *CheckoutFrom) string {
+	if location != "" || len(remotes) == 0 {
+		return location
+	}
+	if checkoutFrom != nil && checkoutFrom.Remote != "" {
+		return remotes[checkoutFrom.Remote]
+	}
+	if url, ok := remotes["origin"]; ok {
+		return url
+	}
+	names := make([]string, 0, len(remotes))
+	for name := range remotes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return remotes[names[0]]
+}
@@ -0,0 +1,53 @@
+package common
+
+import "testing"
+
+func TestGitGetLocation(t *testing.T) {
+
+	tests := []struct {
+		name string
+		git  Git
+		want string
+	}{
+		{
+			name: "Case 1: Location",
+			git:  Git{Location: "https://github.com/odo-devfiles/nodejs-ex.git"},
+			want: "https://github.com/odo-devfiles/nodejs-ex.git",
+		},
+		{
+			name: "Case 2: Origin remote",
+			git: Git{Remotes: map[string]string{
+				"origin":   "https://github.com/odo-devfiles/nodejs-ex.git",
+				"upstream": "https://github.com/devfile/nodejs-ex.git",
+			}},
+			want: "https://github.com/odo-devfiles/nodejs-ex.git",
+		},
+		{
+			name: "Case 3: Checkout remote",
+			git: Git{
+				Remotes: map[string]string{
+					"origin":   "https://github.com/odo-devfiles/nodejs-ex.git",
+					"upstream": "https://github.com/devfile/nodejs-ex.git",
+				},
+				CheckoutFrom: &CheckoutFrom{Remote: "upstream", Revision: "main"},
+			},
+			want: "https://github.com/devfile/nodejs-ex.git",
+		},
+		{
+			name: "Case 4: First remote by name",
+			git: Git{Remotes: map[string]string{
+				"upstream": "https://github.com/devfile/nodejs-ex.git",
+				"fork":     "https://github.com/odo-devfiles/nodejs-ex.git",
+			}},
+			want: "https://github.com/odo-devfiles/nodejs-ex.git",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.git.GetLocation(); got != tt.want {
+				t.Errorf("want location: '%s', got: '%s'", tt.want, got)
+			}
+		})
+	}
+}
@@ -14,10 +14,11 @@ const (
 	CustomComponentType     DevfileComponentType = "Custom"
 
 	DockerfileComponentType DevfileComponentType = "Dockerfile"
+	ImageComponentType      DevfileComponentType = "Image"
 )
 
 // CommandGroupType describes the kind of command group.
-// +kubebuilder:validation:Enum=build;run;test;debug
+// +kubebuilder:validation:Enum=build;run;test;debug;deploy
 type DevfileCommandGroupType string
 
 const (
@@ -25,10 +26,38 @@ const (
 	RunCommandGroupType   DevfileCommandGroupType = "run"
 	TestCommandGroupType  DevfileCommandGroupType = "test"
 	DebugCommandGroupType DevfileCommandGroupType = "debug"
+	// Since 2.2.0
+	DeployCommandGroupType DevfileCommandGroupType = "deploy"
 	// To Support V1
 	InitCommandGroupType DevfileCommandGroupType = "init"
 )
 
+// DevfileCommandType describes the kind of command.
+// Only one of the following command type may be specified
+type DevfileCommandType string
+
+const (
+	ExecCommandType         DevfileCommandType = "Exec"
+	CompositeCommandType    DevfileCommandType = "Composite"
+	VscodeTaskCommandType   DevfileCommandType = "VscodeTask"
+	VscodeLaunchCommandType DevfileCommandType = "VscodeLaunch"
+	ApplyCommandType        DevfileCommandType = "Apply"
+	UnknownCommandType      DevfileCommandType = "Unknown"
+)
+
+// EndpointExposure describes the way an endpoint is exposed on the network.
+// +kubebuilder:validation:Enum=public;internal;none
+type EndpointExposure string
+
+const (
+	// Endpoint will be exposed on the public network, typically through a K8S ingress or an OpenShift route
+	PublicEndpointExposure EndpointExposure = "public"
+	// Endpoint will be exposed internally outside of the main workspace POD, typically by K8S services
+	InternalEndpointExposure EndpointExposure = "internal"
+	// Endpoint will not be exposed and will only be accessible inside the main workspace POD
+	NoneEndpointExposure EndpointExposure = "none"
+)
+
 // DevfileMetadata metadata for devfile
 type DevfileMetadata struct {
 
@@ -40,17 +69,54 @@ type DevfileMetadata struct {
 
 	// Manifest optional URL to remote Deployment Manifest
 	Manifest string `json:"alpha.deployment-manifest,omitempty"`
+
+	// DisplayName Optional devfile display name
+	DisplayName string `json:"displayName,omitempty"`
+
+	// Description Optional devfile description
+	Description string `json:"description,omitempty"`
+
+	// Tags Optional devfile tags
+	Tags []string `json:"tags,omitempty"`
+
+	// Icon Optional devfile icon, can be a URI or a relative path in the project
+	Icon string `json:"icon,omitempty"`
+
+	// ProjectType Optional devfile project type
+	ProjectType string `json:"projectType,omitempty"`
+
+	// Language Optional devfile language
+	Language string `json:"language,omitempty"`
+
+	// Website Optional devfile website
+	Website string `json:"website,omitempty"`
 }
 
 // DevfileCommand command specified in devfile
 type DevfileCommand struct {
+
+	// Composite command that allows executing several sub-commands either sequentially or concurrently
+	Composite *Composite `json:"composite,omitempty"`
+
 	// CLI Command executed in a component container
 	Exec *Exec `json:"exec,omitempty"`
+
+	// Command providing the definition of a VsCode launch action
+	VscodeLaunch *VscodeLaunch `json:"vscodeLaunch,omitempty"`
+
+	// Command providing the definition of a VsCode Task
+	VscodeTask *VscodeTask `json:"vscodeTask,omitempty"`
+
+	// Command that consists in applying a given component definition, typically bound to a devfile event
+	Apply *Apply `json:"apply,omitempty"`
 }
 
 // DevfileComponent component specified in devfile
 type DevfileComponent struct {
 
+	// Map of implementation-dependant free-form YAML attributes
+	Attributes map[string]interface{} `json:"attributes,omitempty"`
+
 	// Allows adding and configuring workspace-related containers
 	Container *Container `json:"container,omitempty"`
 
@@ -60,11 +126,78 @@ type DevfileComponent struct {
 	// Allows importing into the workspace the OpenShift resources defined in a given manifest. For example this allows reusing the OpenShift definitions used to deploy some runtime components in production.
 	Openshift *Openshift `json:"openshift,omitempty"`
 
+	// Allows importing a plugin. Plugins are mainly imported devfiles that contribute components, commands and events as a consistent single unit. They are defined in either YAML files following the devfile syntax, or as `DevWorkspaceTemplate` Kubernetes Custom Resources
+	Plugin *Plugin `json:"plugin,omitempty"`
+
 	// Allows specifying the definition of a volume shared by several other components
 	Volume *Volume `json:"volume,omitempty"`
 
 	// Allows specifying a dockerfile to initiate build
 	Dockerfile *Dockerfile `json:"dockerfile,omitempty"`
+
+	// Allows specifying the definition of an image for outer loop builds
+	Image *Image `json:"image,omitempty"`
+}
+
+// Annotation specifies the annotations added to specific resources for a container
+type Annotation struct {
+
+	// Annotations to be added to deployment
+	Deployment map[string]string `json:"deployment,omitempty"`
+
+	// Annotations to be added to service
+	Service map[string]string `json:"service,omitempty"`
+}
+
+// Apply Command that consists in applying a given component definition, typically bound to a devfile event
+type Apply struct {
+
+	// Optional map of free-form additional command attributes
+	Attributes map[string]interface{} `json:"attributes,omitempty"`
+
+	// Describes component that will be applied
+	Component string `json:"component"`
+
+	// Defines the group this command is part of
+	Group *Group `json:"group,omitempty"`
+
+	// Mandatory identifier that allows referencing this command in composite commands, or from a parent, or in events.
+	Id string `json:"id"`
+
+	// Optional label that provides a label for this command to be used in Editor UI menus for example
+	Label string `json:"label,omitempty"`
+}
+
+// CheckoutFrom Defines from what the project should be checked out
+type CheckoutFrom struct {
+
+	// The remote name should be used as init. Required if there are more than one remote configured
+	Remote string `json:"remote,omitempty"`
+
+	// The revision to checkout from. Should be branch name, tag or commit id. Default branch is used if missing or specified revision is not found.
+	Revision string `json:"revision,omitempty"`
+}
+
+// Composite Composite command that allows executing several sub-commands either sequentially or concurrently
+type Composite struct {
+
+	// Optional map of free-form additional command attributes
+	Attributes map[string]interface{} `json:"attributes,omitempty"`
+
+	// The commands that comprise this composite command
+	Commands []string `json:"commands,omitempty"`
+
+	// Defines the group this command is part of
+	Group *Group `json:"group,omitempty"`
+
+	// Mandatory identifier that allows referencing this command in composite commands, or from a parent, or in events.
+	Id string `json:"id"`
+
+	// Optional label that provides a label for this command to be used in Editor UI menus for example
+	Label string `json:"label,omitempty"`
+
+	// Indicates if the sub-commands should be executed concurrently
+	Parallel bool `json:"parallel,omitempty"`
 }
 
 // Configuration
@@ -86,20 +219,29 @@ type Configuration struct {
 // Container Allows adding and configuring workspace-related containers
 type Container struct {
 
+	// Annotations that should be added to specific resources for this container
+	Annotation *Annotation `json:"annotation,omitempty"`
+
 	// The arguments to supply to the command running the dockerimage component. The arguments are supplied either to the default command provided in the image or to the overridden command. Defaults to an empty array, meaning use whatever is defined in the image.
 	Args []string `json:"args,omitempty"`
 
 	// The command to run in the dockerimage component instead of the default one provided in the image. Defaults to an empty array, meaning use whatever is defined in the image.
 	Command []string `json:"command,omitempty"`
 
+	CpuLimit   string `json:"cpuLimit,omitempty"`
+	CpuRequest string `json:"cpuRequest,omitempty"`
+
 	Endpoints []Endpoint `json:"endpoints,omitempty"`
 
 	// Environment variables used in this container
-	Env          []Env  `json:"env,omitempty"`
-	Image        string `json:"image,omitempty"`
-	MemoryLimit  string `json:"memoryLimit,omitempty"`
-	MountSources bool   `json:"mountSources,omitempty"`
-	Name         string `json:"name"`
+	Env           []Env  `json:"env,omitempty"`
+	Image         string `json:"image,omitempty"`
+	MemoryLimit   string `json:"memoryLimit,omitempty"`
+	MemoryRequest string `json:"memoryRequest,omitempty"`
+	Name          string `json:"name"`
+
+	// Toggles whether or not the project source code should be mounted in the component. Defaults to true when omitted.
+	MountSources *bool `json:"mountSources,omitempty"`
 
 	// Optional specification of the path in the container where project sources should be transferred/mounted when `mountSources` is `true`. When omitted, the value of the `PROJECTS_ROOT` environment variable is used.
 	SourceMapping string `json:"sourceMapping,omitempty"`
@@ -112,8 +254,20 @@ type Container struct {
 type Endpoint struct {
 	Attributes    map[string]string `json:"attributes,omitempty"`
 	Configuration *Configuration    `json:"configuration,omitempty"`
-	Name          string            `json:"name"`
-	TargetPort    int32             `json:"targetPort"`
+
+	// Describes how the endpoint should be exposed on the network. Default value is "public"
+	Exposure EndpointExposure `json:"exposure,omitempty"`
+	Name     string           `json:"name"`
+
+	// Path of the endpoint URL
+	Path string `json:"path,omitempty"`
+
+	// Describes the application and transport protocols of the traffic that will go through this endpoint. Default value is "http"
+	Protocol string `json:"protocol,omitempty"`
+
+	// Describes whether the endpoint should be secured and protected by some authentication process
+	Secure     bool  `json:"secure,omitempty"`
+	TargetPort int32 `json:"targetPort"`
 }
 
 // Env
@@ -142,7 +296,7 @@ type DevfileEvents struct {
 type Exec struct {
 
 	// Optional map of free-form additional command attributes
-	Attributes map[string]string `json:"attributes,omitempty"`
+	Attributes map[string]interface{} `json:"attributes,omitempty"`
 
 	// The actual command-line string
 	CommandLine string `json:"commandLine,omitempty"`
@@ -172,9 +326,15 @@ type Git struct {
 	// The branch to check
 	Branch string `json:"branch,omitempty"`
 
+	// Defines from what the project should be checked out. Required if there are more than one remote configured
+	CheckoutFrom *CheckoutFrom `json:"checkoutFrom,omitempty"`
+
 	// Project's source location address. Should be URL for git and github located projects, or; file:// for zip
 	Location string `json:"location,omitempty"`
 
+	// The remotes map which should be initialized in the git project. Must have at least one remote configured
+	Remotes map[string]string `json:"remotes,omitempty"`
+
 	// Part of project to populate in the working directory.
 	SparseCheckoutDir string `json:"sparseCheckoutDir,omitempty"`
 
@@ -188,9 +348,15 @@ type Github struct {
 	// The branch to check
 	Branch string `json:"branch,omitempty"`
 
+	// Defines from what the project should be checked out. Required if there are more than one remote configured
+	CheckoutFrom *CheckoutFrom `json:"checkoutFrom,omitempty"`
+
 	// Project's source location address. Should be URL for git and github located projects, or; file:// for zip
 	Location string `json:"location,omitempty"`
 
+	// The remotes map which should be initialized in the git project. Must have at least one remote configured
+	Remotes map[string]string `json:"remotes,omitempty"`
+
 	// Part of project to populate in the working directory.
 	SparseCheckoutDir string `json:"sparseCheckoutDir,omitempty"`
 
@@ -208,15 +374,50 @@ type Group struct {
 	Kind DevfileCommandGroupType `json:"kind"`
 }
 
+// Image Allows specifying the definition of an image for outer loop builds
+type Image struct {
+
+	// Allows specifying dockerfile type build
+	Dockerfile *DockerfileImage `json:"dockerfile,omitempty"`
+
+	// Name of the image for the resulting outerloop build
+	ImageName string `json:"imageName"`
+
+	// Mandatory name that allows referencing the component in commands, or inside a parent
+	Name string `json:"name"`
+}
+
+// DockerfileImage Allows specifying dockerfile type build
+type DockerfileImage struct {
+
+	// The arguments to supply to the dockerfile build.
+	Args []string `json:"args,omitempty"`
+
+	// Path of source directory to establish build context. Defaults to ${PROJECT_ROOT} in the container
+	BuildContext string `json:"buildContext,omitempty"`
+
+	// Specify if a privileged builder pod is required.
+	RootRequired bool `json:"rootRequired,omitempty"`
+
+	// URI Reference of a Dockerfile. It can be a full URL or a relative URI from the current devfile as the base URI.
+	Uri string `json:"uri"`
+}
+
 // Kubernetes Allows importing into the workspace the Kubernetes resources defined in a given manifest. For example this allows reusing the Kubernetes definitions used to deploy some runtime components in production.
 type Kubernetes struct {
 
+	// Endpoints exposed by the resources of the component
+	Endpoints []Endpoint `json:"endpoints,omitempty"`
+
 	// Inlined manifest
 	Inlined string `json:"inlined,omitempty"`
 
 	// Mandatory name that allows referencing the component in commands, or inside a parent
 	Name string `json:"name"`
 
+	// Namespace of the referenced Kubernetes resource
+	Namespace string `json:"namespace,omitempty"`
+
 	// Location in a file fetched from a uri.
 	Uri string `json:"uri,omitempty"`
 }
@@ -224,6 +425,9 @@ type Kubernetes struct {
 // Openshift Configuration overriding for an OpenShift component
 type Openshift struct {
 
+	// Endpoints exposed by the resources of the component
+	Endpoints []Endpoint `json:"endpoints,omitempty"`
+
 	// Inlined manifest
 	Inlined string `json:"inlined,omitempty"`
 
@@ -255,12 +459,25 @@ type DevfileParent struct {
 	// Projects worked on in the workspace, containing names and sources locations
 	Projects []*DevfileProject `json:"projects,omitempty"`
 
+	// Entry in a registry (base URL + ID) that contains a Devfile yaml file
+	RegistryEntry *RegistryEntry `json:"registryEntry,omitempty"`
+
 	RegistryUrl string `json:"registryUrl,omitempty"`
 
 	// Uri of a Devfile yaml file
 	Uri string `json:"uri,omitempty"`
 }
 
+// RegistryEntry Entry in a registry (base URL + ID) that contains a Devfile yaml file
+type RegistryEntry struct {
+
+	// Base URL of the registry
+	BaseUrl string `json:"baseUrl,omitempty"`
+
+	// Id of the Devfile yaml file in the registry
+	Id string `json:"id"`
+}
+
 // Plugin Allows importing a plugin. Plugins are mainly imported devfiles that contribute components, commands and events as a consistent single unit. They are defined in either YAML files following the devfile syntax, or as `DevWorkspaceTemplate` Kubernetes Custom Resources
 type Plugin struct {
 
@@ -277,7 +494,11 @@ type Plugin struct {
 	Kubernetes *Kubernetes `json:"kubernetes,omitempty"`
 
 	// Optional name that allows referencing the component in commands, or inside a parent If omitted it will be infered from the location (uri or registryEntry)
-	Name        string `json:"name,omitempty"`
+	Name string `json:"name,omitempty"`
+
+	// Entry in a registry (base URL + ID) that contains a Devfile yaml file
+	RegistryEntry *RegistryEntry `json:"registryEntry,omitempty"`
+
 	RegistryUrl string `json:"registryUrl,omitempty"`
 
 	// Uri of a Devfile yaml file
@@ -303,6 +524,28 @@ type DevfileProject struct {
 	Zip *Zip `json:"zip,omitempty"`
 }
 
+// DevfileStarterProject project that can be used as a starting point when bootstrapping new projects
+type DevfileStarterProject struct {
+
+	// Description of a starter project
+	Description string `json:"description,omitempty"`
+
+	// Project's Git source
+	Git *Git `json:"git,omitempty"`
+
+	// Project's GitHub source
+	Github *Github `json:"github,omitempty"`
+
+	// Project name
+	Name string `json:"name"`
+
+	// Sub-directory from a starter project to be used as root for starter project.
+	SubDir string `json:"subDir,omitempty"`
+
+	// Project's Zip source
+	Zip *Zip `json:"zip,omitempty"`
+}
+
 // Volume Allows specifying the definition of a volume shared by several other components
 type Volume struct {
 
@@ -323,6 +566,44 @@ type VolumeMount struct {
 	Path string `json:"path,omitempty"`
 }
 
+// VscodeLaunch Command providing the definition of a VsCode launch action
+type VscodeLaunch struct {
+
+	// Optional map of free-form additional command attributes
+	Attributes map[string]interface{} `json:"attributes,omitempty"`
+
+	// Defines the group this command is part of
+	Group *Group `json:"group,omitempty"`
+
+	// Mandatory identifier that allows referencing this command in composite commands, or from a parent, or in events.
+	Id string `json:"id"`
+
+	// Inlined content of the VsCode configuration
+	Inlined string `json:"inlined,omitempty"`
+
+	// Location as an absolute of relative URI the VsCode configuration will be fetched from
+	Uri string `json:"uri,omitempty"`
+}
+
+// VscodeTask Command providing the definition of a VsCode Task
+type VscodeTask struct {
+
+	// Optional map of free-form additional command attributes
+	Attributes map[string]interface{} `json:"attributes,omitempty"`
+
+	// Defines the group this command is part of
+	Group *Group `json:"group,omitempty"`
+
+	// Mandatory identifier that allows referencing this command in composite commands, or from a parent, or in events.
+	Id string `json:"id"`
+
+	// Inlined content of the VsCode configuration
+	Inlined string `json:"inlined,omitempty"`
+
+	// Location as an absolute of relative URI the VsCode configuration will be fetched from
+	Uri string `json:"uri,omitempty"`
+}
+
 // Zip Project's Zip source
 type Zip struct {
 
@@ -6,6 +6,7 @@ import (
 	"testing"
 
 	v100 "github.com/devfile/parser/pkg/devfile/parser/data/1.0.0"
+	"github.com/devfile/parser/pkg/devfile/parser/data/common"
 )
 
 func TestNewDevfileData(t *testing.T) {
@@ -104,3 +105,16 @@ func TestIsApiVersionSupported(t *testing.T) {
 		}
 	})
 }
+
+func TestUnsupportedMutationsOnApiVersion100(t *testing.T) {
+
+	obj, err := NewDevfileData(string(apiVersion100))
+	if err != nil {
+		t.Fatalf("did not expect an error '%v'", err)
+	}
+
+	err = obj.AddComponents([]common.DevfileComponent{{Volume: &common.Volume{Name: "cache"}}})
+	if _, ok := err.(*common.UnsupportedOperationError); !ok {
+		t.Errorf("expected an UnsupportedOperationError, got '%v'", err)
+	}
+}
@@ -13,4 +13,17 @@ type DevfileData interface {
 	GetAliasedComponents() []common.DevfileComponent
 	GetProjects() []common.DevfileProject
 	GetCommands() []common.DevfileCommand
+
+	SetMetadata(metadata common.DevfileMetadata) error
+	AddComponents(components []common.DevfileComponent) error
+	UpdateComponent(component common.DevfileComponent) error
+	DeleteComponent(name string) error
+	AddCommands(commands []common.DevfileCommand) error
+	UpdateCommand(command common.DevfileCommand) error
+	DeleteCommand(id string) error
+	AddProjects(projects []common.DevfileProject) error
+	UpdateProject(project common.DevfileProject) error
+	DeleteProject(name string) error
+	AddEvents(events common.DevfileEvents) error
+	UpdateEvents(events common.DevfileEvents) error
 }
@@ -1,47 +1,211 @@
 package data
 
 import (
-	"reflect"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
 
 	v100 "github.com/devfile/parser/pkg/devfile/parser/data/1.0.0"
 	v200 "github.com/devfile/parser/pkg/devfile/parser/data/2.0.0"
 	v210 "github.com/devfile/parser/pkg/devfile/parser/data/2.1.0"
+	v220 "github.com/devfile/parser/pkg/devfile/parser/data/2.2.0"
+	"github.com/devfile/parser/pkg/devfile/validate"
 )
 
-// SupportedApiVersions stores the supported devfile API versions
-type supportedApiVersion string
-
 // Supported devfile API versions in odo
 const (
-	apiVersion100 supportedApiVersion = "1.0.0"
-	apiVersion200 supportedApiVersion = "2.0.0"
-	apiVersion210 supportedApiVersion = "2.1.0"
+	apiVersion100 = "1.0.0"
+	apiVersion200 = "2.0.0"
+	apiVersion210 = "2.1.0"
+	apiVersion220 = "2.2.0"
 )
 
-// List of supported devfile API versions
-var supportedApiVersionsList = []supportedApiVersion{apiVersion100, apiVersion200, apiVersion210}
+// NewDevfileDataFunc returns a new empty devfile data of a schema version
+type NewDevfileDataFunc func() DevfileData
+
+// Converter converts the decoded JSON content of a devfile from the source version to the target version,
+// reporting the elements which can't be converted as warnings. The converter of a registered version
+// converts the devfiles from and to this version.
+type Converter func(content map[string]interface{}, sourceVersion, targetVersion string, warnings *validate.ValidationResult) (map[string]interface{}, error)
 
-// ------------- Init functions ------------- //
+// devfileVersion is a registered devfile schema version
+type devfileVersion struct {
+	version    semver
+	newFunc    NewDevfileDataFunc
+	jsonSchema string
+	converter  Converter
+}
 
-// apiVersionToDevfileStruct maps supported devfile API versions to their corresponding devfile structs
-var apiVersionToDevfileStruct map[supportedApiVersion]reflect.Type
+// registry of the supported devfile schema versions, by version
+var (
+	versionsLock sync.RWMutex
+	versions     = make(map[string]devfileVersion)
+)
 
-// Initializes a map of supported devfile api versions and devfile structs
+// Initializes the registry with the devfile versions supported by the parser
 func init() {
-	apiVersionToDevfileStruct = make(map[supportedApiVersion]reflect.Type)
-	apiVersionToDevfileStruct[apiVersion100] = reflect.TypeOf(v100.Devfile100{})
-	apiVersionToDevfileStruct[apiVersion200] = reflect.TypeOf(v200.Devfile200{})
-	apiVersionToDevfileStruct[apiVersion210] = reflect.TypeOf(v210.Devfile210{})
+	mustRegisterVersion(apiVersion100, func() DevfileData { return &v100.Devfile100{} }, v100.JsonSchema100)
+	mustRegisterVersion(apiVersion200, func() DevfileData { return &v200.Devfile200{} }, v200.JsonSchema200)
+	mustRegisterVersion(apiVersion210, func() DevfileData { return &v210.Devfile210{} }, v210.JsonSchema210)
+	mustRegisterVersion(apiVersion220, func() DevfileData { return &v220.Devfile220{} }, v220.JsonSchema220)
+}
 
+// mustRegisterVersion registers a devfile version supported by the parser, the conversions
+// between these versions being implemented by the parser itself
+func mustRegisterVersion(version string, newFunc NewDevfileDataFunc, jsonSchema string) {
+	if err := RegisterVersion(version, newFunc, jsonSchema, nil); err != nil {
+		panic(err)
+	}
 }
 
-// Map to store mappings between supported devfile API versions and respective devfile JSON schemas
-var devfileApiVersionToJSONSchema map[supportedApiVersion]string
+// RegisterVersion registers a devfile schema version, e.g. an extension of a standard version with a
+// pre-release suffix such as 2.1.0-acme. The devfiles of this version are decoded into the data returned
+// by newFunc and validated against the given JSON schema. The optional converter converts these devfiles
+// from and to the other registered versions.
+func RegisterVersion(version string, newFunc NewDevfileDataFunc, jsonSchema string, converter Converter) error {
+	v, err := parseSemver(version)
+	if err != nil {
+		return err
+	}
+	if newFunc == nil {
+		return fmt.Errorf("devfile version '%s' must provide a devfile data constructor", version)
+	}
+	if jsonSchema == "" {
+		return fmt.Errorf("devfile version '%s' must provide a JSON schema", version)
+	}
 
-// init initializes a map of supported devfile apiVersions with it's respective devfile JSON schema
-func init() {
-	devfileApiVersionToJSONSchema = make(map[supportedApiVersion]string)
-	devfileApiVersionToJSONSchema[apiVersion100] = v100.JsonSchema100
-	devfileApiVersionToJSONSchema[apiVersion200] = v200.JsonSchema200
-	devfileApiVersionToJSONSchema[apiVersion210] = v210.JsonSchema210
+	versionsLock.Lock()
+	defer versionsLock.Unlock()
+	if _, ok := versions[version]; ok {
+		return fmt.Errorf("devfile version '%s' is already registered", version)
+	}
+	versions[version] = devfileVersion{version: v, newFunc: newFunc, jsonSchema: jsonSchema, converter: converter}
+	return nil
+}
+
+// SupportedVersions returns the registered devfile schema versions in ascending order
+func SupportedVersions() []string {
+	versionsLock.RLock()
+	defer versionsLock.RUnlock()
+
+	var list []devfileVersion
+	for _, v := range versions {
+		list = append(list, v)
+	}
+	sort.Slice(list, func(i, j int) bool {
+		return list[i].version.less(list[j].version)
+	})
+
+	supported := make([]string, 0, len(list))
+	for _, v := range list {
+		supported = append(supported, v.version.String())
+	}
+	return supported
+}
+
+// ResolveVersion returns the registered version handling the given devfile version. A version
+// which is not registered resolves to the registered release with the same major and minor
+// version and the highest patch version, e.g. 2.1.3 resolves to 2.1.0.
+func ResolveVersion(version string) (string, bool) {
+	v, ok := lookupVersion(version)
+	if !ok {
+		return "", false
+	}
+	return v.version.String(), true
+}
+
+// GetConverter returns the converter registered with the version, if any
+func GetConverter(version string) Converter {
+	v, ok := lookupVersion(version)
+	if !ok {
+		return nil
+	}
+	return v.converter
+}
+
+// lookupVersion returns the registered version handling the given devfile version
+func lookupVersion(version string) (devfileVersion, bool) {
+	versionsLock.RLock()
+	defer versionsLock.RUnlock()
+
+	if v, ok := versions[version]; ok {
+		return v, true
+	}
+
+	requested, err := parseSemver(version)
+	if err != nil || requested.preRelease != "" {
+		return devfileVersion{}, false
+	}
+	var match devfileVersion
+	found := false
+	for _, v := range versions {
+		if v.version.preRelease != "" || v.version.major != requested.major || v.version.minor != requested.minor {
+			continue
+		}
+		if !found || match.version.less(v.version) {
+			match, found = v, true
+		}
+	}
+	return match, found
+}
+
+// semver is a semantic version made of a major, minor and patch version and an optional pre-release
+type semver struct {
+	major, minor, patch int
+	preRelease          string
+}
+
+// parseSemver parses the MAJOR.MINOR.PATCH[-PRERELEASE] version
+func parseSemver(version string) (semver, error) {
+	var v semver
+	release := version
+	if i := strings.Index(version, "-"); i >= 0 {
+		release, v.preRelease = version[:i], version[i+1:]
+		if v.preRelease == "" {
+			return v, fmt.Errorf("invalid devfile version '%s'", version)
+		}
+	}
+
+	parts := strings.Split(release, ".")
+	if len(parts) != 3 {
+		return v, fmt.Errorf("invalid devfile version '%s', expected MAJOR.MINOR.PATCH", version)
+	}
+	numbers := make([]int, len(parts))
+	for i, part := range parts {
+		n, err := strconv.Atoi(part)
+		if err != nil || n < 0 {
+			return v, fmt.Errorf("invalid devfile version '%s', expected MAJOR.MINOR.PATCH", version)
+		}
+		numbers[i] = n
+	}
+	v.major, v.minor, v.patch = numbers[0], numbers[1], numbers[2]
+	return v, nil
+}
+
+// String returns the version in the MAJOR.MINOR.PATCH[-PRERELEASE] format
+func (v semver) String() string {
+	s := fmt.Sprintf("%d.%d.%d", v.major, v.minor, v.patch)
+	if v.preRelease != "" {
+		s += "-" + v.preRelease
+	}
+	return s
+}
+
+// less returns true if the version precedes the other one, a pre-release preceding its release
+func (v semver) less(other semver) bool {
+	if v.major != other.major {
+		return v.major < other.major
+	}
+	if v.minor != other.minor {
+		return v.minor < other.minor
+	}
+	if v.patch != other.patch {
+		return v.patch < other.patch
+	}
+	if v.preRelease == "" || other.preRelease == "" {
+		return v.preRelease != "" && other.preRelease == ""
+	}
+	return v.preRelease < other.preRelease
 }
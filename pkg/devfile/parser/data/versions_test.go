@@ -0,0 +1,142 @@
+package data
+
+import (
+	"reflect"
+	"testing"
+
+	v210 "github.com/devfile/parser/pkg/devfile/parser/data/2.1.0"
+)
+
+func TestRegisterVersion(t *testing.T) {
+
+	newFunc := func() DevfileData { return &v210.Devfile210{} }
+
+	tests := []struct {
+		name       string
+		version    string
+		newFunc    NewDevfileDataFunc
+		jsonSchema string
+		wantErr    bool
+	}{
+		{
+			name:       "Case 1: Extension version",
+			version:    "2.1.0-test",
+			newFunc:    newFunc,
+			jsonSchema: v210.JsonSchema210,
+		},
+		{
+			name:       "Case 2: Already registered version",
+			version:    apiVersion210,
+			newFunc:    newFunc,
+			jsonSchema: v210.JsonSchema210,
+			wantErr:    true,
+		},
+		{
+			name:       "Case 3: Invalid version",
+			version:    "2.1",
+			newFunc:    newFunc,
+			jsonSchema: v210.JsonSchema210,
+			wantErr:    true,
+		},
+		{
+			name:       "Case 4: Missing devfile data constructor",
+			version:    "2.1.0-nodata",
+			jsonSchema: v210.JsonSchema210,
+			wantErr:    true,
+		},
+		{
+			name:    "Case 5: Missing JSON schema",
+			version: "2.1.0-noschema",
+			newFunc: newFunc,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			defer unregisterVersion(tt.version)
+
+			err := RegisterVersion(tt.version, tt.newFunc, tt.jsonSchema, nil)
+			if tt.wantErr != (err != nil) {
+				t.Fatalf("unexpected error %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+
+			if !IsApiVersionSupported(tt.version) {
+				t.Errorf("expected version '%s' to be supported", tt.version)
+			}
+			schema, err := GetDevfileJSONSchema(tt.version)
+			if err != nil || schema != tt.jsonSchema {
+				t.Errorf("expected the registered schema, got error %v", err)
+			}
+		})
+	}
+}
+
+func TestResolveVersion(t *testing.T) {
+
+	tests := []struct {
+		name    string
+		version string
+		want    string
+		wantOk  bool
+	}{
+		{
+			name:    "Case 1: Registered version",
+			version: "2.0.0",
+			want:    "2.0.0",
+			wantOk:  true,
+		},
+		{
+			name:    "Case 2: Patch version",
+			version: "2.1.3",
+			want:    "2.1.0",
+			wantOk:  true,
+		},
+		{
+			name:    "Case 3: Unknown minor version",
+			version: "2.9.0",
+		},
+		{
+			name:    "Case 4: Unknown pre-release version",
+			version: "2.1.0-unknown",
+		},
+		{
+			name:    "Case 5: Invalid version",
+			version: "latest",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := ResolveVersion(tt.version)
+			if got != tt.want || ok != tt.wantOk {
+				t.Errorf("got: '%s' %t, want: '%s' %t", got, ok, tt.want, tt.wantOk)
+			}
+		})
+	}
+}
+
+func TestSupportedVersions(t *testing.T) {
+
+	if err := RegisterVersion("2.1.0-acme", func() DevfileData { return &v210.Devfile210{} }, v210.JsonSchema210, nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer unregisterVersion("2.1.0-acme")
+
+	want := []string{"1.0.0", "2.0.0", "2.1.0-acme", "2.1.0", "2.2.0"}
+	if got := SupportedVersions(); !reflect.DeepEqual(got, want) {
+		t.Errorf("got: '%v', want: '%v'", got, want)
+	}
+}
+
+// unregisterVersion removes the version registered by a test
+func unregisterVersion(version string) {
+	versionsLock.Lock()
+	defer versionsLock.Unlock()
+	if version != apiVersion100 && version != apiVersion200 && version != apiVersion210 && version != apiVersion220 {
+		delete(versions, version)
+	}
+}
@@ -0,0 +1,132 @@
+package parser
+
+import (
+	"strings"
+
+	"github.com/devfile/parser/pkg/devfile/parser/data/common"
+)
+
+// Default values of the omitted devfile fields, as documented by the schema
+const (
+	// DefaultConfigurationProtocol is the default low-level protocol of an endpoint configuration
+	DefaultConfigurationProtocol = "tcp"
+
+	// DefaultConfigurationScheme is the default URL scheme of an endpoint configuration
+	DefaultConfigurationScheme = "http"
+
+	// DefaultEndpointProtocol is the default protocol of an endpoint, since devfile 2.2.0
+	DefaultEndpointProtocol = "http"
+
+	// DefaultSourceMapping is the default path where the project sources are mounted, the value of PROJECTS_ROOT
+	DefaultSourceMapping = "/projects"
+
+	// DefaultMountSources is whether the project sources are mounted in a container by default
+	DefaultMountSources = common.DefaultMountSources
+)
+
+// SetDefaults fills the omitted fields of a devfile 2.x with their default value documented by the schema:
+// the protocol and scheme of the endpoint configurations, the protocol and exposure of the endpoints since
+// devfile 2.2.0, the path of the volume mounts, the mounting of the project sources and their source mapping,
+// and the clone path of the projects.
+func SetDefaults(d DevfileObj) {
+	if d.Data == nil || d.Ctx.GetApiVersion() == "1.0.0" {
+		return
+	}
+	hasEndpointExposure := SupportsEndpointExposure(d.Ctx.GetApiVersion())
+
+	for _, component := range d.Data.GetComponents() {
+		container := component.Container
+		if container == nil {
+			continue
+		}
+		for i := range container.Endpoints {
+			endpoint := &container.Endpoints[i]
+			if c := endpoint.Configuration; c != nil {
+				setDefaultString(&c.Protocol, DefaultConfigurationProtocol)
+				setDefaultString(&c.Scheme, DefaultConfigurationScheme)
+			}
+			if hasEndpointExposure {
+				setDefaultString(&endpoint.Protocol, DefaultEndpointProtocol)
+				if endpoint.Exposure == "" {
+					endpoint.Exposure = common.PublicEndpointExposure
+				}
+			}
+		}
+		for i := range container.VolumeMounts {
+			mount := &container.VolumeMounts[i]
+			setDefaultString(&mount.Path, "/"+mount.Name)
+		}
+		if container.MountSources == nil {
+			mountSources := DefaultMountSources
+			container.MountSources = &mountSources
+		}
+		if *container.MountSources {
+			setDefaultString(&container.SourceMapping, DefaultSourceMapping)
+		}
+	}
+
+	projects := d.Data.GetProjects()
+	for i := range projects {
+		setDefaultString(&projects[i].ClonePath, projects[i].Name)
+	}
+}
+
+// PruneDefaults clears the fields of a devfile 2.x set to their default value, the inverse of SetDefaults.
+// The volume mount paths are kept, the schema requiring them.
+func PruneDefaults(d DevfileObj) {
+	if d.Data == nil || d.Ctx.GetApiVersion() == "1.0.0" {
+		return
+	}
+	hasEndpointExposure := SupportsEndpointExposure(d.Ctx.GetApiVersion())
+
+	for _, component := range d.Data.GetComponents() {
+		container := component.Container
+		if container == nil {
+			continue
+		}
+		for i := range container.Endpoints {
+			endpoint := &container.Endpoints[i]
+			if c := endpoint.Configuration; c != nil {
+				pruneDefaultString(&c.Protocol, DefaultConfigurationProtocol)
+				pruneDefaultString(&c.Scheme, DefaultConfigurationScheme)
+			}
+			if hasEndpointExposure {
+				pruneDefaultString(&endpoint.Protocol, DefaultEndpointProtocol)
+				if endpoint.Exposure == common.PublicEndpointExposure {
+					endpoint.Exposure = ""
+				}
+			}
+		}
+		if container.GetMountSources() {
+			pruneDefaultString(&container.SourceMapping, DefaultSourceMapping)
+		}
+		if container.MountSources != nil && *container.MountSources == DefaultMountSources {
+			container.MountSources = nil
+		}
+	}
+
+	projects := d.Data.GetProjects()
+	for i := range projects {
+		pruneDefaultString(&projects[i].ClonePath, projects[i].Name)
+	}
+}
+
+// SupportsEndpointExposure returns true if the endpoints of the devfile version have a protocol and an exposure,
+// devfile 2.2.0 onwards
+func SupportsEndpointExposure(version string) bool {
+	return version != "1.0.0" && !strings.HasPrefix(version, "2.0.") && !strings.HasPrefix(version, "2.1.")
+}
+
+// setDefaultString sets the field to its default value if omitted
+func setDefaultString(field *string, value string) {
+	if *field == "" {
+		*field = value
+	}
+}
+
+// pruneDefaultString clears the field if set to its default value
+func pruneDefaultString(field *string, value string) {
+	if *field == value {
+		*field = ""
+	}
+}
@@ -0,0 +1,150 @@
+package parser
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/devfile/parser/pkg/devfile/parser/data/common"
+)
+
+func TestSetDefaults(t *testing.T) {
+
+	const devfile210 = `schemaVersion: 2.1.0
+components:
+  - container:
+      name: runtime
+      image: quay.io/nodejs
+      volumeMounts:
+        - name: cache
+          path: /var/cache
+      endpoints:
+        - name: http
+          targetPort: 3000
+          configuration:
+            path: /health
+  - container:
+      name: tools
+      image: quay.io/tools
+      mountSources: false
+  - volume:
+      name: cache
+projects:
+  - name: nodejs-web-app
+    git:
+      location: https://github.com/che-samples/web-nodejs-sample.git
+  - name: api
+    clonePath: src/api
+    git:
+      location: https://github.com/che-samples/api.git
+`
+
+	// Devfile 2.2.0 names the components at their top level
+	const devfile220 = `schemaVersion: 2.2.0
+components:
+  - name: runtime
+    container:
+      image: quay.io/nodejs
+      volumeMounts:
+        - name: cache
+          path: /var/cache
+      endpoints:
+        - name: http
+          targetPort: 3000
+          configuration:
+            path: /health
+  - name: tools
+    container:
+      image: quay.io/tools
+      mountSources: false
+  - name: cache
+    volume: {}
+projects:
+  - name: nodejs-web-app
+    git:
+      location: https://github.com/che-samples/web-nodejs-sample.git
+  - name: api
+    clonePath: src/api
+    git:
+      location: https://github.com/che-samples/api.git
+`
+
+	mountSources, noMountSources := true, false
+
+	tests := []struct {
+		name     string
+		devfile  string
+		endpoint common.Endpoint
+	}{
+		{
+			name:    "Case 1: Devfile 2.1.0",
+			devfile: devfile210,
+			endpoint: common.Endpoint{
+				Name:          "http",
+				TargetPort:    3000,
+				Configuration: &common.Configuration{Path: "/health", Protocol: "tcp", Scheme: "http"},
+			},
+		},
+		{
+			name:    "Case 2: Devfile 2.2.0 endpoint protocol and exposure",
+			devfile: devfile220,
+			endpoint: common.Endpoint{
+				Name:          "http",
+				TargetPort:    3000,
+				Protocol:      "http",
+				Exposure:      common.PublicEndpointExposure,
+				Configuration: &common.Configuration{Path: "/health", Protocol: "tcp", Scheme: "http"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d, err := ParseInMemoryAndValidate([]byte(tt.devfile))
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			original, err := d.Encode(WriteOptions{Format: FormatYAML})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			SetDefaults(d)
+
+			components := d.Data.GetComponents()
+			runtime, tools := components[0].Container, components[1].Container
+			if !reflect.DeepEqual(runtime.Endpoints[0], tt.endpoint) {
+				t.Errorf("got endpoint: '%+v', want: '%+v'", runtime.Endpoints[0], tt.endpoint)
+			}
+			if runtime.VolumeMounts[0].Path != "/var/cache" {
+				t.Errorf("expected the volume mount path '/var/cache', got '%s'", runtime.VolumeMounts[0].Path)
+			}
+			if !reflect.DeepEqual(runtime.MountSources, &mountSources) || runtime.SourceMapping != "/projects" {
+				t.Errorf("expected the sources mounted in '/projects', got %v '%s'", runtime.MountSources, runtime.SourceMapping)
+			}
+			if !reflect.DeepEqual(tools.MountSources, &noMountSources) || tools.SourceMapping != "" {
+				t.Errorf("expected the sources not to be mounted, got %v '%s'", tools.MountSources, tools.SourceMapping)
+			}
+			projects := d.Data.GetProjects()
+			if projects[0].ClonePath != "nodejs-web-app" || projects[1].ClonePath != "src/api" {
+				t.Errorf("unexpected clone paths '%s' and '%s'", projects[0].ClonePath, projects[1].ClonePath)
+			}
+
+			// The pruned devfile is written as it was before the defaulting pass
+			pruned, err := d.Encode(WriteOptions{Format: FormatYAML, PruneDefaults: true})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if string(pruned) != string(original) {
+				t.Errorf("got pruned devfile:\n%s\nwant:\n%s", pruned, original)
+			}
+			if d.Data.GetProjects()[0].ClonePath == "" {
+				t.Errorf("expected the devfile not to be changed by the writer")
+			}
+
+			PruneDefaults(d)
+			if runtime := d.Data.GetComponents()[0].Container; runtime.MountSources != nil || runtime.SourceMapping != "" {
+				t.Errorf("expected the source mounting defaults to be pruned, got %v '%s'", runtime.MountSources, runtime.SourceMapping)
+			}
+		})
+	}
+}
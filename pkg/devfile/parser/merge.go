@@ -0,0 +1,216 @@
+package parser
+
+import (
+	"fmt"
+	"strings"
+)
+
+// getItemKey returns the name or id identifying a components, commands or projects item.
+// The key is either set at the top level of the item or inside its single union member,
+// e.g. { "container": { "name": "runtime" } } or { "exec": { "id": "build" } }
+func getItemKey(item map[string]interface{}) string {
+	if key := getNameOrId(item); key != "" {
+		return key
+	}
+	for _, value := range item {
+		if member, ok := value.(map[string]interface{}); ok {
+			if key := getNameOrId(member); key != "" {
+				return key
+			}
+		}
+	}
+	return ""
+}
+
+// setItemKey renames a components, commands or projects item
+func setItemKey(item map[string]interface{}, key string) {
+	if getNameOrId(item) != "" {
+		setNameOrId(item, key)
+		return
+	}
+	for _, value := range item {
+		if member, ok := value.(map[string]interface{}); ok && getNameOrId(member) != "" {
+			setNameOrId(member, key)
+			return
+		}
+	}
+}
+
+// getItemKind returns the union member of a components or commands item, e.g. "container" or "exec",
+// whether the key of the item is set inside this member or at the top level of the item
+func getItemKind(item map[string]interface{}) string {
+	topLevel := getNameOrId(item) != ""
+	for kind, value := range item {
+		if member, ok := value.(map[string]interface{}); ok && kind != "attributes" && (topLevel || getNameOrId(member) != "") {
+			return kind
+		}
+	}
+	return ""
+}
+
+// getNameOrId returns the "name" or "id" field of the given map
+func getNameOrId(m map[string]interface{}) string {
+	if name, ok := m["name"].(string); ok && name != "" {
+		return name
+	}
+	if id, ok := m["id"].(string); ok && id != "" {
+		return id
+	}
+	return ""
+}
+
+// setNameOrId sets the "name" or "id" field of the given map, whichever is present
+func setNameOrId(m map[string]interface{}, key string) {
+	if _, ok := m["name"].(string); ok {
+		m["name"] = key
+		return
+	}
+	m["id"] = key
+}
+
+// toItemList converts a decoded JSON array into a list of JSON objects
+func toItemList(value interface{}) ([]map[string]interface{}, error) {
+	if value == nil {
+		return nil, nil
+	}
+	values, ok := value.([]interface{})
+	if !ok {
+		return nil, fmt.Errorf("expected a list, got '%T'", value)
+	}
+	var items []map[string]interface{}
+	for _, v := range values {
+		item, ok := v.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("expected a list of objects, got an item of type '%T'", v)
+		}
+		items = append(items, item)
+	}
+	return items, nil
+}
+
+// fromItemList converts a list of JSON objects back into a JSON array
+func fromItemList(items []map[string]interface{}) []interface{} {
+	values := make([]interface{}, 0, len(items))
+	for _, item := range items {
+		values = append(values, item)
+	}
+	return values
+}
+
+// overrideItems applies the overrides on the base items coming from the given origin with strategic
+// merge semantics. Every override must reference an existing base item by its name or id, and can't
+// change the kind of the item it overrides.
+func overrideItems(section string, origin string, base []map[string]interface{}, overrides []map[string]interface{}) ([]map[string]interface{}, error) {
+	for _, override := range overrides {
+		key := getItemKey(override)
+		if key == "" {
+			return nil, fmt.Errorf("%s override is missing a name or id", section)
+		}
+
+		found := false
+		for i, item := range base {
+			if !strings.EqualFold(getItemKey(item), key) {
+				continue
+			}
+			// Only components and commands are unions of kinds, projects sources can be replaced
+			baseKind, overrideKind := getItemKind(item), getItemKind(override)
+			if (section == "components" || section == "commands") && baseKind != overrideKind && overrideKind != "" {
+				return nil, fmt.Errorf("%s override '%s' cannot change the type from '%s' to '%s'", section, key, baseKind, overrideKind)
+			}
+			base[i] = mergeMaps(item, override)
+			found = true
+			break
+		}
+
+		if !found {
+			return nil, fmt.Errorf("%s override '%s' does not match any item in the %s", section, key, origin)
+		}
+	}
+	return base, nil
+}
+
+// appendUniqueItems appends the items to the base items, returning an error if an item redefines a base item
+// coming from the given origin
+func appendUniqueItems(section string, origin string, base []map[string]interface{}, items []map[string]interface{}) ([]map[string]interface{}, error) {
+	keys := make(map[string]bool)
+	for _, item := range base {
+		keys[strings.ToLower(getItemKey(item))] = true
+	}
+	for _, item := range items {
+		key := strings.ToLower(getItemKey(item))
+		if keys[key] {
+			return nil, fmt.Errorf("%s '%s' is already defined in the %s", section, getItemKey(item), origin)
+		}
+		keys[key] = true
+		base = append(base, item)
+	}
+	return base, nil
+}
+
+// mergeMaps merges the override into the base with strategic merge semantics:
+// nested objects are merged recursively, lists of named objects are merged by name
+// and any other value in the override replaces the base value
+func mergeMaps(base map[string]interface{}, override map[string]interface{}) map[string]interface{} {
+	merged := make(map[string]interface{}, len(base))
+	for k, v := range base {
+		merged[k] = v
+	}
+
+	for k, overrideValue := range override {
+		baseValue, ok := merged[k]
+		if !ok {
+			merged[k] = overrideValue
+			continue
+		}
+
+		switch ov := overrideValue.(type) {
+		case map[string]interface{}:
+			if bv, ok := baseValue.(map[string]interface{}); ok {
+				merged[k] = mergeMaps(bv, ov)
+				continue
+			}
+		case []interface{}:
+			if bv, ok := baseValue.([]interface{}); ok {
+				if list, ok := mergeNamedLists(bv, ov); ok {
+					merged[k] = list
+					continue
+				}
+			}
+		}
+		merged[k] = overrideValue
+	}
+	return merged
+}
+
+// mergeNamedLists merges two lists of objects keyed by name, e.g. endpoints, env or volumeMounts.
+// It returns false if either list contains items that are not named objects.
+func mergeNamedLists(base []interface{}, override []interface{}) ([]interface{}, bool) {
+	baseItems, err := toItemList(base)
+	if err != nil {
+		return nil, false
+	}
+	overrides, err := toItemList(override)
+	if err != nil {
+		return nil, false
+	}
+	for _, item := range append(baseItems, overrides...) {
+		if getItemKey(item) == "" {
+			return nil, false
+		}
+	}
+
+	for _, overrideItem := range overrides {
+		found := false
+		for i, baseItem := range baseItems {
+			if getItemKey(baseItem) == getItemKey(overrideItem) {
+				baseItems[i] = mergeMaps(baseItem, overrideItem)
+				found = true
+				break
+			}
+		}
+		if !found {
+			baseItems = append(baseItems, overrideItem)
+		}
+	}
+	return fromItemList(baseItems), true
+}
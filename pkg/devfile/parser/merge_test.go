@@ -0,0 +1,130 @@
+package parser
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestMergeMaps(t *testing.T) {
+
+	tests := []struct {
+		name     string
+		base     map[string]interface{}
+		override map[string]interface{}
+		want     map[string]interface{}
+	}{
+		{
+			name:     "nested objects are merged",
+			base:     map[string]interface{}{"container": map[string]interface{}{"name": "runtime", "image": "nodejs"}},
+			override: map[string]interface{}{"container": map[string]interface{}{"name": "runtime", "memoryLimit": "1Gi"}},
+			want:     map[string]interface{}{"container": map[string]interface{}{"name": "runtime", "image": "nodejs", "memoryLimit": "1Gi"}},
+		},
+		{
+			name: "named lists are merged by name",
+			base: map[string]interface{}{"env": []interface{}{
+				map[string]interface{}{"name": "A", "value": "1"},
+				map[string]interface{}{"name": "B", "value": "2"},
+			}},
+			override: map[string]interface{}{"env": []interface{}{
+				map[string]interface{}{"name": "B", "value": "3"},
+				map[string]interface{}{"name": "C", "value": "4"},
+			}},
+			want: map[string]interface{}{"env": []interface{}{
+				map[string]interface{}{"name": "A", "value": "1"},
+				map[string]interface{}{"name": "B", "value": "3"},
+				map[string]interface{}{"name": "C", "value": "4"},
+			}},
+		},
+		{
+			name:     "other lists are replaced",
+			base:     map[string]interface{}{"args": []interface{}{"a", "b"}},
+			override: map[string]interface{}{"args": []interface{}{"c"}},
+			want:     map[string]interface{}{"args": []interface{}{"c"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := mergeMaps(tt.base, tt.override)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("want: '%v', got: '%v'", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestGetItemKey(t *testing.T) {
+
+	tests := []struct {
+		name string
+		item map[string]interface{}
+		want string
+	}{
+		{
+			name: "project name",
+			item: map[string]interface{}{"name": "nodejs-starter"},
+			want: "nodejs-starter",
+		},
+		{
+			name: "component name",
+			item: map[string]interface{}{"container": map[string]interface{}{"name": "runtime"}},
+			want: "runtime",
+		},
+		{
+			name: "command id",
+			item: map[string]interface{}{"exec": map[string]interface{}{"id": "build"}},
+			want: "build",
+		},
+		{
+			name: "devfile 2.2.0 component name",
+			item: map[string]interface{}{"name": "runtime", "container": map[string]interface{}{"image": "quay.io/nodejs"}},
+			want: "runtime",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := getItemKey(tt.item); got != tt.want {
+				t.Errorf("want: '%s', got: '%s'", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestGetItemKind(t *testing.T) {
+
+	tests := []struct {
+		name string
+		item map[string]interface{}
+		want string
+	}{
+		{
+			name: "component named inside its kind",
+			item: map[string]interface{}{"container": map[string]interface{}{"name": "runtime"}},
+			want: "container",
+		},
+		{
+			name: "devfile 2.2.0 component with attributes",
+			item: map[string]interface{}{"name": "runtime", "attributes": map[string]interface{}{"debug": true}, "container": map[string]interface{}{"image": "quay.io/nodejs"}},
+			want: "container",
+		},
+		{
+			name: "devfile 2.2.0 command",
+			item: map[string]interface{}{"id": "build", "exec": map[string]interface{}{"commandLine": "npm install"}},
+			want: "exec",
+		},
+		{
+			name: "override without kind",
+			item: map[string]interface{}{"name": "runtime", "attributes": map[string]interface{}{"debug": true}},
+			want: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := getItemKind(tt.item); got != tt.want {
+				t.Errorf("want: '%s', got: '%s'", tt.want, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,136 @@
+package parser
+
+import (
+	"encoding/json"
+
+	"github.com/pkg/errors"
+
+	"github.com/devfile/parser/pkg/devfile/parser/data"
+	"github.com/devfile/parser/pkg/devfile/parser/data/common"
+)
+
+// isParentSet returns true if the parent references a devfile
+func isParentSet(parent common.DevfileParent) bool {
+	return parent.Uri != "" || parent.Id != "" || parent.RegistryEntry != nil || parent.Kubernetes != nil
+}
+
+// getParentReference returns the reference to the devfile of the parent
+func getParentReference(parent common.DevfileParent) devfileReference {
+	ref := devfileReference{
+		uri:         parent.Uri,
+		id:          parent.Id,
+		registryURL: parent.RegistryUrl,
+		kubernetes:  parent.Kubernetes,
+	}
+	if parent.RegistryEntry != nil {
+		ref.id, ref.registryURL = parent.RegistryEntry.Id, parent.RegistryEntry.BaseUrl
+	}
+	return ref
+}
+
+// flattenParent resolves the parent of the devfile, applies the parent overrides and
+// merges the parent components, commands, projects, starter projects, events and variables into the devfile data
+func flattenParent(d *DevfileObj, resolveCtx *resolverContext) error {
+	parent := d.Data.GetParent()
+	if !isParentSet(parent) {
+		return nil
+	}
+
+	parentObj, err := parseReference(d, getParentReference(parent), resolveCtx)
+	if err != nil {
+		return errors.Wrapf(err, "failed to resolve the parent devfile")
+	}
+
+	merged, err := mergeParent(d.Ctx.GetDevfileContent(), parentObj.Data)
+	if err != nil {
+		return errors.Wrapf(err, "failed to merge the parent devfile")
+	}
+
+	// Replace the devfile data by the flattened devfile
+	return setDevfileData(d, merged)
+}
+
+// mergeParent applies the parent overrides of the child devfile content to the parent data,
+// and returns the child devfile content with the resulting parent elements merged in
+func mergeParent(childContent []byte, parentData data.DevfileData) ([]byte, error) {
+	var child map[string]interface{}
+	if err := json.Unmarshal(childContent, &child); err != nil {
+		return nil, errors.Wrapf(err, "failed to decode devfile content")
+	}
+
+	parent, err := toJSONMap(parentData)
+	if err != nil {
+		return nil, err
+	}
+
+	overrides, _ := child["parent"].(map[string]interface{})
+
+	for _, section := range []string{"components", "commands", "projects", "starterProjects"} {
+		parentItems, err := toItemList(parent[section])
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid %s in parent devfile", section)
+		}
+		sectionOverrides, err := toItemList(overrides[section])
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid %s in parent overrides", section)
+		}
+		childItems, err := toItemList(child[section])
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid %s in devfile", section)
+		}
+
+		items, err := overrideItems(section, "parent devfile", parentItems, sectionOverrides)
+		if err != nil {
+			return nil, err
+		}
+		if items, err = appendUniqueItems(section, "parent devfile, use the parent overrides to change it", items, childItems); err != nil {
+			return nil, err
+		}
+		if len(items) > 0 {
+			child[section] = fromItemList(items)
+		}
+	}
+
+	events := mergeEvents(parent["events"], overrides["events"], child["events"])
+	if len(events) > 0 {
+		child["events"] = events
+	}
+
+	// The child variables take precedence over the parent ones
+	variables := make(map[string]interface{})
+	for _, v := range []interface{}{parent["variables"], child["variables"]} {
+		m, _ := v.(map[string]interface{})
+		for name, value := range m {
+			variables[name] = value
+		}
+	}
+	if len(variables) > 0 {
+		child["variables"] = variables
+	}
+
+	return json.Marshal(child)
+}
+
+// mergeEvents overrides the parent events by the parent overrides events,
+// and appends the child events to the result
+func mergeEvents(parentEvents, overrideEvents, childEvents interface{}) map[string]interface{} {
+	events := make(map[string]interface{})
+	if p, ok := parentEvents.(map[string]interface{}); ok {
+		for k, v := range p {
+			events[k] = v
+		}
+	}
+	if o, ok := overrideEvents.(map[string]interface{}); ok {
+		for k, v := range o {
+			events[k] = v
+		}
+	}
+	if c, ok := childEvents.(map[string]interface{}); ok {
+		for k, v := range c {
+			existing, _ := events[k].([]interface{})
+			added, _ := v.([]interface{})
+			events[k] = append(append([]interface{}{}, existing...), added...)
+		}
+	}
+	return events
+}
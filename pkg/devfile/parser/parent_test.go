@@ -0,0 +1,326 @@
+package parser
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	v220 "github.com/devfile/parser/pkg/devfile/parser/data/2.2.0"
+	"github.com/devfile/parser/pkg/devfile/parser/data/common"
+)
+
+const parentDevfile = `schemaVersion: 2.1.0
+metadata:
+  name: parent
+components:
+  - container:
+      name: runtime
+      image: registry.access.redhat.com/ubi8/nodejs-12:1-36
+      memoryLimit: 512Mi
+      endpoints:
+        - name: http
+          targetPort: 3000
+          configuration:
+            protocol: tcp
+  - volume:
+      name: cache
+commands:
+  - exec:
+      id: build
+      component: runtime
+      commandLine: npm install
+events:
+  postStart:
+    - build
+`
+
+func TestParseAndValidateWithParent(t *testing.T) {
+
+	// createDevfiles helper writes the devfiles in a temp dir
+	createDevfiles := func(t *testing.T, devfiles map[string]string) string {
+		t.Helper()
+		dir, err := ioutil.TempDir("", "devfile-parent")
+		if err != nil {
+			t.Fatalf("failed to create temp dir: %v", err)
+		}
+		for name, content := range devfiles {
+			if err := ioutil.WriteFile(filepath.Join(dir, name), []byte(content), 0644); err != nil {
+				t.Fatalf("failed to write devfile: %v", err)
+			}
+		}
+		return dir
+	}
+
+	t.Run("parent uri with overrides", func(t *testing.T) {
+		dir := createDevfiles(t, map[string]string{
+			"parent.yaml": parentDevfile,
+			"devfile.yaml": `schemaVersion: 2.1.0
+metadata:
+  name: child
+parent:
+  uri: parent.yaml
+  components:
+    - container:
+        name: runtime
+        memoryLimit: 1Gi
+        endpoints:
+          - name: http
+            targetPort: 8080
+components:
+  - container:
+      name: tools
+      image: quay.io/tools
+commands:
+  - exec:
+      id: run
+      component: runtime
+      commandLine: npm start
+events:
+  postStart:
+    - run
+`,
+		})
+		defer os.RemoveAll(dir)
+
+		d, err := ParseAndValidate(filepath.Join(dir, "devfile.yaml"))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		components := d.Data.GetComponents()
+		if len(components) != 3 {
+			t.Fatalf("expected 3 components, got %d", len(components))
+		}
+		runtime := components[0].Container
+		if runtime == nil || runtime.Name != "runtime" {
+			t.Fatalf("expected the parent runtime container first, got %v", components[0])
+		}
+		if runtime.MemoryLimit != "1Gi" || runtime.Image != "registry.access.redhat.com/ubi8/nodejs-12:1-36" {
+			t.Errorf("override not applied with strategic merge, got memoryLimit '%s' and image '%s'", runtime.MemoryLimit, runtime.Image)
+		}
+		if len(runtime.Endpoints) != 1 || runtime.Endpoints[0].TargetPort != 8080 {
+			t.Errorf("endpoint override not merged by name, got %v", runtime.Endpoints)
+		}
+
+		if len(d.Data.GetCommands()) != 2 {
+			t.Errorf("expected 2 commands, got %d", len(d.Data.GetCommands()))
+		}
+		if got := strings.Join(d.Data.GetEvents().PostStart, ","); got != "build,run" {
+			t.Errorf("expected postStart events 'build,run', got '%s'", got)
+		}
+	})
+
+	t.Run("devfile 2.2.0 with a devfile 2.1.0 parent", func(t *testing.T) {
+		dir := createDevfiles(t, map[string]string{
+			"parent.yaml": parentDevfile,
+			"devfile.yaml": `schemaVersion: 2.2.0
+parent:
+  uri: parent.yaml
+  components:
+    - name: runtime
+      container:
+        memoryLimit: 1Gi
+components:
+  - name: tools
+    container:
+      image: quay.io/tools
+`,
+		})
+		defer os.RemoveAll(dir)
+
+		d, err := ParseAndValidate(filepath.Join(dir, "devfile.yaml"))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		components := d.Data.GetComponents()
+		if len(components) != 3 || components[0].GetName() != "runtime" || components[2].GetName() != "tools" {
+			t.Fatalf("unexpected components '%v'", components)
+		}
+		if components[0].Container.MemoryLimit != "1Gi" {
+			t.Errorf("override not applied, got memoryLimit '%s'", components[0].Container.MemoryLimit)
+		}
+	})
+
+	t.Run("parent uri over http and registry id", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Path == "/devfiles/nodejs/devfile.yaml" || r.URL.Path == "/stacks/parent.yaml" {
+				fmt.Fprint(w, parentDevfile)
+				return
+			}
+			w.WriteHeader(http.StatusNotFound)
+		}))
+		defer server.Close()
+
+		parents := []string{
+			"uri: " + server.URL + "/stacks/parent.yaml",
+			"id: nodejs\n  registryUrl: " + server.URL,
+			"registryEntry:\n    id: nodejs\n    baseUrl: " + server.URL,
+		}
+		for _, parent := range parents {
+			d, err := ParseInMemoryAndValidate([]byte("schemaVersion: 2.1.0\nparent:\n  " + parent + "\n"))
+			if err != nil {
+				t.Fatalf("unexpected error for parent '%s': %v", parent, err)
+			}
+			if len(d.Data.GetComponents()) != 2 {
+				t.Errorf("expected the 2 parent components for parent '%s', got %d", parent, len(d.Data.GetComponents()))
+			}
+		}
+	})
+
+	t.Run("devfile url with a relative parent uri", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			switch r.URL.Path {
+			case "/stacks/devfile.yaml":
+				fmt.Fprint(w, "schemaVersion: 2.1.0\nparent:\n  uri: nodejs/parent.yaml\n")
+			case "/stacks/nodejs/parent.yaml":
+				fmt.Fprint(w, parentDevfile)
+			default:
+				w.WriteHeader(http.StatusNotFound)
+			}
+		}))
+		defer server.Close()
+
+		d, err := ParseAndValidate(server.URL + "/stacks/devfile.yaml")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(d.Data.GetComponents()) != 2 {
+			t.Errorf("expected the 2 parent components, got %d", len(d.Data.GetComponents()))
+		}
+		if d.Ctx.GetOrigin() != server.URL+"/stacks/devfile.yaml" {
+			t.Errorf("expected the devfile url as origin, got '%s'", d.Ctx.GetOrigin())
+		}
+	})
+
+	t.Run("kubernetes parent with inlined DevWorkspaceTemplate", func(t *testing.T) {
+		d, err := ParseInMemoryAndValidate([]byte(`schemaVersion: 2.1.0
+parent:
+  kubernetes:
+    name: nodejs
+    inlined: |
+      kind: DevWorkspaceTemplate
+      apiVersion: workspace.devfile.io/v1alpha1
+      spec:
+        components:
+          - container:
+              name: runtime
+              image: quay.io/nodejs
+`))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(d.Data.GetComponents()) != 1 {
+			t.Errorf("expected 1 component, got %d", len(d.Data.GetComponents()))
+		}
+	})
+
+	tests := []struct {
+		name     string
+		devfiles map[string]string
+		wantErr  string
+	}{
+		{
+			name: "parent cycle",
+			devfiles: map[string]string{
+				"devfile.yaml": "schemaVersion: 2.1.0\nparent:\n  uri: a.yaml\n",
+				"a.yaml":       "schemaVersion: 2.1.0\nparent:\n  uri: b.yaml\n",
+				"b.yaml":       "schemaVersion: 2.1.0\nparent:\n  uri: a.yaml\n",
+			},
+			wantErr: "devfile reference cycle detected",
+		},
+		{
+			name: "override of a missing component",
+			devfiles: map[string]string{
+				"parent.yaml":  parentDevfile,
+				"devfile.yaml": "schemaVersion: 2.1.0\nparent:\n  uri: parent.yaml\n  components:\n    - container:\n        name: missing\n",
+			},
+			wantErr: "components override 'missing' does not match any item in the parent devfile",
+		},
+		{
+			name: "override changing the component type",
+			devfiles: map[string]string{
+				"parent.yaml":  parentDevfile,
+				"devfile.yaml": "schemaVersion: 2.1.0\nparent:\n  uri: parent.yaml\n  components:\n    - volume:\n        name: runtime\n",
+			},
+			wantErr: "cannot change the type from 'container' to 'volume'",
+		},
+		{
+			name: "devfile 2.2.0 override changing the component type",
+			devfiles: map[string]string{
+				"parent.yaml":  parentDevfile,
+				"devfile.yaml": "schemaVersion: 2.2.0\nparent:\n  uri: parent.yaml\n  components:\n    - name: runtime\n      volume: {}\n",
+			},
+			wantErr: "cannot change the type from 'container' to 'volume'",
+		},
+		{
+			name: "component redefined in the child",
+			devfiles: map[string]string{
+				"parent.yaml":  parentDevfile,
+				"devfile.yaml": "schemaVersion: 2.1.0\nparent:\n  uri: parent.yaml\ncomponents:\n  - volume:\n      name: cache\n",
+			},
+			wantErr: "components 'cache' is already defined in the parent devfile",
+		},
+		{
+			name: "parent id without registry url",
+			devfiles: map[string]string{
+				"devfile.yaml": "schemaVersion: 2.1.0\nparent:\n  id: nodejs\n",
+			},
+			wantErr: "registry url is required to resolve devfile id 'nodejs'",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := createDevfiles(t, tt.devfiles)
+			defer os.RemoveAll(dir)
+
+			_, err := ParseAndValidate(filepath.Join(dir, "devfile.yaml"))
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("expected error containing '%s', got '%v'", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestIsParentSet(t *testing.T) {
+
+	if isParentSet(common.DevfileParent{}) {
+		t.Errorf("expected an empty parent not to be set")
+	}
+	if !isParentSet(common.DevfileParent{Uri: "parent.yaml"}) {
+		t.Errorf("expected a parent with a uri to be set")
+	}
+}
+
+func TestMergeParentStarterProjects(t *testing.T) {
+
+	parentData := &v220.Devfile220{
+		SchemaVersion:   "2.2.0",
+		StarterProjects: []common.DevfileStarterProject{{Name: "nodejs-starter"}},
+	}
+	childContent := []byte(`{"schemaVersion":"2.2.0","starterProjects":[{"name":"express-starter"}]}`)
+
+	merged, err := mergeParent(childContent, parentData)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var d v220.Devfile220
+	if err := json.Unmarshal(merged, &d); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var names []string
+	for _, starterProject := range d.GetStarterProjects() {
+		names = append(names, starterProject.Name)
+	}
+	if want := []string{"nodejs-starter", "express-starter"}; !reflect.DeepEqual(names, want) {
+		t.Errorf("got: '%v', want: '%v'", names, want)
+	}
+}
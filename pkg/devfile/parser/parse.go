@@ -1,7 +1,10 @@
 package parser
 
 import (
+	"context"
 	"encoding/json"
+	"fmt"
+
 	"github.com/pkg/errors"
 
 	devfileCtx "github.com/devfile/parser/pkg/devfile/parser/context"
@@ -9,9 +12,9 @@ import (
 	"github.com/devfile/parser/pkg/devfile/validate"
 )
 
-// ParseDevfile func validates the devfile integrity.
-// Creates devfile context and runtime objects
-func parseDevfile(d DevfileObj) (DevfileObj, error) {
+// parseDevfile validates the devfile integrity, creates the devfile data
+// and flattens the devfile with its parents and plugins.
+func parseDevfile(d DevfileObj, resolveCtx *resolverContext) (DevfileObj, error) {
 
 	// Validate devfile
 	err := d.Ctx.Validate()
@@ -19,52 +22,142 @@ func parseDevfile(d DevfileObj) (DevfileObj, error) {
 		return d, err
 	}
 
+	err = decodeDevfile(&d)
+	if err != nil {
+		return d, err
+	}
+
+	err = flattenDevfile(&d, resolveCtx)
+	if err != nil {
+		return d, err
+	}
+
+	// Successful
+	return d, nil
+}
+
+// decodeDevfile creates the devfile data object of the devfile version from the devfile content
+func decodeDevfile(d *DevfileObj) (err error) {
+
 	// Create a new devfile data object
 	d.Data, err = data.NewDevfileData(d.Ctx.GetApiVersion())
 	if err != nil {
-		return d, err
+		return err
 	}
 
 	// Unmarshal devfile content into devfile struct
 	err = json.Unmarshal(d.Ctx.GetDevfileContent(), &d.Data)
 	if err != nil {
-		return d, errors.Wrapf(err, "failed to decode devfile content")
+		return errors.Wrapf(err, "failed to decode devfile content")
 	}
+	return nil
+}
 
-	// Successful
-	return d, nil
+// flattenDevfile merges the parent and the plugins into the devfile data
+func flattenDevfile(d *DevfileObj, resolveCtx *resolverContext) error {
+
+	// Resolve the parent and merge it into the devfile data
+	err := flattenParent(d, resolveCtx)
+	if err != nil {
+		return err
+	}
+
+	// Replace the plugins by the components and commands they contribute
+	return resolvePlugins(d, resolveCtx)
 }
 
-// Parse func populates the devfile data, parses and validates the devfile integrity.
-// Creates devfile context and runtime objects
-func parse(path string) (d DevfileObj, err error) {
+// ParseDevfile reads the devfile from the path, the URL or the data of the arguments, flattens it with
+// its parent and plugins, substitutes its variables and validates it as requested by the arguments.
+// Reading the devfile and its references is cancelled with the context.
+func ParseDevfile(ctx context.Context, args ParserArgs) (d DevfileObj, err error) {
+
+	sources := 0
+	for _, provided := range []bool{args.Path != "", args.URL != "", args.Data != nil} {
+		if provided {
+			sources++
+		}
+	}
+	if sources != 1 {
+		return d, fmt.Errorf("exactly one of the devfile path, url or data must be provided")
+	}
 
-	// NewDevfileCtx
-	d.Ctx = devfileCtx.NewDevfileCtx(path)
+	resolveCtx := &resolverContext{
+		ctx:          ctx,
+		httpClient:   args.HTTPClient,
+		maxSize:      args.MaxDevfileSize,
+		registryURLs: args.RegistryURLs,
+	}
+
+	if args.URL != "" && !devfileCtx.IsURL(args.URL) {
+		return d, fmt.Errorf("devfile url '%s' must be an http(s) URL", args.URL)
+	}
 
 	// Fill the fields of DevfileCtx struct
-	err = d.Ctx.Populate()
+	location := args.Path
+	if args.URL != "" {
+		location = args.URL
+	}
+	d.Ctx = devfileCtx.NewDevfileCtx(location)
+	if args.Fs != nil {
+		d.Ctx.Fs = args.Fs
+	}
+	d.Ctx.SetHTTPClient(args.HTTPClient)
+	d.Ctx.SetMaxSize(args.MaxDevfileSize)
+	if args.Data != nil {
+		err = d.Ctx.PopulateFromBytes(args.Data)
+	} else {
+		err = d.Ctx.PopulateWithContext(ctx)
+	}
 	if err != nil {
 		return d, err
 	}
-	return parseDevfile(d)
-}
 
-// ParseAndValidate func parses the devfile data
-// and validates the devfile integrity with the schema
-// and validates the devfile data.
-// Creates devfile context and runtime objects.
-func ParseAndValidate(path string) (d DevfileObj, err error) {
+	// The references of the devfile are resolved against its origin
+	resolveCtx.location = d.Ctx.GetOrigin()
 
-	// read and parse devfile from given path
-	d, err = parse(path)
+	if args.ValidationLevel != ValidateNone {
+		if err = d.Ctx.Validate(); err != nil {
+			return d, err
+		}
+	}
+	if err = decodeDevfile(&d); err != nil {
+		return d, err
+	}
+	rawData, err := copyDevfileData(d.Ctx.GetApiVersion(), d.Data)
 	if err != nil {
 		return d, err
 	}
+	if args.Flatten == nil || *args.Flatten {
+		if err = flattenDevfile(&d, resolveCtx); err != nil {
+			return d, err
+		}
+	}
+
+	d.Warnings = substituteDevfileVariables(d, VariableOptions{Overrides: args.VariableOverrides, LookupEnv: args.LookupEnv})
+	if args.ImplicitVolumes {
+		if _, err = AddImplicitVolumes(d); err != nil {
+			return d, err
+		}
+	}
+	if args.SetDefaults {
+		SetDefaults(d)
+	}
+	d.RawData = rawData
 
 	// odo specific validation on devfile content
-	err = validate.ValidateDevfileData(d.Data)
-	if err != nil {
+	if args.ValidationLevel == ValidateFull {
+		result := validateDevfileData(d, validate.ValidationOptions{
+			SkipContainerCheck: args.SkipContainerCheck,
+			UniqueMountPaths:   args.UniqueMountPaths,
+		})
+		// The parsing warnings are reported with the validation problems
+		result.Merge(d.Warnings)
+		if err = result.ToError(); err != nil {
+			return d, err
+		}
+		d.Warnings = result
+	}
+	if d.parsedContent, err = toJSONMap(d.Data); err != nil {
 		return d, err
 	}
 
@@ -72,16 +165,18 @@ func ParseAndValidate(path string) (d DevfileObj, err error) {
 	return d, nil
 }
 
+// ParseAndValidate func parses the devfile data
+// and validates the devfile integrity with the schema
+// and validates the devfile data.
+// Creates devfile context and runtime objects.
+func ParseAndValidate(path string) (d DevfileObj, err error) {
+	return ParseDevfile(context.Background(), ParserArgs{Path: path})
+}
+
 // parseInMemory func populates the data from memory, parses and validates the devfile integrity.
 // Creates devfile context and runtime objects
 func parseInMemory(bytes []byte) (d DevfileObj, err error) {
-
-	// Fill the fields of DevfileCtx struct
-	err = d.Ctx.PopulateFromBytes(bytes)
-	if err != nil {
-		return d, err
-	}
-	return parseDevfile(d)
+	return ParseDevfile(context.Background(), ParserArgs{Data: bytes, ValidationLevel: ValidateSchema})
 }
 
 // ParseInMemoryAndValidate func parses the devfile data in memory
@@ -89,19 +184,33 @@ func parseInMemory(bytes []byte) (d DevfileObj, err error) {
 // and validates the devfile data.
 // Creates devfile context and runtime objects.
 func ParseInMemoryAndValidate(data []byte) (d DevfileObj, err error) {
+	return ParseDevfile(context.Background(), ParserArgs{Data: data})
+}
 
-	// read and parse devfile from given data
-	d, err = parseInMemory(data)
-	if err != nil {
-		return d, err
+// validateDevfileData validates the devfile data, positioning the problems in the devfile source
+// unless the data was flattened with parents or plugins, whose elements are not in that source
+func validateDevfileData(d DevfileObj, options validate.ValidationOptions) *validate.ValidationResult {
+	result := validate.ValidateDevfileWithOptions(d.Data, options)
+	if !isFlattened(d.Ctx.GetDevfileContent()) {
+		result.AttachPositions(d.Ctx.GetPosition)
 	}
+	return result
+}
 
-	// odo specific validation on devfile content
-	err = validate.ValidateDevfileData(d.Data)
-	if err != nil {
-		return d, err
+// isFlattened returns true if the devfile content references a parent or plugins
+func isFlattened(content []byte) bool {
+	var m map[string]interface{}
+	if err := json.Unmarshal(content, &m); err != nil {
+		return false
 	}
-
-	// Successful
-	return d, nil
+	if _, ok := m["parent"]; ok {
+		return true
+	}
+	components, _ := toItemList(m["components"])
+	for _, component := range components {
+		if _, ok := component["plugin"]; ok {
+			return true
+		}
+	}
+	return false
 }
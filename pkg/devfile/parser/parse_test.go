@@ -0,0 +1,498 @@
+package parser
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	v220 "github.com/devfile/parser/pkg/devfile/parser/data/2.2.0"
+	"github.com/devfile/parser/pkg/devfile/parser/data/common"
+	"github.com/devfile/parser/pkg/devfile/validate"
+	"github.com/devfile/parser/pkg/testingutil/filesystem"
+)
+
+func TestParseInMemoryAndValidate(t *testing.T) {
+
+	t.Run("devfile with composite and vscode commands", func(t *testing.T) {
+		d, err := ParseInMemoryAndValidate([]byte(`schemaVersion: 2.1.0
+components:
+  - container:
+      name: runtime
+      image: quay.io/nodejs
+commands:
+  - exec:
+      id: install
+      component: runtime
+      commandLine: npm install
+  - composite:
+      id: InstallAndTest
+      commands:
+        - install
+      group:
+        kind: build
+  - vscodeTask:
+      id: task
+      inlined: "{}"
+`))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		commands := d.Data.GetCommands()
+		if len(commands) != 3 {
+			t.Fatalf("expected 3 commands, got %d", len(commands))
+		}
+		composite := commands[1]
+		if composite.GetCommandType() != common.CompositeCommandType || composite.GetId() != "installandtest" {
+			t.Errorf("expected composite command 'installandtest', got '%s' of type '%s'", composite.GetId(), composite.GetCommandType())
+		}
+		if composite.GetGroup() == nil || composite.GetGroup().Kind != common.BuildCommandGroupType {
+			t.Errorf("expected composite command in the build group, got '%v'", composite.GetGroup())
+		}
+		if commands[2].GetCommandType() != common.VscodeTaskCommandType {
+			t.Errorf("expected a vscode task command, got '%s'", commands[2].GetCommandType())
+		}
+	})
+
+	t.Run("validation problems are positioned in the devfile source", func(t *testing.T) {
+		_, err := ParseInMemoryAndValidate([]byte(`schemaVersion: 2.1.0
+components:
+  - container:
+      name: runtime
+      image: quay.io/nodejs
+commands:
+  - exec:
+      id: install
+      component: missing
+      commandLine: npm install
+`))
+		result, ok := err.(*validate.ValidationResult)
+		if !ok {
+			t.Fatalf("expected a validation result, got: %v", err)
+		}
+
+		want := []validate.ValidationError{
+			{
+				Path:     "/commands/0/exec/component",
+				Code:     validate.CodeInvalidExecComponent,
+				Severity: validate.SeverityError,
+				Message:  fmt.Sprintf(validate.ErrorInvalidExecComponent, "install", "missing"),
+				Position: &validate.Position{Line: 9, Column: 7},
+			},
+		}
+		if !reflect.DeepEqual(result.Errors, want) {
+			t.Errorf("got: '%v', want: '%v'", result.Errors, want)
+		}
+	})
+
+	t.Run("schema violations are positioned in the devfile source", func(t *testing.T) {
+		_, err := ParseInMemoryAndValidate([]byte(`schemaVersion: 2.1.0
+components:
+  - container:
+      name: runtime
+`))
+		result, ok := err.(*validate.ValidationResult)
+		if !ok {
+			t.Fatalf("expected a validation result, got: %v", err)
+		}
+
+		if len(result.Errors) != 1 || result.Errors[0].Path != "/components/0/container/image" ||
+			!reflect.DeepEqual(result.Errors[0].Position, &validate.Position{Line: 3, Column: 5}) {
+			t.Errorf("unexpected schema violations: '%v'", result.Errors)
+		}
+	})
+
+	t.Run("devfile 2.2.0 constructs", func(t *testing.T) {
+		d, err := ParseInMemoryAndValidate([]byte(`schemaVersion: 2.2.0
+metadata:
+  name: nodejs
+variables:
+  version: "14"
+attributes:
+  alpha.build-timeout: 10m
+starterProjects:
+  - name: nodejs-starter
+    subDir: app
+    git:
+      location: https://github.com/odo-devfiles/nodejs-ex.git
+components:
+  - name: runtime
+    container:
+      image: quay.io/nodejs
+      cpuLimit: "1"
+      cpuRequest: 500m
+      memoryRequest: 256Mi
+      annotation:
+        deployment:
+          owner: team
+      endpoints:
+        - name: http
+          targetPort: 3000
+          exposure: internal
+          protocol: https
+          secure: true
+  - name: image
+    image:
+      imageName: nodejs-app
+      dockerfile:
+        uri: Dockerfile
+        buildContext: .
+    attributes:
+      build.timeout: 5m
+commands:
+  - id: build-image
+    apply:
+      component: image
+      group:
+        kind: deploy
+        isDefault: true
+      attributes:
+        retries: 3
+        build:
+          cache: true
+`))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		data, ok := d.Data.(*v220.Devfile220)
+		if !ok {
+			t.Fatalf("expected devfile 2.2.0 data, got '%T'", d.Data)
+		}
+		if data.GetVariables()["version"] != "14" || data.GetAttributes()["alpha.build-timeout"] != "10m" {
+			t.Errorf("unexpected variables '%v' and attributes '%v'", data.GetVariables(), data.GetAttributes())
+		}
+		if starterProjects := data.GetStarterProjects(); len(starterProjects) != 1 || starterProjects[0].Git == nil {
+			t.Errorf("unexpected starter projects '%v'", starterProjects)
+		}
+
+		components := d.Data.GetComponents()
+		container := components[0].Container
+		if container.CpuLimit != "1" || container.CpuRequest != "500m" || container.MemoryRequest != "256Mi" || container.Annotation.Deployment["owner"] != "team" {
+			t.Errorf("unexpected container '%+v'", container)
+		}
+		if endpoint := container.Endpoints[0]; endpoint.Exposure != common.InternalEndpointExposure || endpoint.Protocol != "https" || !endpoint.Secure {
+			t.Errorf("unexpected endpoint '%+v'", endpoint)
+		}
+		if components[1].GetComponentType() != common.ImageComponentType || components[1].Image.Dockerfile.Uri != "Dockerfile" || components[1].Attributes["build.timeout"] != "5m" {
+			t.Errorf("unexpected image component '%+v'", components[1])
+		}
+
+		commands := d.Data.GetCommands()
+		if commands[0].GetCommandType() != common.ApplyCommandType || commands[0].GetGroup().Kind != common.DeployCommandGroupType {
+			t.Errorf("unexpected apply command '%+v'", commands[0].Apply)
+		}
+		wantAttributes := map[string]interface{}{"retries": float64(3), "build": map[string]interface{}{"cache": true}}
+		if !reflect.DeepEqual(commands[0].GetAttributes(), wantAttributes) {
+			t.Errorf("got command attributes '%v', want '%v'", commands[0].GetAttributes(), wantAttributes)
+		}
+	})
+
+	t.Run("devfile 2.2.0 registry stack", func(t *testing.T) {
+		d, err := ParseInMemoryAndValidate([]byte(registryStackDevfile))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		var names []string
+		for _, component := range d.Data.GetComponents() {
+			names = append(names, component.GetName())
+		}
+		if got := strings.Join(names, ","); got != "runtime,image-build,kubernetes-deploy" {
+			t.Errorf("unexpected component names '%s'", got)
+		}
+		var ids []string
+		for _, command := range d.Data.GetCommands() {
+			ids = append(ids, command.GetId())
+		}
+		if got := strings.Join(ids, ","); got != "install,run,debug,test,build-image,deployk8s,deploy" {
+			t.Errorf("unexpected command ids '%s'", got)
+		}
+		starterProjects := d.Data.(*v220.Devfile220).GetStarterProjects()
+		if location := starterProjects[0].Git.GetLocation(); location != "https://github.com/odo-devfiles/nodejs-ex.git" {
+			t.Errorf("unexpected starter project location '%s'", location)
+		}
+
+		// The devfile is written with the names and ids at the top level of the items, and parses back
+		content, err := d.Encode(WriteOptions{Format: FormatYAML})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if !strings.Contains(string(content), "  name: runtime\n") || strings.Contains(string(content), "    name: runtime\n") {
+			t.Errorf("expected the component names at the top level of the components, got:\n%s", content)
+		}
+		reparsed, err := ParseInMemoryAndValidate(content)
+		if err != nil {
+			t.Fatalf("unexpected error parsing the written devfile: %v", err)
+		}
+		if !reflect.DeepEqual(reparsed.Data, d.Data) {
+			t.Errorf("written devfile parsed as '%+v', want '%+v'", reparsed.Data, d.Data)
+		}
+	})
+}
+
+// registryStackDevfile is the nodejs stack of the devfile registry, in the devfile 2.2.0 layout
+const registryStackDevfile = `schemaVersion: 2.2.0
+metadata:
+  name: nodejs
+  displayName: Node.js Runtime
+  description: Node.js 18 application
+  icon: https://nodejs.org/static/images/logos/nodejs-new-pantone-black.svg
+  tags:
+    - Node.js
+    - Express
+    - ubi8
+  projectType: Node.js
+  language: JavaScript
+  version: 2.2.0
+starterProjects:
+  - name: nodejs-starter
+    git:
+      remotes:
+        origin: https://github.com/odo-devfiles/nodejs-ex.git
+variables:
+  CONTAINER_IMAGE: quay.io/unknown-account/myimage
+components:
+  - name: runtime
+    container:
+      image: registry.access.redhat.com/ubi8/nodejs-18:1-32
+      args: ['tail', '-f', '/dev/null']
+      memoryLimit: 1024Mi
+      mountSources: true
+      env:
+        - name: DEBUG_PORT
+          value: '5858'
+      endpoints:
+        - name: http-node
+          targetPort: 3000
+        - exposure: none
+          name: debug
+          targetPort: 5858
+  - name: image-build
+    image:
+      imageName: "{{CONTAINER_IMAGE}}"
+      dockerfile:
+        uri: Dockerfile
+        buildContext: .
+        rootRequired: false
+  - name: kubernetes-deploy
+    attributes:
+      deployment/replicas: 1
+      deployment/cpuRequest: 10m
+      deployment/memoryRequest: 50Mi
+      deployment/container-port: 3000
+    kubernetes:
+      uri: kubernetes/deploy.yaml
+      endpoints:
+        - name: http-3000
+          targetPort: 3000
+commands:
+  - id: install
+    exec:
+      component: runtime
+      commandLine: npm install
+      workingDir: ${PROJECT_SOURCE}
+      group:
+        kind: build
+        isDefault: true
+  - id: run
+    exec:
+      component: runtime
+      commandLine: npm start
+      workingDir: ${PROJECT_SOURCE}
+      group:
+        kind: run
+        isDefault: true
+  - id: debug
+    exec:
+      component: runtime
+      commandLine: npm run debug
+      workingDir: ${PROJECT_SOURCE}
+      group:
+        kind: debug
+        isDefault: true
+  - id: test
+    exec:
+      component: runtime
+      commandLine: npm test
+      workingDir: ${PROJECT_SOURCE}
+      group:
+        kind: test
+        isDefault: true
+  - id: build-image
+    apply:
+      component: image-build
+  - id: deployk8s
+    apply:
+      component: kubernetes-deploy
+  - id: deploy
+    composite:
+      commands:
+        - build-image
+        - deployk8s
+      group:
+        kind: deploy
+        isDefault: true
+`
+
+func TestParseDevfile(t *testing.T) {
+
+	const devfile = `schemaVersion: 2.2.0
+variables:
+  version: "14"
+projects:
+  - name: nodejs-web-app
+    git:
+      location: https://github.com/odo-devfiles/nodejs-ex.git
+components:
+  - name: cache
+    volume: {}
+  - name: image
+    image:
+      imageName: quay.io/nodejs:{{version}}
+      dockerfile:
+        uri: Dockerfile
+`
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/devfile.yaml":
+			fmt.Fprint(w, devfile)
+		case "/devfiles/nodejs/devfile.yaml":
+			fmt.Fprint(w, parentDevfile)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	fs := filesystem.NewFakeFs()
+	if err := fs.WriteFile("/devfiles/devfile.yaml", []byte(devfile), 0644); err != nil {
+		t.Fatalf("failed to write devfile: %v", err)
+	}
+	if err := fs.WriteFile("/registry/nodejs/devfile.yaml", []byte(parentDevfile), 0644); err != nil {
+		t.Fatalf("failed to write devfile: %v", err)
+	}
+
+	noFlatten := false
+	parentByID := []byte("schemaVersion: 2.1.0\nparent:\n  id: nodejs\n")
+	unnamed := []byte("schemaVersion: 2.2.0\ncomponents:\n  - container:\n      image: quay.io/nodejs\n")
+
+	tests := []struct {
+		name           string
+		args           ParserArgs
+		wantComponents int
+		wantImage      string
+		wantClonePath  string
+		wantErr        string
+	}{
+		{
+			name:    "Case 1: Devfile without container component",
+			args:    ParserArgs{Data: []byte(devfile)},
+			wantErr: validate.ErrorNoContainerComponent,
+		},
+		{
+			name:           "Case 2: Container check skipped and variable overrides",
+			args:           ParserArgs{Data: []byte(devfile), SkipContainerCheck: true, VariableOverrides: map[string]string{"version": "16"}},
+			wantComponents: 2,
+			wantImage:      "quay.io/nodejs:16",
+		},
+		{
+			name:           "Case 3: Schema validation only",
+			args:           ParserArgs{Data: []byte(devfile), ValidationLevel: ValidateSchema},
+			wantComponents: 2,
+			wantImage:      "quay.io/nodejs:14",
+		},
+		{
+			name:    "Case 4: Schema validation",
+			args:    ParserArgs{Data: unnamed, ValidationLevel: ValidateSchema},
+			wantErr: "name is required",
+		},
+		{
+			name:           "Case 5: No validation",
+			args:           ParserArgs{Data: unnamed, ValidationLevel: ValidateNone},
+			wantComponents: 1,
+		},
+		{
+			name:           "Case 6: Path on a custom filesystem with defaults",
+			args:           ParserArgs{Path: "/devfiles/devfile.yaml", Fs: fs, SetDefaults: true, ValidationLevel: ValidateSchema},
+			wantComponents: 2,
+			wantImage:      "quay.io/nodejs:14",
+			wantClonePath:  "nodejs-web-app",
+		},
+		{
+			name:           "Case 7: URL",
+			args:           ParserArgs{URL: server.URL + "/devfile.yaml", HTTPClient: server.Client(), SkipContainerCheck: true},
+			wantComponents: 2,
+			wantImage:      "quay.io/nodejs:14",
+		},
+		{
+			name:    "Case 8: URL not found",
+			args:    ParserArgs{URL: server.URL + "/missing.yaml"},
+			wantErr: "Not Found",
+		},
+		{
+			name:           "Case 9: Parent id looked up in the registries",
+			args:           ParserArgs{Data: parentByID, RegistryURLs: []string{server.URL + "/missing", server.URL}},
+			wantComponents: 2,
+		},
+		{
+			name:           "Case 10: Parent id in a local registry directory",
+			args:           ParserArgs{Data: parentByID, Fs: fs, RegistryURLs: []string{"/registry"}},
+			wantComponents: 2,
+		},
+		{
+			name:    "Case 11: Parent id without registry",
+			args:    ParserArgs{Data: parentByID},
+			wantErr: "registry url is required",
+		},
+		{
+			name: "Case 12: Parent not flattened",
+			args: ParserArgs{Data: parentByID, Flatten: &noFlatten, ValidationLevel: ValidateSchema},
+		},
+		{
+			name:    "Case 13: Several devfile sources",
+			args:    ParserArgs{Path: "/devfiles/devfile.yaml", Data: []byte(devfile)},
+			wantErr: "exactly one of the devfile path, url or data must be provided",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d, err := ParseDevfile(context.Background(), tt.args)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("expected error containing '%s', got %v", tt.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			components := d.Data.GetComponents()
+			if len(components) != tt.wantComponents {
+				t.Fatalf("expected %d components, got %d", tt.wantComponents, len(components))
+			}
+			if tt.wantImage != "" && components[1].Image.ImageName != tt.wantImage {
+				t.Errorf("expected image '%s', got '%s'", tt.wantImage, components[1].Image.ImageName)
+			}
+			if projects := d.Data.GetProjects(); len(projects) > 0 && projects[0].ClonePath != tt.wantClonePath {
+				t.Errorf("expected clone path '%s', got '%s'", tt.wantClonePath, projects[0].ClonePath)
+			}
+		})
+	}
+
+	t.Run("cancelled context", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		_, err := ParseDevfile(ctx, ParserArgs{Data: parentByID, RegistryURLs: []string{server.URL}})
+		if err == nil || !strings.Contains(err.Error(), context.Canceled.Error()) {
+			t.Errorf("expected the parsing to be cancelled, got %v", err)
+		}
+	})
+}
@@ -0,0 +1,319 @@
+package parser
+
+import (
+	"encoding/json"
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/devfile/parser/pkg/devfile/parser/data/common"
+	"github.com/devfile/parser/pkg/util"
+)
+
+// pluginContribution holds the components, commands and events contributed by a plugin,
+// and the namespaced names of its components and commands by lowercased original name
+type pluginContribution struct {
+	components     []map[string]interface{}
+	commands       []map[string]interface{}
+	events         map[string]interface{}
+	componentNames map[string]string
+	commandIds     map[string]string
+}
+
+// hasPlugins returns true if at least one of the components is a plugin
+func hasPlugins(components []common.DevfileComponent) bool {
+	for _, component := range components {
+		if component.Plugin != nil {
+			return true
+		}
+	}
+	return false
+}
+
+// getPluginReference returns the reference to the devfile of the plugin
+func getPluginReference(plugin common.Plugin) devfileReference {
+	ref := devfileReference{
+		uri:         plugin.Uri,
+		id:          plugin.Id,
+		registryURL: plugin.RegistryUrl,
+		kubernetes:  plugin.Kubernetes,
+	}
+	if plugin.RegistryEntry != nil {
+		ref.id, ref.registryURL = plugin.RegistryEntry.Id, plugin.RegistryEntry.BaseUrl
+	}
+	return ref
+}
+
+// getPluginName returns the name of the plugin, inferred from its location if omitted
+func getPluginName(plugin common.Plugin) string {
+	ref := getPluginReference(plugin)
+	name := plugin.Name
+	switch {
+	case name != "":
+	case ref.id != "":
+		name = ref.id
+	case ref.uri != "":
+		name = strings.TrimSuffix(path.Base(ref.uri), path.Ext(ref.uri))
+	case ref.kubernetes != nil:
+		name = ref.kubernetes.Name
+	}
+	return strings.ToLower(util.GetDNS1123Name(name))
+}
+
+// resolvePlugins replaces the plugin components of the devfile by the components, commands
+// and events contributed by the plugins. Contributed items are prefixed with the plugin name,
+// and the references of the devfile to the items of a plugin are prefixed accordingly.
+func resolvePlugins(d *DevfileObj, resolveCtx *resolverContext) error {
+	if !hasPlugins(d.Data.GetComponents()) {
+		return nil
+	}
+
+	content, err := toJSONMap(d.Data)
+	if err != nil {
+		return err
+	}
+	components, err := toItemList(content["components"])
+	if err != nil {
+		return errors.Wrapf(err, "invalid components in devfile")
+	}
+	commands, err := toItemList(content["commands"])
+	if err != nil {
+		return errors.Wrapf(err, "invalid commands in devfile")
+	}
+	events, _ := content["events"].(map[string]interface{})
+
+	var ownComponents []map[string]interface{}
+	contributions := make([]*pluginContribution, len(components))
+	for i, component := range components {
+		pluginContent, ok := component["plugin"].(map[string]interface{})
+		if !ok {
+			ownComponents = append(ownComponents, component)
+			continue
+		}
+		contribution, err := resolvePlugin(d, pluginContent, resolveCtx)
+		if err != nil {
+			return err
+		}
+		contributions[i] = &contribution
+	}
+	if err = renamePluginReferences(ownComponents, commands, events, contributions); err != nil {
+		return err
+	}
+
+	var resolved []map[string]interface{}
+	for i, component := range components {
+		contribution := contributions[i]
+		if contribution == nil {
+			resolved = append(resolved, component)
+			continue
+		}
+		resolved = append(resolved, contribution.components...)
+		commands = append(commands, contribution.commands...)
+		events = mergeEvents(events, nil, contribution.events)
+	}
+
+	// Contributed items must not collide with the items of the devfile or of other plugins
+	if _, err = appendUniqueItems("components", "devfile", nil, resolved); err != nil {
+		return err
+	}
+	if _, err = appendUniqueItems("commands", "devfile", nil, commands); err != nil {
+		return err
+	}
+
+	content["components"] = fromItemList(resolved)
+	content["commands"] = fromItemList(commands)
+	if len(events) > 0 {
+		content["events"] = events
+	}
+
+	merged, err := json.Marshal(content)
+	if err != nil {
+		return errors.Wrapf(err, "failed to encode devfile")
+	}
+	return setDevfileData(d, merged)
+}
+
+// resolvePlugin fetches and parses the plugin devfile, applies the plugin overrides
+// and returns the namespaced components, commands and events of the plugin
+func resolvePlugin(d *DevfileObj, pluginContent map[string]interface{}, resolveCtx *resolverContext) (contribution pluginContribution, err error) {
+	var plugin common.Plugin
+	rawPlugin, err := json.Marshal(pluginContent)
+	if err != nil {
+		return contribution, errors.Wrapf(err, "failed to encode plugin")
+	}
+	if err = json.Unmarshal(rawPlugin, &plugin); err != nil {
+		return contribution, errors.Wrapf(err, "failed to decode plugin")
+	}
+
+	name := getPluginName(plugin)
+	if name == "" {
+		return contribution, fmt.Errorf("plugin name can't be inferred, please set a name")
+	}
+	origin := fmt.Sprintf("plugin '%s'", name)
+
+	pluginObj, err := parseReference(d, getPluginReference(plugin), resolveCtx)
+	if err != nil {
+		return contribution, errors.Wrapf(err, "failed to resolve %s", origin)
+	}
+	pluginData, err := toJSONMap(pluginObj.Data)
+	if err != nil {
+		return contribution, err
+	}
+
+	for _, section := range []string{"components", "commands"} {
+		items, err := toItemList(pluginData[section])
+		if err != nil {
+			return contribution, errors.Wrapf(err, "invalid %s in %s", section, origin)
+		}
+		overrides, err := toItemList(pluginContent[section])
+		if err != nil {
+			return contribution, errors.Wrapf(err, "invalid %s overrides of %s", section, origin)
+		}
+		if items, err = overrideItems(section, origin, items, overrides); err != nil {
+			return contribution, err
+		}
+
+		if section == "components" {
+			contribution.components = items
+		} else {
+			contribution.commands = items
+		}
+	}
+	contribution.events, _ = pluginData["events"].(map[string]interface{})
+
+	namespacePluginContribution(name, &contribution)
+	return contribution, nil
+}
+
+// namespacePluginContribution prefixes the names of the contributed components and the ids of the
+// contributed commands with the plugin name, and updates the references between them accordingly
+func namespacePluginContribution(name string, contribution *pluginContribution) {
+	contribution.componentNames = namespaceItems(name, contribution.components)
+	contribution.commandIds = namespaceItems(name, contribution.commands)
+
+	// The references within a plugin are to its own items
+	_ = renameReferences(contribution.components, contribution.commands, contribution.events, func(kind string, value interface{}) (interface{}, error) {
+		names := contribution.commandIds
+		if kind == "component" {
+			names = contribution.componentNames
+		}
+		if s, ok := value.(string); ok {
+			if renamed, ok := names[strings.ToLower(s)]; ok {
+				return renamed, nil
+			}
+		}
+		return value, nil
+	})
+}
+
+// renamePluginReferences prefixes the references of the devfile components, commands and events to the components
+// and commands contributed by the plugins. The references to the items of the devfile itself are kept, and a
+// reference to an item contributed by several plugins must be prefixed in the devfile.
+func renamePluginReferences(components, commands []map[string]interface{}, events map[string]interface{}, contributions []*pluginContribution) error {
+	own := map[string]map[string]bool{"component": {}, "command": {}}
+	for _, component := range components {
+		own["component"][getLowerItemKey(component)] = true
+	}
+	for _, command := range commands {
+		own["command"][getLowerItemKey(command)] = true
+	}
+	contributed := map[string]map[string][]string{"component": {}, "command": {}}
+	for _, contribution := range contributions {
+		if contribution == nil {
+			continue
+		}
+		for key, renamed := range contribution.componentNames {
+			contributed["component"][key] = append(contributed["component"][key], renamed)
+		}
+		for key, renamed := range contribution.commandIds {
+			contributed["command"][key] = append(contributed["command"][key], renamed)
+		}
+	}
+
+	return renameReferences(components, commands, events, func(kind string, value interface{}) (interface{}, error) {
+		s, ok := value.(string)
+		if !ok || own[kind][strings.ToLower(s)] {
+			return value, nil
+		}
+		switch names := contributed[kind][strings.ToLower(s)]; len(names) {
+		case 0:
+			return value, nil
+		case 1:
+			return names[0], nil
+		default:
+			sort.Strings(names)
+			return nil, fmt.Errorf("%s '%s' is contributed by several plugins, reference it with its plugin prefix as one of '%s'",
+				kind, s, strings.Join(names, "', '"))
+		}
+	})
+}
+
+// renameReferences replaces the references of the volume mounts to components, of the commands to components
+// and, for composite commands, to other commands, and of the events to commands by the values returned by rename
+func renameReferences(components, commands []map[string]interface{}, events map[string]interface{},
+	rename func(kind string, value interface{}) (interface{}, error)) (err error) {
+	for _, component := range components {
+		for _, value := range component {
+			member, ok := value.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			mounts, _ := toItemList(member["volumeMounts"])
+			for _, mount := range mounts {
+				if mount["name"], err = rename("component", mount["name"]); err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	for _, command := range commands {
+		for _, value := range command {
+			member, ok := value.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			if _, ok := member["component"]; ok {
+				if member["component"], err = rename("component", member["component"]); err != nil {
+					return err
+				}
+			}
+			if subCommands, ok := member["commands"].([]interface{}); ok {
+				for i := range subCommands {
+					if subCommands[i], err = rename("command", subCommands[i]); err != nil {
+						return err
+					}
+				}
+			}
+		}
+	}
+
+	for _, value := range events {
+		if ids, ok := value.([]interface{}); ok {
+			for i := range ids {
+				if ids[i], err = rename("command", ids[i]); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// namespaceItems prefixes the key of every item with the given name and returns the renamed keys
+func namespaceItems(name string, items []map[string]interface{}) map[string]string {
+	renamed := make(map[string]string)
+	for _, item := range items {
+		key := getItemKey(item)
+		if key == "" {
+			continue
+		}
+		namespaced := fmt.Sprintf("%s-%s", name, key)
+		setItemKey(item, namespaced)
+		renamed[strings.ToLower(key)] = namespaced
+	}
+	return renamed
+}
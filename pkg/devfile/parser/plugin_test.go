@@ -0,0 +1,231 @@
+package parser
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/devfile/parser/pkg/devfile/parser/data/common"
+)
+
+const pluginDevfile = `schemaVersion: 2.1.0
+metadata:
+  name: java-tools
+components:
+  - container:
+      name: tools
+      image: quay.io/java-tools
+      memoryLimit: 512Mi
+      volumeMounts:
+        - name: m2
+          path: /home/user/.m2
+  - volume:
+      name: m2
+commands:
+  - exec:
+      id: build
+      component: tools
+      commandLine: mvn package
+events:
+  postStart:
+    - build
+`
+
+func TestParseAndValidateWithPlugin(t *testing.T) {
+
+	dir, err := ioutil.TempDir("", "devfile-plugin")
+	if err != nil {
+		t.Fatalf("failed to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(dir)
+	if err := ioutil.WriteFile(filepath.Join(dir, "java-tools.yaml"), []byte(pluginDevfile), 0644); err != nil {
+		t.Fatalf("failed to write plugin devfile: %v", err)
+	}
+
+	t.Run("plugin contributes namespaced components, commands and events", func(t *testing.T) {
+		devfilePath := filepath.Join(dir, "devfile.yaml")
+		err := ioutil.WriteFile(devfilePath, []byte(`schemaVersion: 2.1.0
+components:
+  - container:
+      name: tools
+      image: quay.io/runtime
+  - plugin:
+      uri: java-tools.yaml
+      components:
+        - container:
+            name: tools
+            memoryLimit: 1Gi
+`), 0644)
+		if err != nil {
+			t.Fatalf("failed to write devfile: %v", err)
+		}
+
+		d, err := ParseAndValidate(devfilePath)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		components := d.Data.GetComponents()
+		if len(components) != 3 {
+			t.Fatalf("expected 3 components, got %d", len(components))
+		}
+		tools := components[1].Container
+		if tools == nil || tools.Name != "java-tools-tools" {
+			t.Fatalf("expected the namespaced plugin container, got %v", components[1])
+		}
+		if tools.MemoryLimit != "1Gi" {
+			t.Errorf("plugin override not applied, got memoryLimit '%s'", tools.MemoryLimit)
+		}
+		if tools.VolumeMounts[0].Name != "java-tools-m2" {
+			t.Errorf("volume mount not namespaced, got '%s'", tools.VolumeMounts[0].Name)
+		}
+
+		commands := d.Data.GetCommands()
+		if len(commands) != 1 || commands[0].Exec.Id != "java-tools-build" || commands[0].Exec.Component != "java-tools-tools" {
+			t.Errorf("expected the namespaced plugin command, got %v", commands[0].Exec)
+		}
+		if got := strings.Join(d.Data.GetEvents().PostStart, ","); got != "java-tools-build" {
+			t.Errorf("expected namespaced postStart events, got '%s'", got)
+		}
+	})
+
+	t.Run("devfile references to the plugin items are namespaced", func(t *testing.T) {
+		devfilePath := filepath.Join(dir, "references.yaml")
+		err := ioutil.WriteFile(devfilePath, []byte(`schemaVersion: 2.1.0
+components:
+  - container:
+      name: runtime
+      image: quay.io/runtime
+      volumeMounts:
+        - name: m2
+          path: /home/user/.m2
+  - plugin:
+      uri: java-tools.yaml
+commands:
+  - exec:
+      id: run
+      component: runtime
+      commandLine: java -jar target/app.jar
+  - exec:
+      id: package
+      component: Tools
+      commandLine: mvn package -DskipTests
+  - composite:
+      id: build-and-run
+      commands:
+        - build
+        - run
+events:
+  postStop:
+    - Build
+`), 0644)
+		if err != nil {
+			t.Fatalf("failed to write devfile: %v", err)
+		}
+
+		d, err := ParseAndValidate(devfilePath)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if name := d.Data.GetComponents()[0].Container.VolumeMounts[0].Name; name != "java-tools-m2" {
+			t.Errorf("expected the volume mount of the plugin volume to be namespaced, got '%s'", name)
+		}
+		commands := d.Data.GetCommands()
+		if commands[0].Exec.Component != "runtime" || commands[1].Exec.Component != "java-tools-tools" {
+			t.Errorf("unexpected command components '%s', '%s'", commands[0].Exec.Component, commands[1].Exec.Component)
+		}
+		if got := strings.Join(commands[2].Composite.Commands, ","); got != "java-tools-build,run" {
+			t.Errorf("expected the composite command to run the namespaced plugin command, got '%s'", got)
+		}
+		events := d.Data.GetEvents()
+		if got := strings.Join(events.PostStop, ","); got != "java-tools-build" {
+			t.Errorf("expected namespaced postStop events, got '%s'", got)
+		}
+		if got := strings.Join(events.PostStart, ","); got != "java-tools-build" {
+			t.Errorf("expected the postStart events of the plugin, got '%s'", got)
+		}
+	})
+
+	t.Run("devfile reference to an item of several plugins", func(t *testing.T) {
+		devfilePath := filepath.Join(dir, "ambiguous.yaml")
+		err := ioutil.WriteFile(devfilePath, []byte(`schemaVersion: 2.1.0
+components:
+  - plugin:
+      name: java
+      uri: java-tools.yaml
+  - plugin:
+      name: maven
+      uri: java-tools.yaml
+events:
+  postStop:
+    - build
+`), 0644)
+		if err != nil {
+			t.Fatalf("failed to write devfile: %v", err)
+		}
+
+		_, err = ParseAndValidate(devfilePath)
+		wantErr := "command 'build' is contributed by several plugins, reference it with its plugin prefix as one of 'java-build', 'maven-build'"
+		if err == nil || !strings.Contains(err.Error(), wantErr) {
+			t.Errorf("expected error containing '%s', got '%v'", wantErr, err)
+		}
+	})
+
+	t.Run("plugin override of a missing command", func(t *testing.T) {
+		devfilePath := filepath.Join(dir, "invalid.yaml")
+		err := ioutil.WriteFile(devfilePath, []byte(`schemaVersion: 2.1.0
+components:
+  - plugin:
+      name: java
+      uri: java-tools.yaml
+      commands:
+        - exec:
+            id: missing
+`), 0644)
+		if err != nil {
+			t.Fatalf("failed to write devfile: %v", err)
+		}
+
+		_, err = ParseAndValidate(devfilePath)
+		wantErr := "commands override 'missing' does not match any item in the plugin 'java'"
+		if err == nil || !strings.Contains(err.Error(), wantErr) {
+			t.Errorf("expected error containing '%s', got '%v'", wantErr, err)
+		}
+	})
+}
+
+func TestGetPluginName(t *testing.T) {
+
+	tests := []struct {
+		name   string
+		plugin common.Plugin
+		want   string
+	}{
+		{
+			name:   "explicit name",
+			plugin: common.Plugin{Name: "Java", Uri: "java-tools.yaml"},
+			want:   "java",
+		},
+		{
+			name:   "inferred from registry id",
+			plugin: common.Plugin{RegistryEntry: &common.RegistryEntry{Id: "redhat/java11"}},
+			want:   "redhat-java11",
+		},
+		{
+			name:   "inferred from uri",
+			plugin: common.Plugin{Uri: "https://example.com/plugins/java-tools.yaml"},
+			want:   "java-tools",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := getPluginName(tt.plugin); got != tt.want {
+				t.Errorf("want: '%s', got: '%s'", tt.want, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,242 @@
+package parser
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"path/filepath"
+	"strings"
+
+	"github.com/ghodss/yaml"
+	"github.com/pkg/errors"
+	"k8s.io/klog"
+
+	devfileCtx "github.com/devfile/parser/pkg/devfile/parser/context"
+	"github.com/devfile/parser/pkg/devfile/parser/data"
+	"github.com/devfile/parser/pkg/devfile/parser/data/common"
+	"github.com/devfile/parser/pkg/testingutil/filesystem"
+)
+
+// maxReferenceDepth is the maximum number of nested parents and plugins followed when flattening a devfile
+const maxReferenceDepth = 10
+
+// devWorkspaceTemplateKind is the kind of the Kubernetes resource referenced by a kubernetes parent or plugin
+const devWorkspaceTemplateKind = "DevWorkspaceTemplate"
+
+// resolverContext keeps track of the devfiles visited while resolving a chain of parents and plugins
+type resolverContext struct {
+	// location of the devfile being resolved, an absolute path or a URL
+	location string
+
+	// locations of the devfiles visited before the current one, child first
+	visited []string
+
+	// ctx cancels the download of the referenced devfiles
+	ctx context.Context
+
+	// httpClient downloads the devfiles referenced by URL, a client with the default timeout is used when nil
+	httpClient *http.Client
+
+	// maxSize is the maximum size in bytes of the downloaded devfiles, the default maximum size when not positive
+	maxSize int64
+
+	// registryURLs are the registries looked up for the devfiles referenced by id without registry url
+	registryURLs []string
+}
+
+// newResolverContext returns a resolverContext for the devfile at the given location
+func newResolverContext(location string) *resolverContext {
+	return &resolverContext{
+		location: location,
+		ctx:      context.Background(),
+	}
+}
+
+// child returns the resolverContext of a devfile referenced from the current one
+func (r *resolverContext) child(location string) *resolverContext {
+	visited := append([]string{}, r.visited...)
+	return &resolverContext{
+		location:     location,
+		visited:      append(visited, r.location),
+		ctx:          r.ctx,
+		httpClient:   r.httpClient,
+		maxSize:      r.maxSize,
+		registryURLs: r.registryURLs,
+	}
+}
+
+// hasVisited returns true if the given location is already part of the reference chain
+func (r *resolverContext) hasVisited(location string) bool {
+	if location == r.location {
+		return true
+	}
+	for _, v := range r.visited {
+		if v == location {
+			return true
+		}
+	}
+	return false
+}
+
+// devfileReference references a devfile by uri, by id in a registry or by a Kubernetes DevWorkspaceTemplate
+type devfileReference struct {
+	uri         string
+	id          string
+	registryURL string
+	kubernetes  *common.Kubernetes
+}
+
+// parseReference fetches and parses the devfile referenced from the devfile d
+func parseReference(d *DevfileObj, ref devfileReference, resolveCtx *resolverContext) (refObj DevfileObj, err error) {
+	if len(resolveCtx.visited)+1 >= maxReferenceDepth {
+		return refObj, fmt.Errorf("devfile reference chain exceeds the maximum depth of %d", maxReferenceDepth)
+	}
+
+	location, content, err := fetchReference(ref, d.Ctx.GetFs(), resolveCtx, d.Ctx.GetApiVersion())
+	if err != nil {
+		return refObj, err
+	}
+
+	if resolveCtx.hasVisited(location) {
+		return refObj, fmt.Errorf("devfile reference cycle detected, '%s' is already referenced in the chain", location)
+	}
+	klog.V(4).Infof("resolving devfile referenced from '%s'", location)
+
+	// Parse the referenced devfile, which resolves its own parent and plugins
+	refObj.Ctx = devfileCtx.NewDevfileCtx(location)
+	refObj.Ctx.Fs = d.Ctx.GetFs()
+	if err = refObj.Ctx.PopulateFromBytes(content); err != nil {
+		return refObj, errors.Wrapf(err, "failed to populate the devfile '%s'", location)
+	}
+	if strings.HasPrefix(refObj.Ctx.GetApiVersion(), "1.") {
+		return refObj, fmt.Errorf("devfile '%s' has apiVersion '%s', only schemaVersion 2.x devfiles can be referenced", location, refObj.Ctx.GetApiVersion())
+	}
+	refObj, err = parseDevfile(refObj, resolveCtx.child(location))
+	if err != nil {
+		return refObj, errors.Wrapf(err, "failed to parse the devfile '%s'", location)
+	}
+	return refObj, nil
+}
+
+// setDevfileData replaces the devfile data by the given JSON content
+func setDevfileData(d *DevfileObj, content []byte) (err error) {
+	d.Data, err = data.NewDevfileData(d.Ctx.GetApiVersion())
+	if err != nil {
+		return err
+	}
+	if err = json.Unmarshal(content, &d.Data); err != nil {
+		return errors.Wrapf(err, "failed to decode flattened devfile content")
+	}
+	return nil
+}
+
+// toJSONMap converts the devfile data into a decoded JSON object
+func toJSONMap(devfileData data.DevfileData) (map[string]interface{}, error) {
+	content, err := json.Marshal(devfileData)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to encode devfile")
+	}
+	var m map[string]interface{}
+	if err := json.Unmarshal(content, &m); err != nil {
+		return nil, errors.Wrapf(err, "failed to decode devfile")
+	}
+	return m, nil
+}
+
+// fetchReference returns the location and the content of the referenced devfile
+func fetchReference(ref devfileReference, fs filesystem.Filesystem, resolveCtx *resolverContext, schemaVersion string) (location string, content []byte, err error) {
+	switch {
+	case ref.uri != "":
+		if location, err = devfileCtx.ResolveLocation(resolveCtx.location, ref.uri); err != nil {
+			return "", nil, err
+		}
+		content, err = resolveCtx.loadDevfile(location, fs)
+		return location, content, err
+
+	case ref.id != "":
+		if ref.registryURL != "" {
+			location = getRegistryDevfileURL(ref.registryURL, ref.id)
+			content, err = resolveCtx.loadDevfile(location, fs)
+			return location, content, err
+		}
+		if len(resolveCtx.registryURLs) == 0 {
+			return "", nil, fmt.Errorf("registry url is required to resolve devfile id '%s'", ref.id)
+		}
+		// Look the devfile up in the registries in order
+		var errs []string
+		for _, registryURL := range resolveCtx.registryURLs {
+			location = getRegistryDevfileURL(registryURL, ref.id)
+			if content, err = resolveCtx.loadDevfile(location, fs); err == nil {
+				return location, content, nil
+			}
+			if resolveCtx.ctx.Err() != nil {
+				return "", nil, resolveCtx.ctx.Err()
+			}
+			errs = append(errs, fmt.Sprintf("%s: %v", registryURL, err))
+		}
+		return "", nil, fmt.Errorf("devfile id '%s' not found in the registries: %s", ref.id, strings.Join(errs, "; "))
+
+	case ref.kubernetes != nil:
+		return fetchKubernetesReference(ref.kubernetes, fs, resolveCtx, schemaVersion)
+	}
+
+	return "", nil, fmt.Errorf("no devfile is referenced")
+}
+
+// fetchKubernetesReference returns the devfile content of a DevWorkspaceTemplate referenced by a kubernetes parent or plugin.
+// The DevWorkspaceTemplate manifest is either inlined or fetched from its uri, a lookup in a cluster is not supported.
+func fetchKubernetesReference(k *common.Kubernetes, fs filesystem.Filesystem, resolveCtx *resolverContext, schemaVersion string) (location string, content []byte, err error) {
+	var manifest []byte
+	switch {
+	case k.Inlined != "":
+		location = fmt.Sprintf("kubernetes://%s/%s", k.Namespace, k.Name)
+		manifest = []byte(k.Inlined)
+	case k.Uri != "":
+		if location, err = devfileCtx.ResolveLocation(resolveCtx.location, k.Uri); err != nil {
+			return "", nil, err
+		}
+		if manifest, err = resolveCtx.loadDevfile(location, fs); err != nil {
+			return "", nil, err
+		}
+	default:
+		return "", nil, fmt.Errorf("kubernetes reference '%s' must provide an inlined manifest or a uri, fetching it from a cluster is not supported", k.Name)
+	}
+
+	var template map[string]interface{}
+	if err = yaml.Unmarshal(manifest, &template); err != nil {
+		return "", nil, errors.Wrapf(err, "failed to decode kubernetes reference '%s'", location)
+	}
+	if kind, _ := template["kind"].(string); kind != devWorkspaceTemplateKind {
+		return "", nil, fmt.Errorf("kubernetes reference '%s' must be of kind '%s', got '%s'", location, devWorkspaceTemplateKind, kind)
+	}
+	spec, ok := template["spec"].(map[string]interface{})
+	if !ok {
+		return "", nil, fmt.Errorf("kubernetes reference '%s' has no spec", location)
+	}
+
+	// DevWorkspaceTemplate specs don't carry a schema version, use the one of the child
+	spec["schemaVersion"] = schemaVersion
+	content, err = json.Marshal(spec)
+	return location, content, err
+}
+
+// getRegistryDevfileURL returns the location of the devfile with the given id in the registry. An http(s) registry
+// serves its stacks under <url>/devfiles, a registry in a local directory contains the stack directories.
+func getRegistryDevfileURL(registryURL string, id string) string {
+	if !devfileCtx.IsURL(registryURL) {
+		return filepath.Join(strings.TrimPrefix(registryURL, "file://"), id, "devfile.yaml")
+	}
+	return fmt.Sprintf("%s/devfiles/%s/devfile.yaml", strings.TrimSuffix(registryURL, "/"), id)
+}
+
+// loadDevfile reads the devfile content from an http(s) URL or from a file path
+func (r *resolverContext) loadDevfile(location string, fs filesystem.Filesystem) ([]byte, error) {
+	if err := r.ctx.Err(); err != nil {
+		return nil, err
+	}
+	if devfileCtx.IsURL(location) {
+		return devfileCtx.DownloadDevfile(r.ctx, r.httpClient, location, r.maxSize)
+	}
+	return fs.ReadFile(location)
+}
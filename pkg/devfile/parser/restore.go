@@ -0,0 +1,310 @@
+package parser
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// contributedSections are the sections whose items can be contributed to the devfile while parsing it,
+// by its parent, its plugins or the implicit volumes, by kind of item
+var contributedSections = map[string]string{
+	"components":      "component",
+	"commands":        "command",
+	"projects":        "project",
+	"starterProjects": "starter project",
+}
+
+// restoreRawContent returns the content of the devfile data to write unflattened. The values which were not
+// changed since the devfile was parsed are restored to their raw value, before the parent and plugins were
+// merged in, the variables substituted and the defaults set. The changed values are written as they are now.
+// The parsed content is the content of the devfile data when it was parsed.
+func restoreRawContent(raw, parsed, current map[string]interface{}) (map[string]interface{}, error) {
+	restored := make(map[string]interface{})
+	for key := range contributedSections {
+		items, err := restoreSection(key, raw[key], parsed[key], current[key])
+		if err != nil {
+			return nil, err
+		}
+		if len(items) > 0 {
+			restored[key] = items
+		}
+	}
+
+	for key, value := range current {
+		if _, ok := contributedSections[key]; ok {
+			continue
+		}
+		rawValue, inRaw := raw[key]
+		parsedValue, inParsed := parsed[key]
+		if key == "events" {
+			events, err := restoreEvents(rawValue, parsedValue, value)
+			if err != nil {
+				return nil, err
+			}
+			if len(events) > 0 {
+				restored[key] = events
+			}
+			continue
+		}
+		if restoredValue, ok := restoreValue(rawValue, inRaw, parsedValue, inParsed, value); ok {
+			restored[key] = restoredValue
+		}
+	}
+	return restored, nil
+}
+
+// restoreSection returns the items of a components, commands, projects or starter projects section to write.
+// The raw items are written in their order, restored when they were not changed, and followed by the added items.
+// The items contributed while parsing are not written, and can't be changed or deleted.
+func restoreSection(section string, raw, parsed, current interface{}) ([]interface{}, error) {
+	rawItems, err := toItemList(raw)
+	if err != nil {
+		return nil, err
+	}
+	parsedItems, err := toItemList(parsed)
+	if err != nil {
+		return nil, err
+	}
+	currentItems, err := toItemList(current)
+	if err != nil {
+		return nil, err
+	}
+	rawByKey, parsedByKey, currentByKey := indexItems(rawItems), indexItems(parsedItems), indexItems(currentItems)
+	kind := contributedSections[section]
+
+	var items []interface{}
+	for _, rawItem := range rawItems {
+		key := getLowerItemKey(rawItem)
+		parsedItem, inParsed := parsedByKey[key]
+		currentItem, inCurrent := currentByKey[key]
+		switch {
+		case !inParsed:
+			// Replaced while parsing, e.g. a plugin component by the components of the plugin
+			items = append(items, rawItem)
+		case inCurrent:
+			restored, _ := restoreValue(rawItem, true, parsedItem, true, currentItem)
+			items = append(items, restored)
+		}
+	}
+
+	for _, currentItem := range currentItems {
+		key := getLowerItemKey(currentItem)
+		if _, inRaw := rawByKey[key]; inRaw {
+			continue
+		}
+		parsedItem, inParsed := parsedByKey[key]
+		if !inParsed {
+			items = append(items, currentItem)
+			continue
+		}
+		if !equalValues(parsedItem, currentItem) {
+			return nil, fmt.Errorf("cannot write the changes of %s '%s' which is not defined in the devfile but by its parent, a plugin "+
+				"or the parser, write the devfile flattened instead", kind, getItemKey(currentItem))
+		}
+	}
+
+	for key, parsedItem := range parsedByKey {
+		_, inRaw := rawByKey[key]
+		if _, inCurrent := currentByKey[key]; !inCurrent && !inRaw {
+			return nil, fmt.Errorf("cannot write the deletion of %s '%s' which is not defined in the devfile but by its parent, a plugin "+
+				"or the parser, write the devfile flattened instead", kind, getItemKey(parsedItem))
+		}
+	}
+	return items, nil
+}
+
+// restoreEvents returns the events to write. The commands bound to an event by the parent or the plugins
+// come before the commands bound by the devfile and are not written.
+func restoreEvents(raw, parsed, current interface{}) (map[string]interface{}, error) {
+	rawEvents, _ := raw.(map[string]interface{})
+	parsedEvents, _ := parsed.(map[string]interface{})
+	currentEvents, _ := current.(map[string]interface{})
+
+	events := make(map[string]interface{})
+	for event, value := range currentEvents {
+		rawIds, _ := rawEvents[event].([]interface{})
+		parsedIds, _ := parsedEvents[event].([]interface{})
+		currentIds, _ := value.([]interface{})
+		if reflect.DeepEqual(normalizeIds(parsedIds), normalizeIds(currentIds)) {
+			if len(rawIds) > 0 {
+				events[event] = rawIds
+			}
+			continue
+		}
+
+		// The contributed commands are the parsed ones not bound by the devfile
+		contributed := 0
+		if len(parsedIds) >= len(rawIds) && reflect.DeepEqual(normalizeIds(parsedIds[len(parsedIds)-len(rawIds):]), normalizeIds(rawIds)) {
+			contributed = len(parsedIds) - len(rawIds)
+		}
+		if len(currentIds) < contributed || !reflect.DeepEqual(normalizeIds(currentIds[:contributed]), normalizeIds(parsedIds[:contributed])) {
+			return nil, fmt.Errorf("cannot write the changes of the %s event which binds commands of the parent or a plugin, "+
+				"write the devfile flattened instead", event)
+		}
+		if ids := currentIds[contributed:]; len(ids) > 0 {
+			events[event] = ids
+		}
+	}
+
+	for event, value := range parsedEvents {
+		rawIds, _ := rawEvents[event].([]interface{})
+		parsedIds, _ := value.([]interface{})
+		if _, ok := currentEvents[event]; !ok && len(parsedIds) > len(rawIds) {
+			return nil, fmt.Errorf("cannot write the deletion of the %s event which binds commands of the parent or a plugin, "+
+				"write the devfile flattened instead", event)
+		}
+	}
+	return events, nil
+}
+
+// restoreValue returns the value to write and whether to write it. The raw value is restored when the current
+// value is the parsed one, otherwise the current value is written with its unchanged fields and items restored.
+func restoreValue(raw interface{}, inRaw bool, parsed interface{}, inParsed bool, current interface{}) (interface{}, bool) {
+	if inParsed && equalValues(parsed, current) {
+		return raw, inRaw
+	}
+
+	switch currentValue := current.(type) {
+	case map[string]interface{}:
+		parsedMap, ok := parsed.(map[string]interface{})
+		if !ok {
+			return current, true
+		}
+		rawMap, _ := raw.(map[string]interface{})
+		restored := make(map[string]interface{})
+		for key, value := range currentValue {
+			rawField, inRawMap := rawMap[key]
+			parsedField, inParsedMap := parsedMap[key]
+			// Command ids are lowercased once parsed
+			if id, ok := value.(string); ok && key == "id" && inRawMap {
+				if parsedId, ok := parsedField.(string); ok && strings.EqualFold(parsedId, id) {
+					restored[key] = rawField
+					continue
+				}
+			}
+			if restoredField, ok := restoreValue(rawField, inRawMap, parsedField, inParsedMap, value); ok {
+				restored[key] = restoredField
+			}
+		}
+		return restored, len(restored) > 0 || inRaw
+
+	case []interface{}:
+		parsedList, ok := parsed.([]interface{})
+		if !ok {
+			return current, true
+		}
+		rawList, _ := raw.([]interface{})
+		return restoreList(rawList, parsedList, currentValue), true
+	}
+	return current, true
+}
+
+// restoreList returns the items of a list to write. The items are matched by name, or by position when the items
+// have no name and the list length didn't change. Otherwise the current list is written.
+func restoreList(raw, parsed, current []interface{}) []interface{} {
+	rawByKey, rawKeyed := indexListItems(raw)
+	parsedByKey, parsedKeyed := indexListItems(parsed)
+	_, currentKeyed := indexListItems(current)
+
+	restored := make([]interface{}, 0, len(current))
+	switch {
+	case rawKeyed && parsedKeyed && currentKeyed:
+		for _, item := range current {
+			key := getLowerItemKey(item.(map[string]interface{}))
+			rawItem, inRaw := rawByKey[key]
+			parsedItem, inParsed := parsedByKey[key]
+			if value, ok := restoreValue(rawItem, inRaw, parsedItem, inParsed, item); ok {
+				restored = append(restored, value)
+			}
+		}
+	case len(raw) == len(parsed) && len(parsed) == len(current):
+		for i, item := range current {
+			value, _ := restoreValue(raw[i], true, parsed[i], true, item)
+			restored = append(restored, value)
+		}
+	default:
+		return current
+	}
+	return restored
+}
+
+// indexItems returns the items of a section by lowercased name or id
+func indexItems(items []map[string]interface{}) map[string]map[string]interface{} {
+	byKey := make(map[string]map[string]interface{}, len(items))
+	for _, item := range items {
+		byKey[getLowerItemKey(item)] = item
+	}
+	return byKey
+}
+
+// getLowerItemKey returns the lowercased name or id of an item, items being matched case-insensitively
+func getLowerItemKey(item map[string]interface{}) string {
+	return strings.ToLower(getItemKey(item))
+}
+
+// indexListItems returns the items of a list by lowercased name or id, and whether every item has a name or id
+func indexListItems(items []interface{}) (map[string]interface{}, bool) {
+	byKey := make(map[string]interface{}, len(items))
+	for _, value := range items {
+		item, ok := value.(map[string]interface{})
+		if !ok || getItemKey(item) == "" {
+			return nil, false
+		}
+		byKey[getLowerItemKey(item)] = item
+	}
+	return byKey, true
+}
+
+// equalValues returns true if the decoded JSON values are equal, the command ids being compared
+// case-insensitively as they are lowercased once parsed
+func equalValues(a, b interface{}) bool {
+	switch av := a.(type) {
+	case map[string]interface{}:
+		bv, ok := b.(map[string]interface{})
+		if !ok || len(av) != len(bv) {
+			return false
+		}
+		for key, value := range av {
+			other, ok := bv[key]
+			if !ok {
+				return false
+			}
+			if key == "id" {
+				if s, ok := value.(string); ok {
+					if o, ok := other.(string); ok && strings.EqualFold(s, o) {
+						continue
+					}
+				}
+			}
+			if !equalValues(value, other) {
+				return false
+			}
+		}
+		return true
+	case []interface{}:
+		bv, ok := b.([]interface{})
+		if !ok || len(av) != len(bv) {
+			return false
+		}
+		for i := range av {
+			if !equalValues(av[i], bv[i]) {
+				return false
+			}
+		}
+		return true
+	}
+	return reflect.DeepEqual(a, b)
+}
+
+// normalizeIds returns the command ids lowercased
+func normalizeIds(ids []interface{}) []interface{} {
+	normalized := make([]interface{}, 0, len(ids))
+	for _, id := range ids {
+		if s, ok := id.(string); ok {
+			id = strings.ToLower(s)
+		}
+		normalized = append(normalized, id)
+	}
+	return normalized
+}
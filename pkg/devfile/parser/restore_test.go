@@ -0,0 +1,104 @@
+package parser
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestRestoreRawContent(t *testing.T) {
+
+	tests := []struct {
+		name    string
+		raw     string
+		parsed  string
+		current string
+		want    string
+		wantErr string
+	}{
+		{
+			name:    "Case 1: Unchanged substituted and defaulted values",
+			raw:     `{"components": [{"name": "runtime", "container": {"image": "quay.io/nodejs:{{version}}"}}]}`,
+			parsed:  `{"components": [{"name": "runtime", "container": {"image": "quay.io/nodejs:14", "mountSources": true}}]}`,
+			current: `{"components": [{"name": "runtime", "container": {"image": "quay.io/nodejs:14", "mountSources": true}}]}`,
+			want:    `{"components": [{"name": "runtime", "container": {"image": "quay.io/nodejs:{{version}}"}}]}`,
+		},
+		{
+			name:    "Case 2: Changed field next to a substituted value",
+			raw:     `{"components": [{"name": "runtime", "container": {"image": "quay.io/nodejs:{{version}}"}}]}`,
+			parsed:  `{"components": [{"name": "runtime", "container": {"image": "quay.io/nodejs:14", "mountSources": true}}]}`,
+			current: `{"components": [{"name": "runtime", "container": {"image": "quay.io/nodejs:14", "mountSources": false}}]}`,
+			want:    `{"components": [{"name": "runtime", "container": {"image": "quay.io/nodejs:{{version}}", "mountSources": false}}]}`,
+		},
+		{
+			name:    "Case 3: Added and deleted items",
+			raw:     `{"commands": [{"id": "Install", "exec": {"commandLine": "npm install"}}, {"id": "test", "exec": {"commandLine": "npm test"}}]}`,
+			parsed:  `{"commands": [{"id": "install", "exec": {"commandLine": "npm install"}}, {"id": "test", "exec": {"commandLine": "npm test"}}]}`,
+			current: `{"commands": [{"id": "install", "exec": {"commandLine": "npm install"}}, {"id": "run", "exec": {"commandLine": "npm start"}}]}`,
+			want:    `{"commands": [{"id": "Install", "exec": {"commandLine": "npm install"}}, {"id": "run", "exec": {"commandLine": "npm start"}}]}`,
+		},
+		{
+			name:    "Case 4: Plugin replaced by its components",
+			raw:     `{"components": [{"name": "java", "plugin": {"uri": "java.yaml"}}]}`,
+			parsed:  `{"components": [{"name": "java-runtime", "container": {"image": "quay.io/java"}}]}`,
+			current: `{"components": [{"name": "java-runtime", "container": {"image": "quay.io/java"}}]}`,
+			want:    `{"components": [{"name": "java", "plugin": {"uri": "java.yaml"}}]}`,
+		},
+		{
+			name:    "Case 5: Changed parent component",
+			raw:     `{"parent": {"uri": "parent.yaml"}}`,
+			parsed:  `{"parent": {"uri": "parent.yaml"}, "components": [{"name": "runtime", "container": {"image": "quay.io/nodejs"}}]}`,
+			current: `{"parent": {"uri": "parent.yaml"}, "components": [{"name": "runtime", "container": {"image": "quay.io/java"}}]}`,
+			wantErr: "cannot write the changes of component 'runtime'",
+		},
+		{
+			name:    "Case 6: Deleted parent command",
+			raw:     `{"parent": {"uri": "parent.yaml"}}`,
+			parsed:  `{"parent": {"uri": "parent.yaml"}, "commands": [{"id": "build", "exec": {"commandLine": "npm install"}}]}`,
+			current: `{"parent": {"uri": "parent.yaml"}}`,
+			wantErr: "cannot write the deletion of command 'build'",
+		},
+		{
+			name:    "Case 7: Command bound to an event next to the parent ones",
+			raw:     `{"events": {"postStart": ["run"]}}`,
+			parsed:  `{"events": {"postStart": ["build", "run"]}}`,
+			current: `{"events": {"postStart": ["build", "run", "test"]}}`,
+			want:    `{"events": {"postStart": ["run", "test"]}}`,
+		},
+		{
+			name:    "Case 8: Parent command unbound from an event",
+			raw:     `{"events": {"postStart": ["run"]}}`,
+			parsed:  `{"events": {"postStart": ["build", "run"]}}`,
+			current: `{"events": {"postStart": ["run"]}}`,
+			wantErr: "cannot write the changes of the postStart event",
+		},
+	}
+
+	decode := func(t *testing.T, content string) map[string]interface{} {
+		t.Helper()
+		var m map[string]interface{}
+		if err := json.Unmarshal([]byte(content), &m); err != nil {
+			t.Fatalf("failed to decode '%s': %v", content, err)
+		}
+		return m
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := restoreRawContent(decode(t, tt.raw), decode(t, tt.parsed), decode(t, tt.current))
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Errorf("expected error containing '%s', got '%v'", tt.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if want := decode(t, tt.want); !reflect.DeepEqual(got, want) {
+				t.Errorf("got: '%v', want: '%v'", got, want)
+			}
+		})
+	}
+}
@@ -1,8 +1,12 @@
 package parser
 
 import (
+	"net/http"
+
 	devfileCtx "github.com/devfile/parser/pkg/devfile/parser/context"
 	"github.com/devfile/parser/pkg/devfile/parser/data"
+	"github.com/devfile/parser/pkg/devfile/validate"
+	"github.com/devfile/parser/pkg/testingutil/filesystem"
 )
 
 // Default filenames for create devfile
@@ -19,4 +23,78 @@ type DevfileObj struct {
 
 	// Data has the devfile data
 	Data data.DevfileData
+
+	// RawData is the devfile data as read, before its parent and plugins are merged in, its variables are
+	// substituted and its defaults are set. WriteDevfile restores the values of Data unchanged since parsing
+	// to their raw value.
+	RawData data.DevfileData
+
+	// Warnings are the problems found while parsing the devfile which don't make it invalid,
+	// e.g. its references to undefined variables
+	Warnings *validate.ValidationResult
+
+	// parsedContent is the JSON content of Data once parsed, telling the values changed since parsing
+	parsedContent map[string]interface{}
+}
+
+// ValidationLevel sets how thoroughly ParseDevfile validates a devfile
+type ValidationLevel int
+
+const (
+	// ValidateFull validates the devfile against its JSON schema and validates the devfile data
+	ValidateFull ValidationLevel = iota
+
+	// ValidateSchema only validates the devfile against its JSON schema
+	ValidateSchema
+
+	// ValidateNone doesn't validate the devfile, its referenced parent and plugins are still validated against their schema
+	ValidateNone
+)
+
+// ParserArgs are the arguments of ParseDevfile. The devfile is read from exactly one of Path, URL or Data.
+type ParserArgs struct {
+	// Path is the path of the devfile on the filesystem, or its http(s) URL
+	Path string
+
+	// URL is the http(s) URL of the devfile, its relative references are resolved against it
+	URL string
+
+	// Data is the devfile content
+	Data []byte
+
+	// Fs is the filesystem the devfile and its references are read from, the OS filesystem when nil
+	Fs filesystem.Filesystem
+
+	// HTTPClient downloads the devfile and its references, a client with the default timeout when nil
+	HTTPClient *http.Client
+
+	// MaxDevfileSize is the maximum size in bytes of the downloaded devfiles, devfileCtx.DefaultMaxDevfileSize when not set
+	MaxDevfileSize int64
+
+	// Flatten merges the parent and the plugins into the devfile, true when nil
+	Flatten *bool
+
+	// SetDefaults fills the omitted fields with their default value
+	SetDefaults bool
+
+	// ImplicitVolumes adds a volume component for every volume mounted by the containers without volume component
+	ImplicitVolumes bool
+
+	// VariableOverrides take precedence over the variables defined in the devfile
+	VariableOverrides map[string]string
+
+	// LookupEnv resolves the ${ENV} references of the devfile, e.g. os.LookupEnv. They are kept as is when nil.
+	LookupEnv func(name string) (string, bool)
+
+	// ValidationLevel sets how thoroughly the devfile is validated
+	ValidationLevel ValidationLevel
+
+	// SkipContainerCheck accepts devfiles without container component, which odo requires
+	SkipContainerCheck bool
+
+	// UniqueMountPaths rejects containers mounting different volumes to the same path
+	UniqueMountPaths bool
+
+	// RegistryURLs are the registries looked up in order for the parent and plugins referenced by id without registry url
+	RegistryURLs []string
 }
@@ -0,0 +1,259 @@
+package parser
+
+import (
+	"fmt"
+	"strings"
+
+	"k8s.io/klog"
+
+	"github.com/devfile/parser/pkg/devfile/validate"
+)
+
+// Variable substitution warning codes
+const (
+	// CodeUndefinedVariable is reported for a {{variable}} reference to a variable which is not defined
+	CodeUndefinedVariable = "undefined_variable"
+
+	// CodeUndefinedEnvVar is reported for a ${ENV} reference to an environment variable which is not defined
+	CodeUndefinedEnvVar = "undefined_env_var"
+)
+
+// Warnings
+var (
+	WarningUndefinedVariable = "variable '%s' is not defined"
+	WarningUndefinedEnvVar   = "environment variable '%s' is not defined"
+)
+
+// runtimeEnvVars are the env vars set in the containers at runtime, their references are substituted only
+// when the caller defines them
+var runtimeEnvVars = []string{"PROJECTS_ROOT", "PROJECT_SOURCE", "CHE_PROJECTS_ROOT"}
+
+// VariableOptions configures the substitution of the variable references of a devfile
+type VariableOptions struct {
+	// Overrides are variables taking precedence over the variables defined in the devfile
+	Overrides map[string]string
+
+	// LookupEnv returns the value of an environment variable referenced as ${NAME}, e.g. os.LookupEnv.
+	// The environment variable references are left as is when nil.
+	LookupEnv func(name string) (string, bool)
+}
+
+// devfileVariables is implemented by the devfile data of the versions defining variables
+type devfileVariables interface {
+	GetVariables() map[string]string
+}
+
+// variableSubstituter replaces the variable references of the devfile string fields
+type variableSubstituter struct {
+	variables  map[string]string
+	overrides  map[string]string
+	lookupEnv  func(name string) (string, bool)
+	runtimeEnv map[string]bool
+	warnings   *validate.ValidationResult
+}
+
+// SubstituteVariables replaces the {{variable}} references in the images, command lines, env values, working
+// directories and endpoint paths of a devfile 2.x by the value of the variable, taken from the overrides or
+// from the devfile variables. When an environment lookup is provided, the ${ENV} references are replaced by
+// the value of the environment variable, except for the env vars set by the containers themselves.
+// The references to the env vars set at runtime, e.g. ${PROJECT_SOURCE}, are replaced only when the overrides
+// or the environment lookup define them. A reference preceded by a backslash, e.g. \{{variable}} or \${ENV}, is escaped and kept without the backslash.
+// The undefined references are kept as is and reported as warnings.
+func SubstituteVariables(d DevfileObj, options VariableOptions) *validate.ValidationResult {
+	s := &variableSubstituter{
+		variables:  make(map[string]string),
+		overrides:  options.Overrides,
+		lookupEnv:  options.LookupEnv,
+		runtimeEnv: make(map[string]bool),
+		warnings:   validate.NewValidationResult(),
+	}
+	if d.Data == nil || d.Ctx.GetApiVersion() == "1.0.0" {
+		return s.warnings
+	}
+
+	if v, ok := d.Data.(devfileVariables); ok {
+		for name, value := range v.GetVariables() {
+			s.variables[name] = value
+		}
+	}
+	for name, value := range options.Overrides {
+		s.variables[name] = value
+	}
+
+	components := d.Data.GetComponents()
+	commands := d.Data.GetCommands()
+
+	// The env vars defined by the containers and commands are expanded at runtime
+	for _, component := range components {
+		if component.Container != nil {
+			for _, env := range component.Container.Env {
+				s.runtimeEnv[env.Name] = true
+			}
+		}
+	}
+	for _, command := range commands {
+		if command.Exec != nil {
+			for _, env := range command.Exec.Env {
+				s.runtimeEnv[env.Name] = true
+			}
+		}
+	}
+
+	for i, component := range components {
+		if container := component.Container; container != nil {
+			path := validate.JSONPointer("components", i, "container")
+			s.replace(path+"/image", &container.Image)
+			s.replaceAll(path+"/command", container.Command)
+			s.replaceAll(path+"/args", container.Args)
+			s.replace(path+"/sourceMapping", &container.SourceMapping)
+			for j := range container.Env {
+				s.replace(validate.JSONPointer("components", i, "container", "env", j, "value"), &container.Env[j].Value)
+			}
+			for j := range container.Endpoints {
+				endpoint := &container.Endpoints[j]
+				s.replace(validate.JSONPointer("components", i, "container", "endpoints", j, "path"), &endpoint.Path)
+				if endpoint.Configuration != nil {
+					s.replace(validate.JSONPointer("components", i, "container", "endpoints", j, "configuration", "path"), &endpoint.Configuration.Path)
+				}
+			}
+		}
+		if image := component.Image; image != nil {
+			path := validate.JSONPointer("components", i, "image")
+			s.replace(path+"/imageName", &image.ImageName)
+			if image.Dockerfile != nil {
+				s.replace(path+"/dockerfile/uri", &image.Dockerfile.Uri)
+				s.replace(path+"/dockerfile/buildContext", &image.Dockerfile.BuildContext)
+				s.replaceAll(path+"/dockerfile/args", image.Dockerfile.Args)
+			}
+		}
+	}
+
+	for i, command := range commands {
+		if exec := command.Exec; exec != nil {
+			path := validate.JSONPointer("commands", i, "exec")
+			s.replace(path+"/commandLine", &exec.CommandLine)
+			s.replace(path+"/workingDir", &exec.WorkingDir)
+			for j := range exec.Env {
+				s.replace(validate.JSONPointer("commands", i, "exec", "env", j, "value"), &exec.Env[j].Value)
+			}
+		}
+	}
+
+	if !isFlattened(d.Ctx.GetDevfileContent()) {
+		s.warnings.AttachPositions(d.Ctx.GetPosition)
+	}
+	return s.warnings
+}
+
+// substituteDevfileVariables replaces the variable references while parsing a devfile, and returns the warnings
+// about the undefined references
+func substituteDevfileVariables(d DevfileObj, options VariableOptions) *validate.ValidationResult {
+	warnings := SubstituteVariables(d, options)
+	for _, warning := range warnings.Errors {
+		klog.V(4).Infof("devfile variable substitution: %s", warning)
+	}
+	return warnings
+}
+
+// replaceAll replaces the references in every value of the list
+func (s *variableSubstituter) replaceAll(path string, values []string) {
+	for i := range values {
+		s.replace(fmt.Sprintf("%s/%d", path, i), &values[i])
+	}
+}
+
+// replace replaces the references in the value of the field at the given path
+func (s *variableSubstituter) replace(path string, value *string) {
+	if !strings.Contains(*value, "{{") && !strings.Contains(*value, "${") {
+		return
+	}
+
+	var b strings.Builder
+	reported := make(map[string]bool)
+	v := *value
+	for i := 0; i < len(v); {
+		switch {
+		case strings.HasPrefix(v[i:], `\{{`), strings.HasPrefix(v[i:], `\${`):
+			// escaped reference, kept without its backslash
+			b.WriteString(v[i+1 : i+3])
+			i += 3
+		case strings.HasPrefix(v[i:], "{{"):
+			end := strings.Index(v[i+2:], "}}")
+			if end < 0 {
+				b.WriteString(v[i:])
+				i = len(v)
+				continue
+			}
+			reference := v[i : i+2+end+2]
+			name := strings.TrimSpace(v[i+2 : i+2+end])
+			if resolved, ok := s.variables[name]; ok {
+				b.WriteString(resolved)
+			} else {
+				b.WriteString(reference)
+				s.warn(path, CodeUndefinedVariable, fmt.Sprintf(WarningUndefinedVariable, name), reported)
+			}
+			i += len(reference)
+		case strings.HasPrefix(v[i:], "${"):
+			end := strings.Index(v[i+2:], "}")
+			if end < 0 {
+				b.WriteString(v[i:])
+				i = len(v)
+				continue
+			}
+			reference := v[i : i+2+end+1]
+			b.WriteString(s.resolveEnv(path, v[i+2:i+2+end], reference, reported))
+			i += len(reference)
+		default:
+			b.WriteByte(v[i])
+			i++
+		}
+	}
+	*value = b.String()
+}
+
+// resolveEnv returns the value of the referenced environment variable, or the reference itself
+// if the environment is not looked up, if the variable is set by the containers or if it's undefined.
+// The env vars set at runtime are taken from the overrides or the environment, and kept when undefined.
+func (s *variableSubstituter) resolveEnv(path string, name string, reference string, reported map[string]bool) string {
+	if s.runtimeEnv[name] {
+		return reference
+	}
+	if isRuntimeEnvVar(name) {
+		if resolved, ok := s.overrides[name]; ok {
+			return resolved
+		}
+		if s.lookupEnv != nil {
+			if resolved, ok := s.lookupEnv(name); ok {
+				return resolved
+			}
+		}
+		return reference
+	}
+	if s.lookupEnv == nil {
+		return reference
+	}
+	if resolved, ok := s.lookupEnv(name); ok {
+		return resolved
+	}
+	s.warn(path, CodeUndefinedEnvVar, fmt.Sprintf(WarningUndefinedEnvVar, name), reported)
+	return reference
+}
+
+// warn reports an undefined reference once per field
+func (s *variableSubstituter) warn(path string, code string, message string, reported map[string]bool) {
+	if reported[message] {
+		return
+	}
+	reported[message] = true
+	s.warnings.AddWarning(path, code, message)
+}
+
+// isRuntimeEnvVar returns true if the env var is set in the containers at runtime
+func isRuntimeEnvVar(name string) bool {
+	for _, runtimeEnvVar := range runtimeEnvVars {
+		if name == runtimeEnvVar {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,243 @@
+package parser
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/devfile/parser/pkg/devfile/validate"
+)
+
+func TestVariableSubstituterReplace(t *testing.T) {
+
+	env := map[string]string{"REGISTRY": "quay.io/acme", "PROJECTS_ROOT": "/home/user"}
+	lookupEnv := func(name string) (string, bool) {
+		value, ok := env[name]
+		return value, ok
+	}
+
+	tests := []struct {
+		name      string
+		value     string
+		overrides map[string]string
+		lookupEnv func(name string) (string, bool)
+		want      string
+		wantCodes []string
+	}{
+		{
+			name:  "Case 1: Variable references",
+			value: "{{registry}}/nodejs:{{ version }}",
+			want:  "quay.io/nodejs:14",
+		},
+		{
+			name:      "Case 2: Undefined variable reported once",
+			value:     "{{missing}}-{{missing}}",
+			want:      "{{missing}}-{{missing}}",
+			wantCodes: []string{CodeUndefinedVariable},
+		},
+		{
+			name:  "Case 3: Escaped references",
+			value: `echo \{{version}} \${HOME}`,
+			want:  "echo {{version}} ${HOME}",
+		},
+		{
+			name:  "Case 4: Environment not looked up",
+			value: "${REGISTRY}/nodejs",
+			want:  "${REGISTRY}/nodejs",
+		},
+		{
+			name:      "Case 5: Environment references",
+			value:     "${REGISTRY}/nodejs:{{version}}",
+			lookupEnv: lookupEnv,
+			want:      "quay.io/acme/nodejs:14",
+		},
+		{
+			name:      "Case 6: Container env vars are not substituted",
+			value:     "${NODE_ENV}/app",
+			lookupEnv: lookupEnv,
+			want:      "${NODE_ENV}/app",
+		},
+		{
+			name:      "Case 7: Runtime env vars defined by the environment",
+			value:     "${PROJECTS_ROOT}/app",
+			lookupEnv: lookupEnv,
+			want:      "/home/user/app",
+		},
+		{
+			name:      "Case 8: Runtime env vars defined by the overrides",
+			value:     "${PROJECT_SOURCE}/src ${PROJECTS_ROOT}",
+			overrides: map[string]string{"PROJECT_SOURCE": "/projects/app", "PROJECTS_ROOT": "/projects"},
+			want:      "/projects/app/src /projects",
+		},
+		{
+			name:      "Case 9: Undefined runtime env vars are kept",
+			value:     "${PROJECT_SOURCE}/src",
+			lookupEnv: lookupEnv,
+			want:      "${PROJECT_SOURCE}/src",
+		},
+		{
+			name:      "Case 10: Undefined env var",
+			value:     "${MISSING}",
+			lookupEnv: lookupEnv,
+			want:      "${MISSING}",
+			wantCodes: []string{CodeUndefinedEnvVar},
+		},
+		{
+			name:  "Case 11: Unterminated references",
+			value: "{{version ${HOME",
+			want:  "{{version ${HOME",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &variableSubstituter{
+				variables:  map[string]string{"registry": "quay.io", "version": "14"},
+				overrides:  tt.overrides,
+				lookupEnv:  tt.lookupEnv,
+				runtimeEnv: map[string]bool{"NODE_ENV": true},
+				warnings:   validate.NewValidationResult(),
+			}
+			value := tt.value
+			s.replace("/path", &value)
+			if value != tt.want {
+				t.Errorf("got: '%s', want: '%s'", value, tt.want)
+			}
+
+			var codes []string
+			for _, warning := range s.warnings.Errors {
+				codes = append(codes, warning.Code)
+			}
+			if !reflect.DeepEqual(codes, tt.wantCodes) {
+				t.Errorf("got warnings: '%v', want codes: '%v'", s.warnings.Errors, tt.wantCodes)
+			}
+		})
+	}
+}
+
+func TestSubstituteVariables(t *testing.T) {
+
+	const devfile = `schemaVersion: 2.2.0
+variables:
+  version: "14"
+  port-path: /health
+components:
+  - name: runtime
+    container:
+      image: quay.io/nodejs:{{version}}
+      env:
+        - name: MODE
+          value: "{{mode}}"
+      endpoints:
+        - name: http
+          targetPort: 3000
+          path: "{{port-path}}"
+commands:
+  - id: run
+    exec:
+      component: runtime
+      commandLine: node --version={{version}} ${NODE_OPTIONS}
+      workingDir: ${PROJECTS_ROOT}/{{app}}
+`
+
+	t.Run("devfile variables are substituted while parsing", func(t *testing.T) {
+		d, err := ParseInMemoryAndValidate([]byte(devfile))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		container := d.Data.GetComponents()[0].Container
+		if container.Image != "quay.io/nodejs:14" || container.Endpoints[0].Path != "/health" {
+			t.Errorf("unexpected container '%+v'", container)
+		}
+		if env := container.Env[0].Value; env != "{{mode}}" {
+			t.Errorf("expected undefined variable to be kept, got '%s'", env)
+		}
+
+		var paths []string
+		for _, warning := range d.Warnings.Warnings() {
+			paths = append(paths, warning.Path)
+		}
+		if want := []string{"/components/0/container/env/0/value", "/commands/0/exec/workingDir"}; !reflect.DeepEqual(paths, want) {
+			t.Errorf("got warnings for '%v', want '%v'", paths, want)
+		}
+	})
+
+	t.Run("runtime env vars defined by the caller", func(t *testing.T) {
+		d, err := ParseDevfile(context.Background(), ParserArgs{
+			Data: []byte(`schemaVersion: 2.2.0
+components:
+  - name: runtime
+    container:
+      image: quay.io/nodejs
+commands:
+  - id: install
+    exec:
+      component: runtime
+      commandLine: npm install --prefix ${PROJECTS_ROOT}
+      workingDir: ${PROJECT_SOURCE}
+`),
+			LookupEnv: func(name string) (string, bool) {
+				if name == "PROJECT_SOURCE" {
+					return "/projects/nodejs", true
+				}
+				return "", false
+			},
+		})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		exec := d.Data.GetCommands()[0].Exec
+		if exec.WorkingDir != "/projects/nodejs" || exec.CommandLine != "npm install --prefix ${PROJECTS_ROOT}" {
+			t.Errorf("unexpected command '%+v'", exec)
+		}
+		if len(d.Warnings.Warnings()) != 0 {
+			t.Errorf("unexpected warnings '%v'", d.Warnings.Warnings())
+		}
+	})
+
+	t.Run("overrides and environment", func(t *testing.T) {
+		var d DevfileObj
+		if err := d.Ctx.PopulateFromBytes([]byte(devfile)); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		d, err := parseDevfile(d, newResolverContext(""))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		warnings := SubstituteVariables(d, VariableOptions{
+			Overrides: map[string]string{"version": "16", "mode": "ci"},
+			LookupEnv: func(name string) (string, bool) { return "", false },
+		})
+
+		container := d.Data.GetComponents()[0].Container
+		if container.Image != "quay.io/nodejs:16" || container.Env[0].Value != "ci" {
+			t.Errorf("unexpected container '%+v'", container)
+		}
+		exec := d.Data.GetCommands()[0].Exec
+		if exec.CommandLine != "node --version=16 ${NODE_OPTIONS}" || exec.WorkingDir != "${PROJECTS_ROOT}/{{app}}" {
+			t.Errorf("unexpected command '%+v'", exec)
+		}
+
+		want := []validate.ValidationError{
+			{
+				Path:     "/commands/0/exec/commandLine",
+				Code:     CodeUndefinedEnvVar,
+				Severity: validate.SeverityWarning,
+				Message:  fmt.Sprintf(WarningUndefinedEnvVar, "NODE_OPTIONS"),
+				Position: &validate.Position{Line: 20, Column: 7},
+			},
+			{
+				Path:     "/commands/0/exec/workingDir",
+				Code:     CodeUndefinedVariable,
+				Severity: validate.SeverityWarning,
+				Message:  fmt.Sprintf(WarningUndefinedVariable, "app"),
+				Position: &validate.Position{Line: 21, Column: 7},
+			},
+		}
+		if !reflect.DeepEqual(warnings.Errors, want) {
+			t.Errorf("got: '%v', want: '%v'", warnings.Errors, want)
+		}
+	})
+}
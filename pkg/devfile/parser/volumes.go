@@ -0,0 +1,49 @@
+package parser
+
+import (
+	"k8s.io/klog"
+
+	"github.com/devfile/parser/pkg/devfile/parser/data/common"
+)
+
+// AddImplicitVolumes adds a volume component for every volume mounted by the containers of a devfile 2.x
+// without corresponding volume component, the volumes being implicitly added as documented by the schema.
+// It returns the names of the added volumes.
+func AddImplicitVolumes(d DevfileObj) ([]string, error) {
+	if d.Data == nil || d.Ctx.GetApiVersion() == "1.0.0" {
+		return nil, nil
+	}
+
+	components := d.Data.GetComponents()
+	volumes := make(map[string]bool)
+	for _, component := range components {
+		if component.Volume != nil {
+			volumes[component.Volume.Name] = true
+		}
+	}
+
+	var added []string
+	var implicitVolumes []common.DevfileComponent
+	for _, component := range components {
+		if component.Container == nil {
+			continue
+		}
+		for _, volumeMount := range component.Container.VolumeMounts {
+			if volumes[volumeMount.Name] {
+				continue
+			}
+			volumes[volumeMount.Name] = true
+			added = append(added, volumeMount.Name)
+			implicitVolumes = append(implicitVolumes, common.DevfileComponent{Volume: &common.Volume{Name: volumeMount.Name}})
+		}
+	}
+	if len(implicitVolumes) == 0 {
+		return nil, nil
+	}
+
+	if err := d.Data.AddComponents(implicitVolumes); err != nil {
+		return nil, err
+	}
+	klog.V(4).Infof("added implicit volume components %v", added)
+	return added, nil
+}
@@ -0,0 +1,87 @@
+package parser
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestAddImplicitVolumes(t *testing.T) {
+
+	const devfile = `schemaVersion: 2.2.0
+components:
+  - name: runtime
+    container:
+      image: quay.io/nodejs
+      volumeMounts:
+        - name: cache
+          path: /cache
+        - name: m2
+          path: /home/user/.m2
+  - name: tools
+    container:
+      image: quay.io/tools
+      volumeMounts:
+        - name: m2
+          path: /root/.m2
+        - name: data
+          path: /data
+  - name: data
+    volume:
+      size: 2Gi
+`
+
+	tests := []struct {
+		name        string
+		args        ParserArgs
+		wantVolumes []string
+		wantErr     string
+	}{
+		{
+			name:    "Case 1: Mounted volumes without volume component",
+			args:    ParserArgs{Data: []byte(devfile)},
+			wantErr: "container 'runtime' mounts volume 'cache' which is not a volume component",
+		},
+		{
+			name:        "Case 2: Implicit volumes",
+			args:        ParserArgs{Data: []byte(devfile), ImplicitVolumes: true},
+			wantVolumes: []string{"data", "cache", "m2"},
+		},
+		{
+			name:    "Case 3: Implicit volumes with unique mount paths",
+			args:    ParserArgs{Data: []byte(strings.Replace(devfile, "/root/.m2", "/cache", 1)), ImplicitVolumes: true, UniqueMountPaths: true},
+			wantErr: "containers 'runtime' and 'tools' mount different volumes 'cache' and 'm2' to the same path '/cache'",
+		},
+		{
+			name:    "Case 4: Invalid volume size",
+			args:    ParserArgs{Data: []byte(strings.Replace(devfile, "2Gi", "2 GB", 1)), ImplicitVolumes: true},
+			wantErr: "volume 'data' has an invalid size '2 GB'",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d, err := ParseDevfile(context.Background(), tt.args)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Errorf("expected error containing '%s', got '%v'", tt.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			var volumes []string
+			for _, component := range d.Data.GetComponents() {
+				if component.Volume != nil {
+					volumes = append(volumes, component.Volume.Name)
+				}
+			}
+			if !reflect.DeepEqual(volumes, tt.wantVolumes) {
+				t.Errorf("got volumes: '%v', want: '%v'", volumes, tt.wantVolumes)
+			}
+		})
+	}
+}
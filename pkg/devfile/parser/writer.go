@@ -2,50 +2,246 @@ package parser
 
 import (
 	"encoding/json"
-	"gopkg.in/yaml.v2"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
 
+	"github.com/ghodss/yaml"
 	"github.com/pkg/errors"
 	"k8s.io/klog"
+
+	"github.com/devfile/parser/pkg/devfile/parser/data"
+	"github.com/devfile/parser/pkg/util"
+)
+
+// Devfile output formats
+const (
+	FormatYAML = "yaml"
+	FormatJSON = "json"
 )
 
+// WriteOptions are the options of WriteDevfile
+type WriteOptions struct {
+	// Format is the output format, FormatYAML or FormatJSON. When empty, the format is inferred
+	// from the extension of the destination, or is the format the devfile was provided in.
+	Format string
+
+	// PreserveFormatting applies the changes made to the devfile as minimal edits of the YAML source it was
+	// read from, keeping its comments, key order and formatting. It is ignored when writing JSON.
+	PreserveFormatting bool
+
+	// PruneDefaults omits the fields set to their default value, keeping the written devfile minimal.
+	// The devfile itself is left unchanged.
+	PruneDefaults bool
+
+	// Flattened writes the devfile flattened with its parent and plugins and with its variables substituted,
+	// without its parent reference, instead of the devfile as read
+	Flattened bool
+}
+
 // WriteJsonDevfile creates a devfile.json file
 func (d *DevfileObj) WriteJsonDevfile() error {
+	return d.WriteDevfile(OutputDevfileJsonPath, WriteOptions{Format: FormatJSON})
+}
 
-	// Encode data into JSON format
-	jsonData, err := json.MarshalIndent(d.Data, "", "  ")
+// WriteYamlDevfile creates a devfile.yaml file
+func (d *DevfileObj) WriteYamlDevfile() error {
+	return d.WriteDevfile(OutputDevfileYamlPath, WriteOptions{Format: FormatYAML})
+}
+
+// WriteDevfile writes the devfile to the given path, or over the devfile it was read from if the path is empty.
+// The devfile is written atomically, keeping its schema version and omitting its empty sections. The data of the
+// devfile is written with the values unchanged since parsing restored to their raw value, so that its parent and
+// plugins are not merged in, its variables not substituted and its defaults not set.
+func (d *DevfileObj) WriteDevfile(path string, options WriteOptions) error {
+	if path == "" {
+		path = d.Ctx.GetAbsPath()
+	}
+	if path == "" {
+		return fmt.Errorf("no destination to write the devfile to, the devfile was not read from a file")
+	}
+
+	format, err := d.getOutputFormat(path, options)
 	if err != nil {
-		return errors.Wrapf(err, "failed to marshal devfile object into json")
+		return err
 	}
 
-	// Write to devfile.json
-	fs := d.Ctx.GetFs()
-	err = fs.WriteFile(OutputDevfileJsonPath, jsonData, 0644)
+	content, err := d.encode(format, options)
 	if err != nil {
-		return errors.Wrapf(err, "failed to create devfile json file")
+		return err
+	}
+
+	if err = d.writeFileAtomically(path, content); err != nil {
+		return errors.Wrapf(err, "failed to write devfile to '%s'", path)
 	}
 
 	// Successful
-	klog.V(4).Infof("devfile json created at: '%s'", OutputDevfileJsonPath)
+	klog.V(4).Infof("devfile %s written at: '%s'", format, path)
 	return nil
 }
 
-// WriteYamlDevfile creates a devfile.yaml file
-func (d *DevfileObj) WriteYamlDevfile() error {
+// Encode returns the devfile content in the format of the options, or in the format the devfile was provided in
+func (d *DevfileObj) Encode(options WriteOptions) ([]byte, error) {
+	format, err := d.getOutputFormat("", options)
+	if err != nil {
+		return nil, err
+	}
+	return d.encode(format, options)
+}
+
+// getOutputFormat returns the format to write the devfile in
+func (d *DevfileObj) getOutputFormat(path string, options WriteOptions) (string, error) {
+	switch options.Format {
+	case FormatYAML, FormatJSON:
+		return options.Format, nil
+	case "":
+	default:
+		return "", fmt.Errorf("unsupported devfile format '%s', should be '%s' or '%s'", options.Format, FormatYAML, FormatJSON)
+	}
+
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".json":
+		return FormatJSON, nil
+	case ".yaml", ".yml":
+		return FormatYAML, nil
+	}
+	if d.Ctx.IsJSON() {
+		return FormatJSON, nil
+	}
+	return FormatYAML, nil
+}
+
+// encode returns the devfile content in the given format
+func (d *DevfileObj) encode(format string, options WriteOptions) ([]byte, error) {
+	devfileData := d.Data
+	if options.PruneDefaults {
+		pruned, err := copyDevfileData(d.Ctx.GetApiVersion(), devfileData)
+		if err != nil {
+			return nil, err
+		}
+		PruneDefaults(DevfileObj{Ctx: d.Ctx, Data: pruned})
+		devfileData = pruned
+	}
+
+	content, err := toJSONMap(devfileData)
+	if err != nil {
+		return nil, err
+	}
+	if options.Flattened {
+		// The parent is merged in the flattened devfile
+		delete(content, "parent")
+	} else if d.RawData != nil && d.parsedContent != nil {
+		rawContent, err := toJSONMap(d.RawData)
+		if err != nil {
+			return nil, err
+		}
+		if content, err = restoreRawContent(rawContent, d.parsedContent, content); err != nil {
+			return nil, err
+		}
+	}
 
-	// Encode data into YAML format
-	yamlData, err := yaml.Marshal(d.Data)
+	// Keep the version the devfile was read with
+	versionKey := "schemaVersion"
+	if _, ok := content["apiVersion"]; ok {
+		versionKey = "apiVersion"
+	}
+	if version, _ := content[versionKey].(string); version == "" && d.Ctx.GetApiVersion() != "" {
+		content[versionKey] = d.Ctx.GetApiVersion()
+	}
+
+	// Omit the empty sections, e.g. the events or the parent of a 2.x devfile
+	for key, value := range content {
+		if isEmptyValue(value) {
+			delete(content, key)
+		}
+	}
+
+	if format == FormatYAML && options.PreserveFormatting && len(d.Ctx.GetSource()) > 0 && !d.Ctx.IsJSON() {
+		patched, err := patchYAML(d.Ctx.GetSource(), content)
+		if err == nil {
+			return patched, nil
+		}
+		klog.V(4).Infof("failed to preserve the devfile formatting, writing it from scratch: %v", err)
+	}
+
+	jsonData, err := json.MarshalIndent(content, "", "  ")
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to marshal devfile object into json")
+	}
+	if format == FormatJSON {
+		return append(jsonData, '\n'), nil
+	}
+
+	yamlData, err := yaml.JSONToYAML(jsonData)
 	if err != nil {
-		return errors.Wrapf(err, "failed to marshal devfile object into yaml")
+		return nil, errors.Wrapf(err, "failed to marshal devfile object into yaml")
 	}
+	return yamlData, nil
+}
 
-	// Write to devfile.yaml
+// copyDevfileData returns a deep copy of the devfile data of the given version
+func copyDevfileData(version string, devfileData data.DevfileData) (data.DevfileData, error) {
+	copied, err := data.NewDevfileData(version)
+	if err != nil {
+		return nil, err
+	}
+	content, err := json.Marshal(devfileData)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to encode devfile")
+	}
+	if err := json.Unmarshal(content, copied); err != nil {
+		return nil, errors.Wrapf(err, "failed to decode devfile")
+	}
+	return copied, nil
+}
+
+// isEmptyValue returns true if the decoded JSON value is null, an empty object or an empty list
+func isEmptyValue(value interface{}) bool {
+	switch v := value.(type) {
+	case nil:
+		return true
+	case map[string]interface{}:
+		return len(v) == 0
+	case []interface{}:
+		return len(v) == 0
+	}
+	return false
+}
+
+// writeFileAtomically writes the content to a temporary file next to the destination, then renames it
+// to the destination so that the destination is never left partially written
+func (d *DevfileObj) writeFileAtomically(path string, content []byte) (err error) {
 	fs := d.Ctx.GetFs()
-	err = fs.WriteFile(OutputDevfileYamlPath, yamlData, 0644)
+
+	// Keep the permissions of the file being replaced
+	perm := os.FileMode(0644)
+	if info, statErr := fs.Stat(path); statErr == nil {
+		perm = info.Mode().Perm()
+	}
+
+	tempPath := filepath.Join(filepath.Dir(path), fmt.Sprintf(".%s.%s.tmp", filepath.Base(path), util.GenerateRandomString(8)))
+	tempFile, err := fs.OpenFile(tempPath, os.O_WRONLY|os.O_CREATE|os.O_EXCL, perm)
 	if err != nil {
-		return errors.Wrapf(err, "failed to create devfile yaml file")
+		return err
 	}
+	defer func() {
+		if err != nil {
+			_ = fs.Remove(tempPath)
+		}
+	}()
 
-	// Successful
-	klog.V(4).Infof("devfile yaml created at: '%s'", OutputDevfileYamlPath)
-	return nil
+	if _, err = tempFile.Write(content); err != nil {
+		_ = tempFile.Close()
+		return err
+	}
+	// Flush the content to disk before renaming, a crash must not leave a truncated destination
+	if err = tempFile.Sync(); err != nil {
+		_ = tempFile.Close()
+		return err
+	}
+	if err = tempFile.Close(); err != nil {
+		return err
+	}
+	return fs.Rename(tempPath, path)
 }
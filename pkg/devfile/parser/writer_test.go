@@ -1,10 +1,17 @@
 package parser
 
 import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 
 	devfileCtx "github.com/devfile/parser/pkg/devfile/parser/context"
 	v100 "github.com/devfile/parser/pkg/devfile/parser/data/1.0.0"
+	"github.com/devfile/parser/pkg/devfile/parser/data/common"
 	"github.com/devfile/parser/pkg/testingutil/filesystem"
 )
 
@@ -72,3 +79,240 @@ func TestWriteJsonDevfile(t *testing.T) {
 		}
 	})
 }
+
+func TestWriteDevfile(t *testing.T) {
+
+	const jsonDevfile = `{"schemaVersion": "2.0.0", "metadata": {"name": "nodejs"}, "components": [{"container": {"name": "runtime", "image": "quay.io/nodejs"}}]}`
+
+	t.Run("write over the source devfile in its original format", func(t *testing.T) {
+		dir, err := ioutil.TempDir("", "devfile-writer")
+		if err != nil {
+			t.Fatalf("failed to create temp dir: %v", err)
+		}
+		defer os.RemoveAll(dir)
+
+		path := filepath.Join(dir, "devfile")
+		if err = ioutil.WriteFile(path, []byte(jsonDevfile), 0640); err != nil {
+			t.Fatalf("failed to write devfile: %v", err)
+		}
+
+		devfileObj, err := ParseAndValidate(path)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if err = devfileObj.Data.SetMetadata(common.DevfileMetadata{Name: "nodejs-renamed"}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if err = devfileObj.WriteDevfile("", WriteOptions{}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		content, err := ioutil.ReadFile(path)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		var written map[string]interface{}
+		if err = json.Unmarshal(content, &written); err != nil {
+			t.Fatalf("expected a json devfile, got: %s", content)
+		}
+		if written["schemaVersion"] != "2.0.0" {
+			t.Errorf("expected schemaVersion to be preserved, got: %v", written["schemaVersion"])
+		}
+		if metadata := written["metadata"].(map[string]interface{}); metadata["name"] != "nodejs-renamed" {
+			t.Errorf("expected the updated metadata, got: %v", metadata)
+		}
+		for _, section := range []string{"events", "parent", "projects", "commands"} {
+			if _, ok := written[section]; ok {
+				t.Errorf("expected empty section '%s' to be omitted", section)
+			}
+		}
+
+		info, err := os.Stat(path)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if info.Mode().Perm() != 0640 {
+			t.Errorf("expected file permissions to be preserved, got: %v", info.Mode().Perm())
+		}
+		files, _ := ioutil.ReadDir(dir)
+		if len(files) != 1 {
+			t.Errorf("expected no temporary file to be left, got %d files", len(files))
+		}
+	})
+
+	t.Run("write to a destination in the format of its extension", func(t *testing.T) {
+		devfileObj, err := ParseInMemoryAndValidate([]byte(jsonDevfile))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		fs := filesystem.NewFakeFs()
+		devfileObj.Ctx.Fs = fs
+
+		if err = devfileObj.WriteDevfile("/out/devfile.yaml", WriteOptions{}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		content, err := fs.ReadFile("/out/devfile.yaml")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if !strings.Contains(string(content), "schemaVersion: 2.0.0") || strings.HasPrefix(string(content), "{") {
+			t.Errorf("expected a yaml devfile, got: %s", content)
+		}
+	})
+
+	t.Run("write a devfile with a parent and variables as read", func(t *testing.T) {
+		fs := filesystem.NewFakeFs()
+		for name, content := range map[string]string{
+			"/devfiles/parent.yaml":  "schemaVersion: 2.2.0\ncomponents:\n  - name: runtime\n    container:\n      image: quay.io/nodejs\n",
+			"/devfiles/devfile.yaml": "schemaVersion: 2.2.0\nparent:\n  uri: parent.yaml\nvariables:\n  version: \"14\"\ncomponents:\n  - name: tools\n    container:\n      image: quay.io/tools:{{version}}\n",
+		} {
+			if err := fs.WriteFile(name, []byte(content), 0644); err != nil {
+				t.Fatalf("failed to write devfile: %v", err)
+			}
+		}
+
+		devfileObj, err := ParseDevfile(context.Background(), ParserArgs{Path: "/devfiles/devfile.yaml", Fs: fs})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(devfileObj.Data.GetComponents()) != 2 || len(devfileObj.RawData.GetComponents()) != 1 {
+			t.Fatalf("expected the raw data to be kept unflattened")
+		}
+		if err = devfileObj.WriteDevfile("", WriteOptions{}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		content, err := fs.ReadFile("/devfiles/devfile.yaml")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if !strings.Contains(string(content), "image: quay.io/tools:{{version}}") || strings.Contains(string(content), "name: runtime") {
+			t.Errorf("expected the devfile to be written as read, got: %s", content)
+		}
+		if _, err = ParseDevfile(context.Background(), ParserArgs{Path: "/devfiles/devfile.yaml", Fs: fs}); err != nil {
+			t.Errorf("expected the written devfile to parse, got: %v", err)
+		}
+
+		flattened, err := devfileObj.Encode(WriteOptions{Flattened: true})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if !strings.Contains(string(flattened), "name: runtime") || strings.Contains(string(flattened), "parent:") {
+			t.Errorf("expected the flattened devfile without parent, got: %s", flattened)
+		}
+	})
+
+	t.Run("write the changes of a devfile with a parent and variables", func(t *testing.T) {
+		fs := filesystem.NewFakeFs()
+		for name, content := range map[string]string{
+			"/devfiles/parent.yaml": "schemaVersion: 2.2.0\ncomponents:\n  - name: runtime\n    container:\n      image: quay.io/nodejs\n",
+			"/devfiles/devfile.yaml": `schemaVersion: 2.2.0
+parent:
+  uri: parent.yaml
+variables:
+  version: "14"
+components:
+  - name: tools
+    container:
+      image: quay.io/tools:{{version}}
+      memoryLimit: 512Mi
+commands:
+  - id: Install
+    exec:
+      component: tools
+      commandLine: npm install
+  - id: test
+    exec:
+      component: tools
+      commandLine: npm test
+`,
+		} {
+			if err := fs.WriteFile(name, []byte(content), 0644); err != nil {
+				t.Fatalf("failed to write devfile: %v", err)
+			}
+		}
+
+		devfileObj, err := ParseDevfile(context.Background(), ParserArgs{Path: "/devfiles/devfile.yaml", Fs: fs, SetDefaults: true})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		tools := devfileObj.Data.GetComponents()[1]
+		tools.Container.MemoryLimit = "1Gi"
+		if err = devfileObj.Data.UpdateComponent(tools); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		cache := common.DevfileComponent{Volume: &common.Volume{Name: "cache", Size: "1Gi"}}
+		if err = devfileObj.Data.AddComponents([]common.DevfileComponent{cache}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if err = devfileObj.Data.DeleteCommand("test"); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if err = devfileObj.WriteDevfile("", WriteOptions{}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		content, err := fs.ReadFile("/devfiles/devfile.yaml")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		for _, want := range []string{"image: quay.io/tools:{{version}}", "memoryLimit: 1Gi", "name: cache", "id: Install"} {
+			if !strings.Contains(string(content), want) {
+				t.Errorf("expected the written devfile to contain '%s', got: %s", want, content)
+			}
+		}
+		for _, unwanted := range []string{"name: runtime", "id: test", "mountSources"} {
+			if strings.Contains(string(content), unwanted) {
+				t.Errorf("expected the written devfile not to contain '%s', got: %s", unwanted, content)
+			}
+		}
+
+		reparsed, err := ParseDevfile(context.Background(), ParserArgs{Path: "/devfiles/devfile.yaml", Fs: fs})
+		if err != nil {
+			t.Fatalf("expected the written devfile to parse, got: %v", err)
+		}
+		components := reparsed.Data.GetComponents()
+		if len(components) != 3 || components[1].Container.MemoryLimit != "1Gi" || components[1].Container.Image != "quay.io/tools:14" || components[2].GetName() != "cache" {
+			t.Errorf("unexpected components of the written devfile: %+v", components)
+		}
+		if commands := reparsed.Data.GetCommands(); len(commands) != 1 || commands[0].GetId() != "install" {
+			t.Errorf("unexpected commands of the written devfile: %+v", commands)
+		}
+
+		// The components of the parent can't be changed in the devfile
+		runtime := reparsed.Data.GetComponents()[0]
+		runtime.Container.MemoryLimit = "2Gi"
+		if err = reparsed.Data.UpdateComponent(runtime); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if _, err = reparsed.Encode(WriteOptions{}); err == nil || !strings.Contains(err.Error(), "component 'runtime'") {
+			t.Errorf("expected an error writing the change of the parent component, got: %v", err)
+		}
+		if _, err = reparsed.Encode(WriteOptions{Flattened: true}); err != nil {
+			t.Errorf("unexpected error writing the flattened devfile: %v", err)
+		}
+	})
+
+	t.Run("no destination for an in-memory devfile", func(t *testing.T) {
+		devfileObj, err := ParseInMemoryAndValidate([]byte(jsonDevfile))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if err = devfileObj.WriteDevfile("", WriteOptions{}); err == nil {
+			t.Errorf("expected an error, didn't get one")
+		}
+	})
+
+	t.Run("unsupported format", func(t *testing.T) {
+		devfileObj, err := ParseInMemoryAndValidate([]byte(jsonDevfile))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		devfileObj.Ctx.Fs = filesystem.NewFakeFs()
+		if err = devfileObj.WriteDevfile("devfile.toml", WriteOptions{Format: "toml"}); err == nil {
+			t.Errorf("expected an error, didn't get one")
+		}
+	})
+}
@@ -0,0 +1,517 @@
+package parser
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v3"
+)
+
+// yamlEdit replaces the lines [startLine, endLine) of the source by the given lines,
+// or, for an inline edit, the columns [startCol, endCol) of the start line by the given text
+type yamlEdit struct {
+	startLine int
+	endLine   int
+	lines     []string
+
+	inline   bool
+	startCol int
+	endCol   int
+	text     string
+
+	// order in which the edit was made, for edits inserting lines at the same position
+	order int
+}
+
+// yamlPatcher computes the minimal edits turning a YAML source into a new content.
+// Unchanged elements keep their formatting and comments, changed elements are rewritten in place,
+// and only the elements that can't be patched in place are re-rendered.
+type yamlPatcher struct {
+	lines []string
+	edits []yamlEdit
+}
+
+// patchYAML applies the content on the YAML source with minimal edits, so that the lines of
+// the unchanged elements of the source stay byte-identical
+func patchYAML(source []byte, content map[string]interface{}) ([]byte, error) {
+	var doc yaml.Node
+	if err := yaml.Unmarshal(source, &doc); err != nil {
+		return nil, errors.Wrapf(err, "failed to decode devfile source")
+	}
+	if doc.Kind != yaml.DocumentNode || len(doc.Content) == 0 {
+		return nil, fmt.Errorf("devfile source is not a YAML document")
+	}
+
+	p := &yamlPatcher{lines: splitLines(source)}
+	if !p.patchNode(doc.Content[0], content, len(p.lines)) {
+		return nil, fmt.Errorf("devfile source can't be patched in place")
+	}
+	return p.apply(), nil
+}
+
+// splitLines splits the source into lines, keeping their line endings
+func splitLines(source []byte) []string {
+	var lines []string
+	for len(source) > 0 {
+		i := bytes.IndexByte(source, '\n')
+		if i < 0 {
+			lines = append(lines, string(source))
+			break
+		}
+		lines = append(lines, string(source[:i+1]))
+		source = source[i+1:]
+	}
+	return lines
+}
+
+// apply applies the edits on the source lines, bottom-up so that line numbers stay valid
+func (p *yamlPatcher) apply() []byte {
+	edits := append([]yamlEdit{}, p.edits...)
+	sort.SliceStable(edits, func(i, j int) bool {
+		a, b := edits[i], edits[j]
+		if a.startLine != b.startLine {
+			return a.startLine > b.startLine
+		}
+		// On the same line, inline edits go right to left, replacements go before insertions,
+		// and insertions go in reverse order so that they end up in the order they were made
+		if a.inline && b.inline {
+			return a.startCol > b.startCol
+		}
+		aInsert, bInsert := a.endLine == a.startLine, b.endLine == b.startLine
+		if aInsert != bInsert {
+			return !aInsert
+		}
+		return a.order > b.order
+	})
+
+	lines := append([]string{}, p.lines...)
+	for _, edit := range edits {
+		if edit.inline {
+			line := []rune(lines[edit.startLine])
+			lines[edit.startLine] = string(line[:edit.startCol]) + edit.text + string(line[edit.endCol:])
+			continue
+		}
+		updated := append([]string{}, lines[:edit.startLine]...)
+		updated = append(updated, edit.lines...)
+		lines = append(updated, lines[edit.endLine:]...)
+	}
+	return []byte(strings.Join(lines, ""))
+}
+
+// replaceLines replaces the lines [start, end) by the given lines
+func (p *yamlPatcher) replaceLines(start int, end int, lines []string) {
+	p.edits = append(p.edits, yamlEdit{startLine: start, endLine: end, lines: lines, order: len(p.edits)})
+}
+
+// patchNode patches the node with the value. The node is part of the source lines [.., end).
+// It returns false, without any edit, if the node can't be patched in place.
+func (p *yamlPatcher) patchNode(node *yaml.Node, value interface{}, end int) bool {
+	if nodeEquals(node, value) {
+		return true
+	}
+	if node.Anchor != "" || node.Kind == yaml.AliasNode || node.Style&yaml.FlowStyle != 0 {
+		return false
+	}
+
+	edits := len(p.edits)
+	patched := false
+	switch node.Kind {
+	case yaml.MappingNode:
+		if m, ok := value.(map[string]interface{}); ok {
+			patched = p.patchMapping(node, m, end)
+		}
+	case yaml.SequenceNode:
+		if l, ok := value.([]interface{}); ok {
+			patched = p.patchSequence(node, l, end)
+		}
+	case yaml.ScalarNode:
+		patched = p.patchScalar(node, value)
+	}
+	if !patched {
+		p.edits = p.edits[:edits]
+	}
+	return patched
+}
+
+// patchMapping patches the members of the mapping node, removing the members missing from the value
+// and appending the members missing from the node
+func (p *yamlPatcher) patchMapping(node *yaml.Node, value map[string]interface{}, end int) bool {
+	if len(node.Content) == 0 {
+		return false
+	}
+
+	var starts []int
+	for i := 0; i < len(node.Content); i += 2 {
+		starts = append(starts, node.Content[i].Line-1)
+	}
+	ranges := p.getRanges(starts, end)
+
+	indent := node.Content[0].Column - 1
+	seen := make(map[string]bool)
+	for i := 0; i < len(node.Content); i += 2 {
+		key, member := node.Content[i], node.Content[i+1]
+		r := ranges[i/2]
+		prefix, shared := p.getPrefix(key.Line-1, key.Column-1)
+		seen[key.Value] = true
+
+		newValue, ok := value[key.Value]
+		if !ok {
+			// Empty members are omitted from the value
+			if isEmptyNode(member) {
+				continue
+			}
+			if shared {
+				return false
+			}
+			p.replaceLines(r[0], r[1], nil)
+			continue
+		}
+
+		if !p.patchNode(member, newValue, r[1]) {
+			p.replaceLines(r[0], r[1], renderYAML(map[string]interface{}{key.Value: newValue}, prefix, indent))
+		}
+	}
+
+	var added []string
+	for key := range value {
+		if !seen[key] && !isEmptyValue(value[key]) {
+			added = append(added, key)
+		}
+	}
+	sort.Strings(added)
+	insertAt := ranges[len(ranges)-1][1]
+	for _, key := range added {
+		p.replaceLines(insertAt, insertAt, renderYAML(map[string]interface{}{key: value[key]}, strings.Repeat(" ", indent), indent))
+	}
+	return true
+}
+
+// patchSequence patches the items of the sequence node. Items identified by a name or an id
+// are matched by key, other items by index.
+func (p *yamlPatcher) patchSequence(node *yaml.Node, value []interface{}, end int) bool {
+	if len(node.Content) == 0 {
+		return false
+	}
+
+	var starts, dashes []int
+	for _, item := range node.Content {
+		dash := p.getDashColumn(item)
+		if dash < 0 {
+			return false
+		}
+		starts = append(starts, item.Line-1)
+		dashes = append(dashes, dash)
+	}
+	ranges := p.getRanges(starts, end)
+	indent := dashes[0]
+
+	matches, ok := matchItems(node.Content, value)
+	if !ok {
+		return false
+	}
+
+	matched := make(map[int]bool)
+	for _, i := range matches {
+		if i >= 0 {
+			matched[i] = true
+		}
+	}
+	for i := range node.Content {
+		if !matched[i] {
+			p.replaceLines(ranges[i][0], ranges[i][1], nil)
+		}
+	}
+
+	for j, newItem := range value {
+		i := matches[j]
+		if i >= 0 {
+			r := ranges[i]
+			if !p.patchNode(node.Content[i], newItem, r[1]) {
+				prefix := string([]rune(p.lines[r[0]])[:dashes[i]])
+				p.replaceLines(r[0], r[1], renderYAML([]interface{}{newItem}, prefix, dashes[i]))
+			}
+			continue
+		}
+
+		// Insert the new item before the next matched item, or after the last item
+		insertAt := ranges[len(ranges)-1][1]
+		for _, next := range matches[j+1:] {
+			if next >= 0 {
+				insertAt = ranges[next][0]
+				break
+			}
+		}
+		p.replaceLines(insertAt, insertAt, renderYAML([]interface{}{newItem}, strings.Repeat(" ", indent), indent))
+	}
+	return true
+}
+
+// matchItems returns, for every item of the value, the index of the matching node item or -1.
+// It returns false if the matching items are not in the same order.
+func matchItems(items []*yaml.Node, value []interface{}) ([]int, bool) {
+	matches := make([]int, len(value))
+
+	keys := make(map[string]int)
+	keyed := true
+	for i, item := range items {
+		var m map[string]interface{}
+		if item.Kind != yaml.MappingNode || item.Decode(&m) != nil || getItemKey(m) == "" {
+			keyed = false
+			break
+		}
+		keys[strings.ToLower(getItemKey(m))] = i
+	}
+	for _, newItem := range value {
+		if m, ok := newItem.(map[string]interface{}); !ok || getItemKey(m) == "" {
+			keyed = false
+		}
+	}
+
+	last := -1
+	for j, newItem := range value {
+		matches[j] = -1
+		if keyed {
+			if i, ok := keys[strings.ToLower(getItemKey(newItem.(map[string]interface{})))]; ok {
+				matches[j] = i
+			}
+		} else if j < len(items) {
+			matches[j] = j
+		}
+		if matches[j] >= 0 {
+			if matches[j] < last {
+				return nil, false
+			}
+			last = matches[j]
+		}
+	}
+	return matches, true
+}
+
+// patchScalar rewrites a single-line scalar node in place, keeping its quoting style
+func (p *yamlPatcher) patchScalar(node *yaml.Node, value interface{}) bool {
+	if node.Style&(yaml.LiteralStyle|yaml.FoldedStyle) != 0 {
+		return false
+	}
+	switch value.(type) {
+	case map[string]interface{}, []interface{}:
+		return false
+	}
+
+	lineIndex := node.Line - 1
+	line := []rune(strings.TrimRight(p.lines[lineIndex], "\r\n"))
+	start := node.Column - 1
+	end := getScalarEnd(line, start, node)
+	if end < 0 {
+		return false
+	}
+
+	text, ok := renderScalar(value, node.Style)
+	if !ok {
+		return false
+	}
+	p.edits = append(p.edits, yamlEdit{startLine: lineIndex, endLine: lineIndex, inline: true, startCol: start, endCol: end, text: text, order: len(p.edits)})
+	return true
+}
+
+// getScalarEnd returns the column right after the single-line scalar starting at the given column,
+// or -1 if the scalar spans several lines
+func getScalarEnd(line []rune, start int, node *yaml.Node) int {
+	if start >= len(line) {
+		return -1
+	}
+	switch {
+	case node.Style&yaml.DoubleQuotedStyle != 0:
+		for i := start + 1; i < len(line); i++ {
+			if line[i] == '\\' {
+				i++
+				continue
+			}
+			if line[i] == '"' {
+				return i + 1
+			}
+		}
+		return -1
+	case node.Style&yaml.SingleQuotedStyle != 0:
+		for i := start + 1; i < len(line); i++ {
+			if line[i] == '\'' {
+				if i+1 < len(line) && line[i+1] == '\'' {
+					i++
+					continue
+				}
+				return i + 1
+			}
+		}
+		return -1
+	}
+
+	// Plain scalars end before a comment or at the end of the line
+	end := len(line)
+	for i := start; i < len(line); i++ {
+		if line[i] == '#' && i > start && (line[i-1] == ' ' || line[i-1] == '\t') {
+			end = i
+			break
+		}
+	}
+	for end > start && (line[end-1] == ' ' || line[end-1] == '\t') {
+		end--
+	}
+	if string(line[start:end]) != node.Value {
+		return -1
+	}
+	return end
+}
+
+// getRanges returns the line ranges of siblings starting at the given lines, the last one ending
+// at the given end. Trailing blank and comment lines are left out of the ranges.
+func (p *yamlPatcher) getRanges(starts []int, end int) [][2]int {
+	ranges := make([][2]int, len(starts))
+	for i, start := range starts {
+		rangeEnd := end
+		if i+1 < len(starts) {
+			rangeEnd = starts[i+1]
+		}
+		for rangeEnd > start+1 {
+			trimmed := strings.TrimSpace(p.lines[rangeEnd-1])
+			if trimmed != "" && !strings.HasPrefix(trimmed, "#") {
+				break
+			}
+			rangeEnd--
+		}
+		ranges[i] = [2]int{start, rangeEnd}
+	}
+	return ranges
+}
+
+// getPrefix returns the text of the line before the given column, and whether it contains
+// anything else than spaces, e.g. the dash of a sequence item
+func (p *yamlPatcher) getPrefix(line int, column int) (string, bool) {
+	runes := []rune(p.lines[line])
+	if column > len(runes) {
+		column = len(runes)
+	}
+	prefix := string(runes[:column])
+	return prefix, strings.TrimSpace(prefix) != ""
+}
+
+// getDashColumn returns the column of the dash introducing the sequence item, or -1 if the
+// item doesn't start on the same line as a dash preceded only by spaces
+func (p *yamlPatcher) getDashColumn(item *yaml.Node) int {
+	runes := []rune(p.lines[item.Line-1])
+	i := item.Column - 2
+	for i >= 0 && i < len(runes) && runes[i] == ' ' {
+		i--
+	}
+	if i < 0 || i >= len(runes) || runes[i] != '-' {
+		return -1
+	}
+	if strings.TrimSpace(string(runes[:i])) != "" {
+		return -1
+	}
+	return i
+}
+
+// renderYAML renders the value as YAML lines, the first line starting with the given prefix
+// and the following ones indented with the given number of spaces
+func renderYAML(value interface{}, prefix string, indent int) []string {
+	var buf bytes.Buffer
+	encoder := yaml.NewEncoder(&buf)
+	encoder.SetIndent(2)
+	_ = encoder.Encode(toYAMLValue(value))
+	_ = encoder.Close()
+
+	lines := splitLines(buf.Bytes())
+	for i := range lines {
+		if i == 0 {
+			lines[i] = prefix + lines[i]
+		} else {
+			lines[i] = strings.Repeat(" ", indent) + lines[i]
+		}
+	}
+	return lines
+}
+
+// renderScalar renders the value as a single-line scalar, quoted with the given style if it is a string
+func renderScalar(value interface{}, style yaml.Style) (string, bool) {
+	node := &yaml.Node{}
+	if err := node.Encode(toYAMLValue(value)); err != nil {
+		return "", false
+	}
+	if _, ok := value.(string); ok && style&(yaml.DoubleQuotedStyle|yaml.SingleQuotedStyle) != 0 {
+		node.Style = style & (yaml.DoubleQuotedStyle | yaml.SingleQuotedStyle)
+	}
+	out, err := yaml.Marshal(node)
+	if err != nil {
+		return "", false
+	}
+	text := strings.TrimSuffix(string(out), "\n")
+	if strings.Contains(text, "\n") {
+		return "", false
+	}
+	return text, true
+}
+
+// toYAMLValue converts a decoded JSON value for YAML encoding, turning integral numbers into integers
+func toYAMLValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case float64:
+		if v == math.Trunc(v) && math.Abs(v) < 1<<53 {
+			return int64(v)
+		}
+	case map[string]interface{}:
+		m := make(map[string]interface{}, len(v))
+		for key, item := range v {
+			m[key] = toYAMLValue(item)
+		}
+		return m
+	case []interface{}:
+		l := make([]interface{}, len(v))
+		for i, item := range v {
+			l[i] = toYAMLValue(item)
+		}
+		return l
+	}
+	return value
+}
+
+// nodeEquals returns true if the node decodes to the same JSON value as the given one
+func nodeEquals(node *yaml.Node, value interface{}) bool {
+	decoded, ok := decodeNode(node)
+	if !ok {
+		return false
+	}
+	return reflect.DeepEqual(decoded, normalizeJSON(value))
+}
+
+// isEmptyNode returns true if the node decodes to null, an empty object or an empty list
+func isEmptyNode(node *yaml.Node) bool {
+	decoded, ok := decodeNode(node)
+	return ok && isEmptyValue(decoded)
+}
+
+// decodeNode decodes the node into a JSON value
+func decodeNode(node *yaml.Node) (interface{}, bool) {
+	var decoded interface{}
+	if err := node.Decode(&decoded); err != nil {
+		return nil, false
+	}
+	return normalizeJSON(decoded), true
+}
+
+// normalizeJSON returns the value as decoded from its JSON encoding
+func normalizeJSON(value interface{}) interface{} {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return value
+	}
+	var normalized interface{}
+	if err := json.Unmarshal(data, &normalized); err != nil {
+		return value
+	}
+	return normalized
+}
@@ -0,0 +1,172 @@
+package parser
+
+import (
+	"io/ioutil"
+	"strings"
+	"testing"
+
+	"github.com/devfile/parser/pkg/devfile/parser/data/common"
+)
+
+const repoDevfilePath = "../../../devfile.yaml"
+
+func TestPatchYAMLRoundTrip(t *testing.T) {
+
+	source, err := ioutil.ReadFile(repoDevfilePath)
+	if err != nil {
+		t.Fatalf("failed to read devfile: %v", err)
+	}
+
+	t.Run("unchanged devfile is byte-identical", func(t *testing.T) {
+		d, err := ParseAndValidate(repoDevfilePath)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		got, err := d.encode(FormatYAML, WriteOptions{PreserveFormatting: true})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if string(got) != string(source) {
+			t.Errorf("got:\n%s\nwant:\n%s", got, source)
+		}
+	})
+
+	t.Run("only the changed lines are rewritten", func(t *testing.T) {
+		d, err := ParseAndValidate(repoDevfilePath)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		components := d.Data.GetComponents()
+		components[0].Container.Image = "quay.io/nodejs:14"
+		if err = d.Data.UpdateComponent(components[0]); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if err = d.Data.DeleteProject("nodejs-starter"); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		got, err := d.encode(FormatYAML, WriteOptions{PreserveFormatting: true})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		want := strings.Replace(string(source), "image: registry.access.redhat.com/ubi8/nodejs-12:1-36", "image: quay.io/nodejs:14", 1)
+		want = strings.Replace(want, `projects:
+  - name: nodejs-starter
+    git:
+      location: "https://github.com/odo-devfiles/nodejs-ex.git"
+`, "", 1)
+		if string(got) != want {
+			t.Errorf("got:\n%s\nwant:\n%s", got, want)
+		}
+	})
+}
+
+func TestPatchYAML(t *testing.T) {
+
+	const source = `# Devfile of the nodejs stack
+schemaVersion: 2.0.0
+metadata:
+  name: nodejs # the stack name
+components:
+  # the runtime container
+  - container:
+      name: runtime
+      image: "quay.io/nodejs"
+      memoryLimit: 512Mi
+
+  - volume:
+      name: cache
+commands:
+  - exec:
+      id: build
+      component: runtime
+      commandLine: npm install # install dependencies
+`
+
+	tests := []struct {
+		name   string
+		update func(t *testing.T, d DevfileObj)
+		want   string
+	}{
+		{
+			name:   "No changes",
+			update: func(t *testing.T, d DevfileObj) {},
+			want:   source,
+		},
+		{
+			name: "Scalars are rewritten in place, keeping quotes and comments",
+			update: func(t *testing.T, d DevfileObj) {
+				if err := d.Data.SetMetadata(common.DevfileMetadata{Name: "node"}); err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				components := d.Data.GetComponents()
+				components[0].Container.Image = "quay.io/node"
+				if err := d.Data.UpdateComponent(components[0]); err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				commands := d.Data.GetCommands()
+				commands[0].Exec.CommandLine = "npm ci"
+				if err := d.Data.UpdateCommand(commands[0]); err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+			},
+			want: strings.NewReplacer(
+				"name: nodejs # the stack name", "name: node # the stack name",
+				`image: "quay.io/nodejs"`, `image: "quay.io/node"`,
+				"commandLine: npm install # install dependencies", "commandLine: npm ci # install dependencies",
+			).Replace(source),
+		},
+		{
+			name: "Items are added and removed by key",
+			update: func(t *testing.T, d DevfileObj) {
+				if err := d.Data.DeleteComponent("cache"); err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				if err := d.Data.AddCommands([]common.DevfileCommand{{Exec: &common.Exec{Id: "test", Component: "runtime", CommandLine: "npm test"}}}); err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+			},
+			want: strings.Replace(source, `
+  - volume:
+      name: cache
+`, "\n", 1) + `  - exec:
+      commandLine: npm test
+      component: runtime
+      id: test
+`,
+		},
+		{
+			name: "Members are added after the existing ones",
+			update: func(t *testing.T, d DevfileObj) {
+				components := d.Data.GetComponents()
+				mountSources := true
+				components[0].Container.MountSources = &mountSources
+				if err := d.Data.UpdateComponent(components[0]); err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+			},
+			want: strings.Replace(source, "      memoryLimit: 512Mi\n", "      memoryLimit: 512Mi\n      mountSources: true\n", 1),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d, err := ParseInMemoryAndValidate([]byte(source))
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			tt.update(t, d)
+
+			got, err := d.encode(FormatYAML, WriteOptions{PreserveFormatting: true})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if string(got) != tt.want {
+				t.Errorf("got:\n%s\nwant:\n%s", got, tt.want)
+			}
+		})
+	}
+}
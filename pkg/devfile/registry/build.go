@@ -0,0 +1,260 @@
+package registry
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+	"k8s.io/klog"
+
+	"github.com/devfile/parser/pkg/devfile/parser"
+	devfileCtx "github.com/devfile/parser/pkg/devfile/parser/context"
+	"github.com/devfile/parser/pkg/devfile/parser/data/common"
+	"github.com/devfile/parser/pkg/testingutil/filesystem"
+)
+
+// StackTypeStack is the type of the stacks listed in the index
+const StackTypeStack = "stack"
+
+// StackReport lists the problems found in a stack of a local registry
+type StackReport struct {
+	// Name of the stack, the name of its directory
+	Name string
+
+	// Problems found in the stack
+	Problems []string
+}
+
+// BuildError is returned when some stacks of a local registry are invalid, with a report per invalid stack
+type BuildError struct {
+	Reports []StackReport
+}
+
+// Error lists the problems of each invalid stack
+func (e *BuildError) Error() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%d invalid stack(s) in the registry:", len(e.Reports))
+	for _, report := range e.Reports {
+		fmt.Fprintf(&b, "\n  stack '%s':", report.Name)
+		for _, problem := range report.Problems {
+			fmt.Fprintf(&b, "\n    - %s", strings.Replace(strings.TrimSpace(problem), "\n", "\n      ", -1))
+		}
+	}
+	return b.String()
+}
+
+// devfileStarterProjects is implemented by the devfile data of the versions defining starter projects
+type devfileStarterProjects interface {
+	GetStarterProjects() []common.DevfileStarterProject
+}
+
+// BuildIndex parses and validates the stacks of a local registry, the subdirectories of dir containing a devfile.yaml,
+// and returns the index listing them, sorted by name. The parent of a stack must be another stack of the registry,
+// referenced by id or by a relative uri. A *BuildError reports the problems of the invalid stacks.
+func BuildIndex(ctx context.Context, fs filesystem.Filesystem, dir string) ([]Stack, error) {
+	if fs == nil {
+		fs = filesystem.DefaultFs{}
+	}
+	dir, err := filepath.Abs(strings.TrimPrefix(dir, "file://"))
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid registry directory")
+	}
+
+	entries, err := fs.ReadDir(dir)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to read registry directory '%s'", dir)
+	}
+	names := make(map[string]bool)
+	for _, entry := range entries {
+		if !entry.IsDir() || strings.HasPrefix(entry.Name(), ".") {
+			continue
+		}
+		if _, err := fs.Stat(filepath.Join(dir, entry.Name(), DevfileFile)); err == nil {
+			names[entry.Name()] = true
+		}
+	}
+
+	var stacks []Stack
+	var reports []StackReport
+	for _, name := range sortedNames(names) {
+		stack, problems := buildStack(ctx, fs, dir, name, names)
+		if len(problems) > 0 {
+			reports = append(reports, StackReport{Name: name, Problems: problems})
+			continue
+		}
+		stacks = append(stacks, stack)
+	}
+	if len(reports) > 0 {
+		return stacks, &BuildError{Reports: reports}
+	}
+	return stacks, nil
+}
+
+// WriteIndex writes the index of a local registry to its index.json, formatted deterministically
+func WriteIndex(fs filesystem.Filesystem, dir string, stacks []Stack) error {
+	if fs == nil {
+		fs = filesystem.DefaultFs{}
+	}
+	sorted := append([]Stack{}, stacks...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].Name < sorted[j].Name
+	})
+	content, err := json.MarshalIndent(sorted, "", "  ")
+	if err != nil {
+		return errors.Wrapf(err, "failed to encode the registry index")
+	}
+	content = append(content, '\n')
+
+	path := filepath.Join(strings.TrimPrefix(dir, "file://"), IndexFile)
+	if err := fs.WriteFile(path, content, 0644); err != nil {
+		return errors.Wrapf(err, "failed to write the registry index '%s'", path)
+	}
+	klog.V(4).Infof("wrote the index of %d stacks to '%s'", len(sorted), path)
+	return nil
+}
+
+// BuildRegistry builds the index of a local registry and writes it, unless some stacks are invalid
+func BuildRegistry(ctx context.Context, fs filesystem.Filesystem, dir string) ([]Stack, error) {
+	stacks, err := BuildIndex(ctx, fs, dir)
+	if err != nil {
+		return stacks, err
+	}
+	return stacks, WriteIndex(fs, dir, stacks)
+}
+
+// buildStack parses and validates the stack with the given name and returns its index entry, or its problems
+func buildStack(ctx context.Context, fs filesystem.Filesystem, dir string, name string, names map[string]bool) (Stack, []string) {
+	path := filepath.Join(dir, name, DevfileFile)
+
+	// Read the devfile as is to check its parent reference before flattening it
+	d, err := parser.ParseDevfile(ctx, parser.ParserArgs{Path: path, Fs: fs, Flatten: new(bool), ValidationLevel: parser.ValidateSchema})
+	if err != nil {
+		return Stack{}, []string{err.Error()}
+	}
+	var problems []string
+	if problem := checkParent(d.Data.GetParent(), path, dir, name, names); problem != "" {
+		problems = append(problems, problem)
+	}
+	metadata := d.Data.GetMetadata()
+	if metadata.Name != "" && metadata.Name != name {
+		problems = append(problems, fmt.Sprintf("metadata name '%s' doesn't match the stack directory '%s'", metadata.Name, name))
+	}
+	if len(problems) > 0 {
+		return Stack{}, problems
+	}
+
+	// Validate the devfile flattened with its parent
+	if _, err := parser.ParseDevfile(ctx, parser.ParserArgs{Path: path, Fs: fs, RegistryURLs: []string{dir}}); err != nil {
+		return Stack{}, []string{err.Error()}
+	}
+
+	resources, err := listResources(fs, filepath.Join(dir, name))
+	if err != nil {
+		return Stack{}, []string{err.Error()}
+	}
+	stack := Stack{
+		Name:        name,
+		Version:     metadata.Version,
+		DisplayName: metadata.DisplayName,
+		Description: metadata.Description,
+		Type:        StackTypeStack,
+		Tags:        metadata.Tags,
+		Icon:        metadata.Icon,
+		ProjectType: metadata.ProjectType,
+		Language:    metadata.Language,
+		// The self link is the path of the stack devfile within the registry
+		Links:     map[string]string{"self": name + "/" + DevfileFile},
+		Resources: resources,
+	}
+	if s, ok := d.Data.(devfileStarterProjects); ok {
+		for _, starter := range s.GetStarterProjects() {
+			stack.StarterProjects = append(stack.StarterProjects, starter.Name)
+		}
+	}
+	return stack, nil
+}
+
+// checkParent returns the problem of a parent reference which is not another stack of the registry, if any
+func checkParent(parent common.DevfileParent, path string, dir string, name string, names map[string]bool) string {
+	var parentName string
+	switch {
+	case parent.Id != "" || parent.RegistryEntry != nil:
+		parentName = parent.Id
+		registryURL := parent.RegistryUrl
+		if parent.RegistryEntry != nil {
+			parentName, registryURL = parent.RegistryEntry.Id, parent.RegistryEntry.BaseUrl
+		}
+		if registryURL != "" {
+			return fmt.Sprintf("parent '%s' must be a stack of the registry, got registry url '%s'", parentName, registryURL)
+		}
+	case parent.Uri != "":
+		location, err := devfileCtx.ResolveLocation(path, parent.Uri)
+		if err != nil {
+			return err.Error()
+		}
+		rel, err := filepath.Rel(dir, location)
+		if err != nil || filepath.Base(rel) != DevfileFile || filepath.Dir(filepath.Dir(rel)) != "." {
+			return fmt.Sprintf("parent uri '%s' must reference the devfile of a stack of the registry", parent.Uri)
+		}
+		parentName = filepath.Dir(rel)
+	case parent.Kubernetes != nil:
+		return fmt.Sprintf("parent '%s' must be a stack of the registry, got a kubernetes reference", parent.Kubernetes.Name)
+	default:
+		return ""
+	}
+
+	if parentName == name {
+		return fmt.Sprintf("stack '%s' can't be its own parent", name)
+	}
+	if !names[parentName] {
+		return fmt.Sprintf("parent '%s' is not a stack of the registry", parentName)
+	}
+	return ""
+}
+
+// listResources returns the paths of the files of the stack directory, sorted, skipping the hidden files.
+// The util indexers are not used as they write their file index and .gitignore entry into the walked directory,
+// and walk the OS filesystem instead of the given one.
+func listResources(fs filesystem.Filesystem, stackDir string) ([]string, error) {
+	var resources []string
+	err := fs.Walk(stackDir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if path != stackDir && strings.HasPrefix(info.Name(), ".") {
+			if info.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if info.IsDir() {
+			return nil
+		}
+		rel, err := filepath.Rel(stackDir, path)
+		if err != nil {
+			return err
+		}
+		resources = append(resources, filepath.ToSlash(rel))
+		return nil
+	})
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to list the resources of '%s'", stackDir)
+	}
+	sort.Strings(resources)
+	return resources, nil
+}
+
+// sortedNames returns the names of the set in ascending order
+func sortedNames(names map[string]bool) []string {
+	sorted := make([]string, 0, len(names))
+	for name := range names {
+		sorted = append(sorted, name)
+	}
+	sort.Strings(sorted)
+	return sorted
+}
@@ -0,0 +1,160 @@
+package registry
+
+import (
+	"context"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/devfile/parser/pkg/testingutil/filesystem"
+)
+
+const nodejsStack = `schemaVersion: 2.2.0
+metadata:
+  name: nodejs
+  version: 1.0.0
+  displayName: NodeJS Runtime
+  tags: ["NodeJS", "Express"]
+  language: nodejs
+  projectType: nodejs
+starterProjects:
+  - name: nodejs-starter
+    git:
+      location: https://github.com/odo-devfiles/nodejs-ex.git
+components:
+  - name: runtime
+    container:
+      image: quay.io/nodejs
+`
+
+func TestBuildIndex(t *testing.T) {
+
+	// createRegistry helper writes the stack files in a registry directory of a fake filesystem
+	createRegistry := func(t *testing.T, files map[string]string) filesystem.Filesystem {
+		t.Helper()
+		fs := filesystem.NewFakeFs()
+		for name, content := range files {
+			if err := fs.WriteFile(filepath.Join("/registry", name), []byte(content), 0644); err != nil {
+				t.Fatalf("failed to write registry file: %v", err)
+			}
+		}
+		return fs
+	}
+
+	t.Run("valid registry", func(t *testing.T) {
+		fs := createRegistry(t, map[string]string{
+			"nodejs/devfile.yaml":               nodejsStack,
+			"nodejs/.git/config":                "",
+			"nodejs/kubernetes/deploy.yaml":     "kind: Deployment\n",
+			"nodejs-express/devfile.yaml":       "schemaVersion: 2.2.0\nparent:\n  id: nodejs\n",
+			"nodejs-express-mongo/devfile.yaml": "schemaVersion: 2.2.0\nparent:\n  uri: ../nodejs-express/devfile.yaml\n",
+			"docs/README.md":                    "not a stack",
+		})
+
+		stacks, err := BuildRegistry(context.Background(), fs, "/registry")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(stacks) != 3 {
+			t.Fatalf("expected 3 stacks, got %d", len(stacks))
+		}
+
+		content, err := fs.ReadFile("/registry/index.json")
+		if err != nil {
+			t.Fatalf("failed to read index: %v", err)
+		}
+		want := `[
+  {
+    "name": "nodejs",
+    "version": "1.0.0",
+    "displayName": "NodeJS Runtime",
+    "type": "stack",
+    "tags": [
+      "NodeJS",
+      "Express"
+    ],
+    "projectType": "nodejs",
+    "language": "nodejs",
+    "links": {
+      "self": "nodejs/devfile.yaml"
+    },
+    "resources": [
+      "devfile.yaml",
+      "kubernetes/deploy.yaml"
+    ],
+    "starterProjects": [
+      "nodejs-starter"
+    ]
+  },
+  {
+    "name": "nodejs-express",
+    "type": "stack",
+    "links": {
+      "self": "nodejs-express/devfile.yaml"
+    },
+    "resources": [
+      "devfile.yaml"
+    ]
+  },
+  {
+    "name": "nodejs-express-mongo",
+    "type": "stack",
+    "links": {
+      "self": "nodejs-express-mongo/devfile.yaml"
+    },
+    "resources": [
+      "devfile.yaml"
+    ]
+  }
+]
+`
+		if string(content) != want {
+			t.Errorf("got index:\n%s\nwant:\n%s", content, want)
+		}
+	})
+
+	t.Run("invalid stacks", func(t *testing.T) {
+		fs := createRegistry(t, map[string]string{
+			"nodejs/devfile.yaml":   nodejsStack,
+			"python/devfile.yaml":   "schemaVersion: 2.2.0\nparent:\n  id: python-base\n",
+			"remote/devfile.yaml":   "schemaVersion: 2.2.0\nparent:\n  id: nodejs\n  registryUrl: https://registry.devfile.io\n",
+			"renamed/devfile.yaml":  strings.Replace(nodejsStack, "name: nodejs\n", "name: node\n", 1),
+			"volumes/devfile.yaml":  "schemaVersion: 2.2.0\ncomponents:\n  - name: cache\n    volume: {}\n",
+			"outside/devfile.yaml":  "schemaVersion: 2.2.0\nparent:\n  uri: /stacks/devfile.yaml\n",
+			"yourself/devfile.yaml": "schemaVersion: 2.2.0\nparent:\n  uri: devfile.yaml\n",
+		})
+
+		stacks, err := BuildRegistry(context.Background(), fs, "/registry")
+		buildErr, ok := err.(*BuildError)
+		if !ok {
+			t.Fatalf("expected a build error, got %v", err)
+		}
+		if len(stacks) != 1 || stacks[0].Name != "nodejs" {
+			t.Errorf("expected the valid nodejs stack, got '%v'", stacks)
+		}
+
+		var names []string
+		for _, report := range buildErr.Reports {
+			names = append(names, report.Name)
+		}
+		if want := []string{"outside", "python", "remote", "renamed", "volumes", "yourself"}; !reflect.DeepEqual(names, want) {
+			t.Errorf("got reports for '%v', want '%v'", names, want)
+		}
+		for _, problem := range []string{
+			"parent uri '/stacks/devfile.yaml' must reference the devfile of a stack of the registry",
+			"parent 'python-base' is not a stack of the registry",
+			"got registry url 'https://registry.devfile.io'",
+			"metadata name 'node' doesn't match the stack directory 'renamed'",
+			"odo requires atleast one component of type 'Container' in devfile",
+			"stack 'yourself' can't be its own parent",
+		} {
+			if !strings.Contains(err.Error(), problem) {
+				t.Errorf("expected the report to contain '%s', got:\n%s", problem, err)
+			}
+		}
+		if _, err := fs.Stat("/registry/index.json"); err == nil {
+			t.Errorf("expected no index to be written for an invalid registry")
+		}
+	})
+}
@@ -0,0 +1,122 @@
+package registry
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+
+	"github.com/pkg/errors"
+	"k8s.io/klog"
+
+	"github.com/devfile/parser/pkg/util"
+)
+
+// etagSuffix is the suffix of the cache file holding the ETag of a cached file
+const etagSuffix = ".etag"
+
+// fetchURL returns the content of the file at the given URL. A cached file is revalidated with its ETag
+// and is served when the registry can't be reached or when the client is offline.
+func (c *Client) fetchURL(ctx context.Context, location string) ([]byte, error) {
+	cached, etag, cacheErr := c.readCache(location)
+	if c.Offline {
+		if cacheErr != nil {
+			return nil, fmt.Errorf("'%s' is not cached and can't be fetched offline", location)
+		}
+		return cached, nil
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, location, nil)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid registry url '%s'", location)
+	}
+	if cacheErr == nil && etag != "" {
+		req.Header.Set("If-None-Match", etag)
+	}
+
+	client := c.HTTPClient
+	if client == nil {
+		client = &http.Client{Timeout: util.HTTPRequestTimeout}
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		if cacheErr == nil && ctx.Err() == nil {
+			klog.V(4).Infof("registry unreachable, using the cached '%s': %v", location, err)
+			return cached, nil
+		}
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotModified && cacheErr == nil {
+		klog.V(4).Infof("cached '%s' is up to date", location)
+		return cached, nil
+	}
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return nil, fmt.Errorf("failed to download '%s': %s", location, http.StatusText(resp.StatusCode))
+	}
+
+	maxSize := c.MaxSize
+	if maxSize <= 0 {
+		maxSize = DefaultMaxResourceSize
+	}
+	content, err := ioutil.ReadAll(io.LimitReader(resp.Body, maxSize+1))
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to download '%s'", location)
+	}
+	if int64(len(content)) > maxSize {
+		return nil, fmt.Errorf("'%s' exceeds the maximum size of %d bytes", location, maxSize)
+	}
+
+	if err := c.writeCache(location, content, resp.Header.Get("ETag")); err != nil {
+		klog.V(4).Infof("failed to cache '%s': %v", location, err)
+	}
+	return content, nil
+}
+
+// cachePath returns the path of the cache file of the given URL
+func (c *Client) cachePath(location string) string {
+	sum := sha256.Sum256([]byte(location))
+	return filepath.Join(c.CacheDir, hex.EncodeToString(sum[:]))
+}
+
+// readCache returns the cached content of the given URL and its ETag, if any
+func (c *Client) readCache(location string) (content []byte, etag string, err error) {
+	if c.CacheDir == "" {
+		return nil, "", fmt.Errorf("no cache directory")
+	}
+	p := c.cachePath(location)
+	if content, err = c.fs().ReadFile(p); err != nil {
+		return nil, "", err
+	}
+	if e, err := c.fs().ReadFile(p + etagSuffix); err == nil {
+		etag = string(e)
+	}
+	return content, etag, nil
+}
+
+// writeCache caches the content of the given URL with its ETag
+func (c *Client) writeCache(location string, content []byte, etag string) error {
+	if c.CacheDir == "" {
+		return nil
+	}
+	if err := c.fs().MkdirAll(c.CacheDir, 0755); err != nil {
+		return err
+	}
+	p := c.cachePath(location)
+	if err := c.fs().WriteFile(p, content, 0644); err != nil {
+		return err
+	}
+	if etag == "" {
+		if err := c.fs().Remove(p + etagSuffix); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+		return nil
+	}
+	return c.fs().WriteFile(p+etagSuffix, []byte(etag), 0644)
+}
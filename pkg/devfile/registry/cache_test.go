@@ -0,0 +1,52 @@
+package registry
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/devfile/parser/pkg/testingutil/filesystem"
+)
+
+func TestFetchURLCache(t *testing.T) {
+
+	requests, downloads := 0, 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if r.Header.Get("If-None-Match") == `"v1"` {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		downloads++
+		w.Header().Set("ETag", `"v1"`)
+		fmt.Fprint(w, testIndex)
+	}))
+
+	c := &Client{HTTPClient: server.Client(), Fs: filesystem.NewFakeFs(), CacheDir: "/cache"}
+	ctx := context.Background()
+	registry := server.URL
+
+	for i := 0; i < 2; i++ {
+		if stacks, err := c.GetIndex(ctx, registry); err != nil || len(stacks) != 2 {
+			t.Fatalf("unexpected index '%v' (%v)", stacks, err)
+		}
+	}
+	if requests != 2 || downloads != 1 {
+		t.Errorf("expected the cached index to be revalidated, got %d requests and %d downloads", requests, downloads)
+	}
+
+	// The cached index is served when the registry is unreachable or when offline
+	server.Close()
+	if stacks, err := c.GetIndex(ctx, registry); err != nil || len(stacks) != 2 {
+		t.Errorf("expected the cached index with the registry unreachable, got '%v' (%v)", stacks, err)
+	}
+	c.Offline = true
+	if stacks, err := c.GetIndex(ctx, registry); err != nil || len(stacks) != 2 {
+		t.Errorf("expected the cached index offline, got '%v' (%v)", stacks, err)
+	}
+	if _, err := c.GetDevfile(ctx, registry, "nodejs"); err == nil {
+		t.Errorf("expected an error for a devfile not cached offline")
+	}
+}
@@ -0,0 +1,199 @@
+package registry
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/pkg/errors"
+	"k8s.io/klog"
+
+	devfileCtx "github.com/devfile/parser/pkg/devfile/parser/context"
+	"github.com/devfile/parser/pkg/testingutil/filesystem"
+)
+
+const (
+	// IndexFile is the name of the index listing the stacks of a registry
+	IndexFile = "index.json"
+
+	// DevfileFile is the name of the devfile of a stack
+	DevfileFile = "devfile.yaml"
+
+	// DefaultMaxResourceSize is the maximum size in bytes of a downloaded index or stack resource, unless set otherwise
+	DefaultMaxResourceSize int64 = 50 * 1024 * 1024
+)
+
+// Client fetches the stacks of devfile registries. A registry is either served over http(s), its index
+// and stacks being under <url>/devfiles, or a local directory containing the index and the stack directories.
+// The files of the http(s) registries are cached on disk when a cache directory is set.
+type Client struct {
+	// HTTPClient fetches the files of the http(s) registries, a client with the default timeout when nil
+	HTTPClient *http.Client
+
+	// Fs is the filesystem of the local registries and of the cache
+	Fs filesystem.Filesystem
+
+	// CacheDir is the directory caching the files of the http(s) registries, no cache is used when empty
+	CacheDir string
+
+	// Offline serves the files of the http(s) registries from the cache only
+	Offline bool
+
+	// MaxSize is the maximum size in bytes of a downloaded file, DefaultMaxResourceSize when not positive
+	MaxSize int64
+}
+
+// NewClient returns a registry client caching the files of the http(s) registries in the cache directory
+func NewClient(cacheDir string) *Client {
+	return &Client{
+		Fs:       filesystem.DefaultFs{},
+		CacheDir: cacheDir,
+	}
+}
+
+// GetIndex returns the stacks listed in the index of the registry
+func (c *Client) GetIndex(ctx context.Context, registry string) ([]Stack, error) {
+	content, err := c.fetch(ctx, c.location(registry, IndexFile))
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to fetch the index of registry '%s'", registry)
+	}
+	var stacks []Stack
+	if err := json.Unmarshal(content, &stacks); err != nil {
+		return nil, errors.Wrapf(err, "failed to decode the index of registry '%s'", registry)
+	}
+	return stacks, nil
+}
+
+// SearchStacks returns the stacks of the registry selected by the filter
+func (c *Client) SearchStacks(ctx context.Context, registry string, filter Filter) ([]Stack, error) {
+	stacks, err := c.GetIndex(ctx, registry)
+	if err != nil {
+		return nil, err
+	}
+	return FilterStacks(stacks, filter), nil
+}
+
+// GetStack returns the stack with the given name listed in the index of the registry
+func (c *Client) GetStack(ctx context.Context, registry string, name string) (Stack, error) {
+	stacks, err := c.GetIndex(ctx, registry)
+	if err != nil {
+		return Stack{}, err
+	}
+	for _, s := range stacks {
+		if s.Name == name {
+			return s, nil
+		}
+	}
+	return Stack{}, fmt.Errorf("stack '%s' not found in registry '%s'", name, registry)
+}
+
+// GetDevfile returns the devfile content of the stack with the given name
+func (c *Client) GetDevfile(ctx context.Context, registry string, name string) ([]byte, error) {
+	return c.GetResource(ctx, registry, name, DevfileFile)
+}
+
+// GetResource returns the content of a resource of the stack with the given name
+func (c *Client) GetResource(ctx context.Context, registry string, name string, resource string) ([]byte, error) {
+	if err := validateRelativePath(name); err != nil {
+		return nil, errors.Wrapf(err, "invalid stack name")
+	}
+	if err := validateRelativePath(resource); err != nil {
+		return nil, errors.Wrapf(err, "invalid resource of stack '%s'", name)
+	}
+	content, err := c.fetch(ctx, c.location(registry, name, resource))
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to fetch resource '%s' of stack '%s'", resource, name)
+	}
+	return content, nil
+}
+
+// DownloadStack downloads the devfile and the resources of the stack with the given name into the destination directory
+func (c *Client) DownloadStack(ctx context.Context, registry string, name string, destination string) error {
+	stack, err := c.GetStack(ctx, registry, name)
+	if err != nil {
+		return err
+	}
+
+	resources := stack.Resources
+	if !containsString(resources, DevfileFile) {
+		resources = append([]string{DevfileFile}, resources...)
+	}
+	// Check the resources before writing any file of the stack
+	for _, resource := range resources {
+		if err := validateRelativePath(resource); err != nil {
+			return errors.Wrapf(err, "invalid resource of stack '%s'", name)
+		}
+	}
+	for _, resource := range resources {
+		content, err := c.GetResource(ctx, registry, name, resource)
+		if err != nil {
+			return err
+		}
+		target := filepath.Join(destination, filepath.FromSlash(resource))
+		if err := c.fs().MkdirAll(filepath.Dir(target), 0755); err != nil {
+			return errors.Wrapf(err, "failed to create directory for '%s'", target)
+		}
+		if err := c.fs().WriteFile(target, content, 0644); err != nil {
+			return errors.Wrapf(err, "failed to write '%s'", target)
+		}
+		klog.V(4).Infof("downloaded resource '%s' of stack '%s' to '%s'", resource, name, target)
+	}
+	return nil
+}
+
+// location returns the location of a file of the registry, given by its path elements relative to the stacks directory
+func (c *Client) location(registry string, elements ...string) string {
+	if devfileCtx.IsURL(registry) {
+		escaped := make([]string, 0, len(elements))
+		for _, element := range elements {
+			for _, segment := range strings.Split(element, "/") {
+				escaped = append(escaped, url.PathEscape(segment))
+			}
+		}
+		return strings.TrimSuffix(registry, "/") + "/devfiles/" + strings.Join(escaped, "/")
+	}
+	dir := strings.TrimPrefix(registry, "file://")
+	return filepath.Join(dir, filepath.FromSlash(path.Join(elements...)))
+}
+
+// fetch returns the content of a file of an http(s) registry, going through the cache, or of a local registry
+func (c *Client) fetch(ctx context.Context, location string) ([]byte, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	if devfileCtx.IsURL(location) {
+		return c.fetchURL(ctx, location)
+	}
+	return c.fs().ReadFile(location)
+}
+
+// fs returns the filesystem of the client, the OS filesystem when not set
+func (c *Client) fs() filesystem.Filesystem {
+	if c.Fs == nil {
+		return filesystem.DefaultFs{}
+	}
+	return c.Fs
+}
+
+// validateRelativePath checks that a stack name or resource is a relative path within the stack directory
+func validateRelativePath(p string) error {
+	if p == "" || strings.Contains(p, "\\") || path.IsAbs(p) || path.Clean(p) != p || p == ".." || strings.HasPrefix(p, "../") {
+		return fmt.Errorf("'%s' must be a relative path within the registry", p)
+	}
+	return nil
+}
+
+// containsString returns true if the list contains the string
+func containsString(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
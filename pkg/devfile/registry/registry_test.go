@@ -0,0 +1,135 @@
+package registry
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/devfile/parser/pkg/testingutil/filesystem"
+)
+
+const testIndex = `[
+  {
+    "name": "nodejs",
+    "displayName": "NodeJS Runtime",
+    "tags": ["NodeJS", "Express"],
+    "language": "nodejs",
+    "projectType": "nodejs",
+    "links": {"self": "devfile-catalog/nodejs:latest"},
+    "resources": ["devfile.yaml", "kubernetes/deploy.yaml"],
+    "starterProjects": ["nodejs-starter"]
+  },
+  {
+    "name": "java-maven",
+    "displayName": "Maven Java",
+    "tags": ["Java", "Maven"],
+    "language": "java",
+    "projectType": "maven",
+    "resources": ["../secret"]
+  }
+]`
+
+const testDevfile = "schemaVersion: 2.1.0\nmetadata:\n  name: nodejs\n"
+
+func TestClient(t *testing.T) {
+
+	files := map[string]string{
+		"index.json":                    testIndex,
+		"nodejs/devfile.yaml":           testDevfile,
+		"nodejs/kubernetes/deploy.yaml": "kind: Deployment\n",
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		content, ok := files[strings.TrimPrefix(r.URL.Path, "/devfiles/")]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		fmt.Fprint(w, content)
+	}))
+	defer server.Close()
+
+	fs := filesystem.NewFakeFs()
+	for name, content := range files {
+		if err := fs.WriteFile(filepath.Join("/registry", name), []byte(content), 0644); err != nil {
+			t.Fatalf("failed to write registry file: %v", err)
+		}
+	}
+
+	registries := map[string]string{
+		"http registry":  server.URL,
+		"local registry": "/registry",
+	}
+	for name, registry := range registries {
+		t.Run(name, func(t *testing.T) {
+			c := &Client{HTTPClient: server.Client(), Fs: fs}
+			ctx := context.Background()
+
+			stacks, err := c.GetIndex(ctx, registry)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if len(stacks) != 2 || stacks[0].Links["self"] != "devfile-catalog/nodejs:latest" || !reflect.DeepEqual(stacks[0].StarterProjects, []string{"nodejs-starter"}) {
+				t.Errorf("unexpected index '%+v'", stacks)
+			}
+
+			found, err := c.SearchStacks(ctx, registry, Filter{Tags: []string{"express"}})
+			if err != nil || len(found) != 1 || found[0].Name != "nodejs" {
+				t.Errorf("expected to find the nodejs stack, got '%+v' (%v)", found, err)
+			}
+
+			devfile, err := c.GetDevfile(ctx, registry, "nodejs")
+			if err != nil || string(devfile) != testDevfile {
+				t.Errorf("unexpected devfile '%s' (%v)", devfile, err)
+			}
+
+			destination := filepath.Join("/stacks", strings.Replace(name, " ", "-", -1))
+			if err := c.DownloadStack(ctx, registry, "nodejs", destination); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			for _, resource := range []string{"devfile.yaml", "kubernetes/deploy.yaml"} {
+				content, err := fs.ReadFile(filepath.Join(destination, resource))
+				if err != nil || string(content) != files["nodejs/"+resource] {
+					t.Errorf("unexpected resource '%s': '%s' (%v)", resource, content, err)
+				}
+			}
+
+			if err := c.DownloadStack(ctx, registry, "java-maven", destination); err == nil || !strings.Contains(err.Error(), "must be a relative path within the registry") {
+				t.Errorf("expected the resource outside of the stack to be rejected, got %v", err)
+			}
+			if _, err := c.GetStack(ctx, registry, "python"); err == nil {
+				t.Errorf("expected an error for a stack not in the index")
+			}
+		})
+	}
+}
+
+func TestValidateRelativePath(t *testing.T) {
+
+	tests := []struct {
+		path    string
+		wantErr bool
+	}{
+		{path: "devfile.yaml"},
+		{path: "kubernetes/deploy.yaml"},
+		{path: "", wantErr: true},
+		{path: "/etc/passwd", wantErr: true},
+		{path: "../devfile.yaml", wantErr: true},
+		{path: "kubernetes/../../devfile.yaml", wantErr: true},
+		{path: `..\devfile.yaml`, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			err := validateRelativePath(tt.path)
+			if tt.wantErr != (err != nil) {
+				t.Errorf("unexpected error %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,90 @@
+package registry
+
+import (
+	"strings"
+)
+
+// Stack is a stack listed in the index of a devfile registry
+type Stack struct {
+	// Name of the stack, the directory of its devfile and resources in the registry
+	Name string `json:"name"`
+
+	Version     string   `json:"version,omitempty"`
+	DisplayName string   `json:"displayName,omitempty"`
+	Description string   `json:"description,omitempty"`
+	Type        string   `json:"type,omitempty"`
+	Tags        []string `json:"tags,omitempty"`
+	Icon        string   `json:"icon,omitempty"`
+	ProjectType string   `json:"projectType,omitempty"`
+	Language    string   `json:"language,omitempty"`
+
+	// Links of the stack, e.g. its self link in the registry
+	Links map[string]string `json:"links,omitempty"`
+
+	// Resources are the files of the stack, relative to its directory, including its devfile
+	Resources []string `json:"resources,omitempty"`
+
+	// StarterProjects are the names of the starter projects of the stack devfile
+	StarterProjects []string `json:"starterProjects,omitempty"`
+}
+
+// Filter selects the stacks of a registry index, the empty fields select all the stacks
+type Filter struct {
+	// Tags selects the stacks having all the tags, ignoring the case
+	Tags []string
+
+	// Language selects the stacks of the language, ignoring the case
+	Language string
+
+	// ProjectType selects the stacks of the project type, ignoring the case
+	ProjectType string
+
+	// Text selects the stacks whose name, display name or description contain the text, ignoring the case
+	Text string
+}
+
+// HasTag returns true if the stack has the tag, ignoring the case
+func (s Stack) HasTag(tag string) bool {
+	for _, t := range s.Tags {
+		if strings.EqualFold(t, tag) {
+			return true
+		}
+	}
+	return false
+}
+
+// Matches returns true if the stack is selected by the filter
+func (f Filter) Matches(s Stack) bool {
+	for _, tag := range f.Tags {
+		if !s.HasTag(tag) {
+			return false
+		}
+	}
+	if f.Language != "" && !strings.EqualFold(s.Language, f.Language) {
+		return false
+	}
+	if f.ProjectType != "" && !strings.EqualFold(s.ProjectType, f.ProjectType) {
+		return false
+	}
+	if f.Text != "" {
+		text := strings.ToLower(f.Text)
+		for _, field := range []string{s.Name, s.DisplayName, s.Description} {
+			if strings.Contains(strings.ToLower(field), text) {
+				return true
+			}
+		}
+		return false
+	}
+	return true
+}
+
+// FilterStacks returns the stacks selected by the filter, in the order of the index
+func FilterStacks(stacks []Stack, filter Filter) []Stack {
+	var selected []Stack
+	for _, s := range stacks {
+		if filter.Matches(s) {
+			selected = append(selected, s)
+		}
+	}
+	return selected
+}
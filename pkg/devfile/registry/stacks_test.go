@@ -0,0 +1,57 @@
+package registry
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestFilterStacks(t *testing.T) {
+
+	stacks := []Stack{
+		{Name: "nodejs", DisplayName: "NodeJS Runtime", Tags: []string{"NodeJS", "Express"}, Language: "nodejs", ProjectType: "nodejs"},
+		{Name: "java-springboot", DisplayName: "Spring Boot", Description: "Java application with Maven", Tags: []string{"Java", "Spring"}, Language: "java", ProjectType: "springboot"},
+		{Name: "java-quarkus", DisplayName: "Quarkus Java", Tags: []string{"Java", "Quarkus"}, Language: "java", ProjectType: "quarkus"},
+	}
+
+	tests := []struct {
+		name   string
+		filter Filter
+		want   []string
+	}{
+		{
+			name: "Case 1: No filter",
+			want: []string{"nodejs", "java-springboot", "java-quarkus"},
+		},
+		{
+			name:   "Case 2: Tags ignoring the case",
+			filter: Filter{Tags: []string{"java", "SPRING"}},
+			want:   []string{"java-springboot"},
+		},
+		{
+			name:   "Case 3: Language",
+			filter: Filter{Language: "Java"},
+			want:   []string{"java-springboot", "java-quarkus"},
+		},
+		{
+			name:   "Case 4: Text in the description",
+			filter: Filter{Text: "maven"},
+			want:   []string{"java-springboot"},
+		},
+		{
+			name:   "Case 5: No match",
+			filter: Filter{Language: "java", ProjectType: "nodejs"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+			for _, s := range FilterStacks(stacks, tt.filter) {
+				got = append(got, s.Name)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got: '%v', want: '%v'", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,152 @@
+package validate
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/devfile/parser/pkg/devfile/parser/data/common"
+)
+
+// Errors
+var (
+	ErrorMissingExecComponent          = "exec command '%s' does not reference any component"
+	ErrorInvalidExecComponent          = "exec command '%s' references component '%s' which is not a container component"
+	ErrorCompositeCommandEmptyCommands = "composite command '%s' does not reference any command"
+	ErrorInvalidCompositeCommand       = "composite command '%s' references command '%s' which does not exist"
+	ErrorCompositeCommandCycle         = "composite command '%s' references itself through '%s'"
+	ErrorMultipleDefaultCommands       = "command group '%s' has more than one default command: %s"
+	ErrorInvalidApplyComponent         = "apply command '%s' references component '%s' which is not an image, kubernetes or openshift component"
+)
+
+// ValidateCommands validates all the devfile commands against the devfile components:
+// exec commands must reference an existing container component, composite commands must
+// reference existing commands without cycles, apply commands must reference an existing image, kubernetes
+// or openshift component, and every group kind has at most one default command
+func ValidateCommands(commands []common.DevfileCommand, components []common.DevfileComponent) *ValidationResult {
+	result := NewValidationResult()
+
+	containers := make(map[string]bool)
+	applicable := make(map[string]bool)
+	for _, component := range components {
+		switch {
+		case component.Container != nil:
+			containers[strings.ToLower(component.Container.Name)] = true
+		case component.Image != nil, component.Kubernetes != nil, component.Openshift != nil:
+			applicable[strings.ToLower(component.GetName())] = true
+		}
+	}
+
+	commandMap := getCommandMap(commands)
+
+	for i, command := range commands {
+		switch {
+		case command.Exec != nil:
+			if command.Exec.Component == "" {
+				result.AddError(JSONPointer("commands", i, "exec"), CodeMissingExecComponent, fmt.Sprintf(ErrorMissingExecComponent, command.Exec.Id))
+			} else if !containers[strings.ToLower(command.Exec.Component)] {
+				result.AddError(JSONPointer("commands", i, "exec", "component"), CodeInvalidExecComponent, fmt.Sprintf(ErrorInvalidExecComponent, command.Exec.Id, command.Exec.Component))
+			}
+		case command.Composite != nil:
+			validateCompositeCommand(i, command, commandMap, result)
+		case command.Apply != nil:
+			if !applicable[strings.ToLower(command.Apply.Component)] {
+				result.AddError(JSONPointer("commands", i, "apply", "component"), CodeInvalidApplyComponent, fmt.Sprintf(ErrorInvalidApplyComponent, command.Apply.Id, command.Apply.Component))
+			}
+		}
+	}
+
+	validateDefaultCommands(commands, result)
+
+	return result
+}
+
+// getCommandKey returns the key of the command kind in the devfile, e.g. vscodeTask
+func getCommandKey(command common.DevfileCommand) string {
+	kind := string(command.GetCommandType())
+	return strings.ToLower(kind[:1]) + kind[1:]
+}
+
+// getCommandMap returns the commands indexed by their lowercase id
+func getCommandMap(commands []common.DevfileCommand) map[string]common.DevfileCommand {
+	commandMap := make(map[string]common.DevfileCommand, len(commands))
+	for _, command := range commands {
+		commandMap[strings.ToLower(command.GetId())] = command
+	}
+	return commandMap
+}
+
+// validateCompositeCommand checks that the sub-commands of a composite command exist,
+// and that the composite command doesn't reference itself through its sub-commands
+func validateCompositeCommand(index int, command common.DevfileCommand, commandMap map[string]common.DevfileCommand, result *ValidationResult) {
+	composite := command.Composite
+	if len(composite.Commands) == 0 {
+		result.AddError(JSONPointer("commands", index, "composite", "commands"), CodeEmptyCompositeCommand, fmt.Sprintf(ErrorCompositeCommandEmptyCommands, composite.Id))
+		return
+	}
+
+	for j, subCommandId := range composite.Commands {
+		if _, ok := commandMap[strings.ToLower(subCommandId)]; !ok {
+			result.AddError(JSONPointer("commands", index, "composite", "commands", j), CodeInvalidCompositeCommand, fmt.Sprintf(ErrorInvalidCompositeCommand, composite.Id, subCommandId))
+		}
+	}
+
+	if cycle := findCompositeCycle(strings.ToLower(composite.Id), command, commandMap, nil); cycle != nil {
+		result.AddError(JSONPointer("commands", index, "composite", "commands"), CodeCompositeCommandCycle, fmt.Sprintf(ErrorCompositeCommandCycle, composite.Id, strings.Join(cycle, " -> ")))
+	}
+}
+
+// findCompositeCycle returns the chain of command ids leading from the composite command back to the
+// command with the given id, or nil if there is none
+func findCompositeCycle(id string, command common.DevfileCommand, commandMap map[string]common.DevfileCommand, chain []string) []string {
+	chain = append(chain, strings.ToLower(command.GetId()))
+	for _, subCommandId := range command.Composite.Commands {
+		subId := strings.ToLower(subCommandId)
+		if subId == id {
+			return append(chain, subId)
+		}
+
+		subCommand, ok := commandMap[subId]
+		if !ok || subCommand.Composite == nil {
+			continue
+		}
+		visited := false
+		for _, c := range chain {
+			if c == subId {
+				visited = true
+				break
+			}
+		}
+		if visited {
+			continue
+		}
+		if cycle := findCompositeCycle(id, subCommand, commandMap, chain); cycle != nil {
+			return cycle
+		}
+	}
+	return nil
+}
+
+// validateDefaultCommands checks that there is at most one default command per group kind
+func validateDefaultCommands(commands []common.DevfileCommand, result *ValidationResult) {
+	defaults := make(map[common.DevfileCommandGroupType][]string)
+	lastIndex := make(map[common.DevfileCommandGroupType]int)
+	var kinds []common.DevfileCommandGroupType
+	for i, command := range commands {
+		group := command.GetGroup()
+		if group == nil || !group.IsDefault {
+			continue
+		}
+		if _, ok := defaults[group.Kind]; !ok {
+			kinds = append(kinds, group.Kind)
+		}
+		defaults[group.Kind] = append(defaults[group.Kind], command.GetId())
+		lastIndex[group.Kind] = i
+	}
+
+	for _, kind := range kinds {
+		if len(defaults[kind]) > 1 {
+			path := JSONPointer("commands", lastIndex[kind], getCommandKey(commands[lastIndex[kind]]), "group", "isDefault")
+			result.AddError(path, CodeMultipleDefaultCommands, fmt.Sprintf(ErrorMultipleDefaultCommands, kind, strings.Join(defaults[kind], ", ")))
+		}
+	}
+}
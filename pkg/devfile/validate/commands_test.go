@@ -0,0 +1,111 @@
+package validate
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/devfile/parser/pkg/devfile/parser/data/common"
+)
+
+func TestValidateCommands(t *testing.T) {
+
+	components := []common.DevfileComponent{
+		{Container: &common.Container{Name: "runtime"}},
+		{Volume: &common.Volume{Name: "cache"}},
+		{Image: &common.Image{Name: "image", ImageName: "nodejs-app"}},
+	}
+
+	tests := []struct {
+		name     string
+		commands []common.DevfileCommand
+		want     []ValidationError
+	}{
+		{
+			name: "Valid exec and composite commands",
+			commands: []common.DevfileCommand{
+				{Exec: &common.Exec{Id: "build", Component: "runtime", Group: &common.Group{Kind: common.BuildCommandGroupType, IsDefault: true}}},
+				{Exec: &common.Exec{Id: "run", Component: "Runtime", Group: &common.Group{Kind: common.RunCommandGroupType, IsDefault: true}}},
+				{Composite: &common.Composite{Id: "all", Commands: []string{"build", "Run"}}},
+			},
+		},
+		{
+			name: "Exec command referencing a missing component",
+			commands: []common.DevfileCommand{
+				{Exec: &common.Exec{Id: "build", Component: "missing"}},
+			},
+			want: []ValidationError{
+				{Path: "/commands/0/exec/component", Code: CodeInvalidExecComponent, Severity: SeverityError, Message: fmt.Sprintf(ErrorInvalidExecComponent, "build", "missing")},
+			},
+		},
+		{
+			name: "Exec command referencing a volume component",
+			commands: []common.DevfileCommand{
+				{Exec: &common.Exec{Id: "build", Component: "cache"}},
+			},
+			want: []ValidationError{
+				{Path: "/commands/0/exec/component", Code: CodeInvalidExecComponent, Severity: SeverityError, Message: fmt.Sprintf(ErrorInvalidExecComponent, "build", "cache")},
+			},
+		},
+		{
+			name: "Apply commands referencing an image and a volume component",
+			commands: []common.DevfileCommand{
+				{Apply: &common.Apply{Id: "build-image", Component: "image", Group: &common.Group{Kind: common.DeployCommandGroupType}}},
+				{Apply: &common.Apply{Id: "cache", Component: "cache"}},
+			},
+			want: []ValidationError{
+				{Path: "/commands/1/apply/component", Code: CodeInvalidApplyComponent, Severity: SeverityError, Message: fmt.Sprintf(ErrorInvalidApplyComponent, "cache", "cache")},
+			},
+		},
+		{
+			name: "Composite command referencing a missing command",
+			commands: []common.DevfileCommand{
+				{Composite: &common.Composite{Id: "all", Commands: []string{"missing"}}},
+			},
+			want: []ValidationError{
+				{Path: "/commands/0/composite/commands/0", Code: CodeInvalidCompositeCommand, Severity: SeverityError, Message: fmt.Sprintf(ErrorInvalidCompositeCommand, "all", "missing")},
+			},
+		},
+		{
+			name: "Composite commands with a cycle",
+			commands: []common.DevfileCommand{
+				{Composite: &common.Composite{Id: "a", Commands: []string{"b"}}},
+				{Composite: &common.Composite{Id: "b", Commands: []string{"a"}}},
+			},
+			want: []ValidationError{
+				{Path: "/commands/0/composite/commands", Code: CodeCompositeCommandCycle, Severity: SeverityError, Message: fmt.Sprintf(ErrorCompositeCommandCycle, "a", "a -> b -> a")},
+				{Path: "/commands/1/composite/commands", Code: CodeCompositeCommandCycle, Severity: SeverityError, Message: fmt.Sprintf(ErrorCompositeCommandCycle, "b", "b -> a -> b")},
+			},
+		},
+		{
+			name: "Composite command without sub-commands and exec command without component",
+			commands: []common.DevfileCommand{
+				{Exec: &common.Exec{Id: "build"}},
+				{Composite: &common.Composite{Id: "all"}},
+			},
+			want: []ValidationError{
+				{Path: "/commands/0/exec", Code: CodeMissingExecComponent, Severity: SeverityError, Message: fmt.Sprintf(ErrorMissingExecComponent, "build")},
+				{Path: "/commands/1/composite/commands", Code: CodeEmptyCompositeCommand, Severity: SeverityError, Message: fmt.Sprintf(ErrorCompositeCommandEmptyCommands, "all")},
+			},
+		},
+		{
+			name: "Multiple default commands in a group",
+			commands: []common.DevfileCommand{
+				{Exec: &common.Exec{Id: "build1", Component: "runtime", Group: &common.Group{Kind: common.BuildCommandGroupType, IsDefault: true}}},
+				{Composite: &common.Composite{Id: "build2", Commands: []string{"build1"}, Group: &common.Group{Kind: common.BuildCommandGroupType, IsDefault: true}}},
+			},
+			want: []ValidationError{
+				{Path: "/commands/1/composite/group/isDefault", Code: CodeMultipleDefaultCommands, Severity: SeverityError, Message: fmt.Sprintf(ErrorMultipleDefaultCommands, "build", "build1, build2")},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ValidateCommands(tt.commands, components)
+			if !reflect.DeepEqual(got.Errors, tt.want) {
+				t.Errorf("got: '%v', want: '%v'", got.Errors, tt.want)
+			}
+		})
+	}
+}
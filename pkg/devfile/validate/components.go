@@ -1,6 +1,7 @@
 package validate
 
 import (
+	"errors"
 	"fmt"
 
 	"github.com/devfile/parser/pkg/devfile/parser/data/common"
@@ -12,12 +13,33 @@ var (
 	ErrorNoContainerComponent = fmt.Sprintf("odo requires atleast one component of type '%s' in devfile", common.ContainerComponentType)
 )
 
-// ValidateComponents validates all the devfile components
+// ValidateComponents validates all the devfile components, returning the first error found, warnings aside
 func ValidateComponents(components []common.DevfileComponent) error {
+	for _, e := range ValidateComponentsResult(components).Errors {
+		if e.Severity == SeverityError {
+			return errors.New(e.Message)
+		}
+	}
+	return nil
+}
+
+// ValidateComponentsResult validates all the devfile components, returning the problems found with their path and code
+func ValidateComponentsResult(components []common.DevfileComponent) *ValidationResult {
+	return validateComponents(components, true)
+}
+
+// validateComponents validates the devfile components, requiring a container component if requested
+func validateComponents(components []common.DevfileComponent, requireContainer bool) *ValidationResult {
+	result := NewValidationResult()
 
 	// components cannot be empty
 	if len(components) < 1 {
-		return fmt.Errorf(ErrorNoComponents)
+		result.AddError(JSONPointer("components"), CodeNoComponents, ErrorNoComponents)
+		return result
+	}
+
+	if !requireContainer {
+		return result
 	}
 
 	// Check if component of type container  is present
@@ -30,9 +52,8 @@ func ValidateComponents(components []common.DevfileComponent) error {
 	}
 
 	if !isContainerComponentPresent {
-		return fmt.Errorf(ErrorNoContainerComponent)
+		result.AddError(JSONPointer("components"), CodeNoContainerComponent, ErrorNoContainerComponent)
 	}
 
-	// Successful
-	return nil
+	return result
 }
@@ -1,10 +1,11 @@
 package validate
 
 import (
-	"fmt"
+	"errors"
 	"reflect"
 	"testing"
 
+	v220 "github.com/devfile/parser/pkg/devfile/parser/data/2.2.0"
 	"github.com/devfile/parser/pkg/devfile/parser/data/common"
 )
 
@@ -16,11 +17,19 @@ func TestValidateComponents(t *testing.T) {
 		components := []common.DevfileComponent{}
 
 		got := ValidateComponents(components)
-		want := fmt.Errorf(ErrorNoComponents)
+		want := errors.New(ErrorNoComponents)
 
 		if !reflect.DeepEqual(got, want) {
 			t.Errorf("got: '%v', want: '%v'", got, want)
 		}
+
+		result := ValidateComponentsResult(components)
+		wantErrors := []ValidationError{
+			{Path: "/components", Code: CodeNoComponents, Severity: SeverityError, Message: ErrorNoComponents},
+		}
+		if !reflect.DeepEqual(result.Errors, wantErrors) {
+			t.Errorf("got: '%v', want: '%v'", result.Errors, wantErrors)
+		}
 	})
 
 	t.Run("Container type of component present", func(t *testing.T) {
@@ -39,4 +48,28 @@ func TestValidateComponents(t *testing.T) {
 			t.Errorf("Not expecting an error: '%v'", got)
 		}
 	})
+
+	t.Run("No container component", func(t *testing.T) {
+
+		components := []common.DevfileComponent{
+			{
+				Volume: &common.Volume{
+					Name: "cache",
+				},
+			},
+		}
+
+		got := ValidateComponentsResult(components)
+		want := []ValidationError{
+			{Path: "/components", Code: CodeNoContainerComponent, Severity: SeverityError, Message: ErrorNoContainerComponent},
+		}
+		if !reflect.DeepEqual(got.Errors, want) {
+			t.Errorf("got: '%v', want: '%v'", got.Errors, want)
+		}
+
+		got = ValidateDevfileWithOptions(&v220.Devfile220{Components: components}, ValidationOptions{SkipContainerCheck: true})
+		if got.HasErrors() {
+			t.Errorf("Not expecting an error with the container check skipped: '%v'", got)
+		}
+	})
 }
@@ -0,0 +1,38 @@
+package validate
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/devfile/parser/pkg/devfile/parser/data/common"
+)
+
+// Errors
+var (
+	ErrorInvalidEventCommand = "%s event references command '%s' which does not exist"
+)
+
+// ValidateEvents validates that every devfile event references an existing command
+func ValidateEvents(events common.DevfileEvents, commands []common.DevfileCommand) *ValidationResult {
+	result := NewValidationResult()
+
+	commandMap := getCommandMap(commands)
+
+	for _, event := range []struct {
+		name string
+		ids  []string
+	}{
+		{"preStart", events.PreStart},
+		{"postStart", events.PostStart},
+		{"preStop", events.PreStop},
+		{"postStop", events.PostStop},
+	} {
+		for i, id := range event.ids {
+			if _, ok := commandMap[strings.ToLower(id)]; !ok {
+				result.AddError(JSONPointer("events", event.name, i), CodeInvalidEventCommand, fmt.Sprintf(ErrorInvalidEventCommand, event.name, id))
+			}
+		}
+	}
+
+	return result
+}
@@ -0,0 +1,45 @@
+package validate
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/devfile/parser/pkg/devfile/parser/data/common"
+)
+
+func TestValidateEvents(t *testing.T) {
+
+	commands := []common.DevfileCommand{
+		{Exec: &common.Exec{Id: "init"}},
+		{Composite: &common.Composite{Id: "clean"}},
+	}
+
+	tests := []struct {
+		name   string
+		events common.DevfileEvents
+		want   []ValidationError
+	}{
+		{
+			name:   "Events referencing existing commands",
+			events: common.DevfileEvents{PreStart: []string{"init"}, PostStop: []string{"Clean"}},
+		},
+		{
+			name:   "Events referencing missing commands",
+			events: common.DevfileEvents{PostStart: []string{"init", "missing"}, PreStop: []string{"stop"}},
+			want: []ValidationError{
+				{Path: "/events/postStart/1", Code: CodeInvalidEventCommand, Severity: SeverityError, Message: fmt.Sprintf(ErrorInvalidEventCommand, "postStart", "missing")},
+				{Path: "/events/preStop/0", Code: CodeInvalidEventCommand, Severity: SeverityError, Message: fmt.Sprintf(ErrorInvalidEventCommand, "preStop", "stop")},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ValidateEvents(tt.events, commands)
+			if !reflect.DeepEqual(got.Errors, tt.want) {
+				t.Errorf("got: '%v', want: '%v'", got.Errors, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,181 @@
+package validate
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Severity describes how serious a validation problem is
+type Severity string
+
+const (
+	// SeverityError problems make the devfile invalid
+	SeverityError Severity = "error"
+	// SeverityWarning problems are reported but don't make the devfile invalid
+	SeverityWarning Severity = "warning"
+)
+
+// Validation error codes
+const (
+	CodeNoComponents            = "no_components"
+	CodeNoContainerComponent    = "no_container_component"
+	CodeMissingExecComponent    = "missing_exec_component"
+	CodeInvalidExecComponent    = "invalid_exec_component"
+	CodeInvalidApplyComponent   = "invalid_apply_component"
+	CodeEmptyCompositeCommand   = "empty_composite_command"
+	CodeInvalidCompositeCommand = "invalid_composite_command"
+	CodeCompositeCommandCycle   = "composite_command_cycle"
+	CodeMultipleDefaultCommands = "multiple_default_commands"
+	CodeInvalidEventCommand     = "invalid_event_command"
+	CodeInvalidVolumeMount      = "invalid_volume_mount"
+	CodeConflictingMountPath    = "conflicting_mount_path"
+	CodeInvalidVolumeSize       = "invalid_volume_size"
+	CodeUnsupportedField        = "unsupported_field"
+	CodeMissingField            = "missing_field"
+	CodeSchemaPrefix            = "schema_"
+)
+
+// Position is the location of an element in the devfile source
+type Position struct {
+	// Line is the 1-based line of the element
+	Line int `json:"line"`
+
+	// Column is the 1-based column of the element
+	Column int `json:"column"`
+}
+
+// PositionLookup returns the source position of the element at the given JSON pointer, if known
+type PositionLookup func(path string) (Position, bool)
+
+// ValidationError is a single problem found while validating a devfile
+type ValidationError struct {
+	// Path is the JSON pointer to the offending element, e.g. /components/2/container/memoryLimit
+	Path string `json:"path"`
+
+	// Code is the machine-readable identifier of the problem
+	Code string `json:"code"`
+
+	// Severity of the problem
+	Severity Severity `json:"severity"`
+
+	// Message is the human-readable description of the problem
+	Message string `json:"message"`
+
+	// Position of the offending element in the devfile source, if known
+	Position *Position `json:"position,omitempty"`
+}
+
+// String returns the problem prefixed by its path and position
+func (e ValidationError) String() string {
+	location := e.Path
+	if e.Position != nil {
+		location = strings.TrimSpace(fmt.Sprintf("%s (line %d, column %d)", e.Path, e.Position.Line, e.Position.Column))
+	}
+	if location == "" {
+		return e.Message
+	}
+	return fmt.Sprintf("%s: %s", location, e.Message)
+}
+
+// ValidationResult collects all the problems found while validating a devfile
+type ValidationResult struct {
+	Errors []ValidationError `json:"errors,omitempty"`
+}
+
+// NewValidationResult returns an empty ValidationResult
+func NewValidationResult() *ValidationResult {
+	return &ValidationResult{}
+}
+
+// AddError adds an error-severity problem to the result
+func (r *ValidationResult) AddError(path string, code string, message string) {
+	r.Errors = append(r.Errors, ValidationError{Path: path, Code: code, Severity: SeverityError, Message: message})
+}
+
+// AddWarning adds a warning-severity problem to the result
+func (r *ValidationResult) AddWarning(path string, code string, message string) {
+	r.Errors = append(r.Errors, ValidationError{Path: path, Code: code, Severity: SeverityWarning, Message: message})
+}
+
+// Merge adds all the problems of the other result to the result
+func (r *ValidationResult) Merge(other *ValidationResult) {
+	if other != nil {
+		r.Errors = append(r.Errors, other.Errors...)
+	}
+}
+
+// AttachPositions sets the source position of the problems which don't have one yet.
+// A problem about an element missing from the source, e.g. a required property,
+// is positioned on its nearest ancestor.
+func (r *ValidationResult) AttachPositions(lookup PositionLookup) {
+	if lookup == nil {
+		return
+	}
+	for i := range r.Errors {
+		if r.Errors[i].Position != nil {
+			continue
+		}
+		path := r.Errors[i].Path
+		for {
+			if position, ok := lookup(path); ok {
+				r.Errors[i].Position = &position
+				break
+			}
+			if path == "" {
+				break
+			}
+			path = path[:strings.LastIndex(path, "/")]
+		}
+	}
+}
+
+// HasErrors returns true if the result contains at least one error-severity problem
+func (r *ValidationResult) HasErrors() bool {
+	for _, e := range r.Errors {
+		if e.Severity == SeverityError {
+			return true
+		}
+	}
+	return false
+}
+
+// Warnings returns the warning-severity problems of the result
+func (r *ValidationResult) Warnings() []ValidationError {
+	var warnings []ValidationError
+	for _, e := range r.Errors {
+		if e.Severity == SeverityWarning {
+			warnings = append(warnings, e)
+		}
+	}
+	return warnings
+}
+
+// Error implements the error interface, listing every problem of the result
+func (r *ValidationResult) Error() string {
+	var messages []string
+	for _, e := range r.Errors {
+		messages = append(messages, fmt.Sprintf("- %s %s", e.Severity, e))
+	}
+	return fmt.Sprintf("invalid devfile. errors :\n%s\n", strings.Join(messages, "\n"))
+}
+
+// ToError returns the result as an error if it contains at least one error-severity problem, nil otherwise
+func (r *ValidationResult) ToError() error {
+	if r == nil || !r.HasErrors() {
+		return nil
+	}
+	return r
+}
+
+// JSONPointer returns the JSON pointer made of the given reference tokens, escaping them as per RFC 6901
+func JSONPointer(tokens ...interface{}) string {
+	var b strings.Builder
+	for _, token := range tokens {
+		s := fmt.Sprintf("%v", token)
+		s = strings.Replace(s, "~", "~0", -1)
+		s = strings.Replace(s, "/", "~1", -1)
+		b.WriteString("/")
+		b.WriteString(s)
+	}
+	return b.String()
+}
@@ -0,0 +1,106 @@
+package validate
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestValidationResult(t *testing.T) {
+
+	t.Run("Warnings only", func(t *testing.T) {
+		result := NewValidationResult()
+		result.AddWarning("/components/0", "some_warning", "some warning")
+
+		if result.HasErrors() {
+			t.Errorf("not expecting errors in '%v'", result.Errors)
+		}
+		if err := result.ToError(); err != nil {
+			t.Errorf("not expecting an error: '%v'", err)
+		}
+		if got := len(result.Warnings()); got != 1 {
+			t.Errorf("got %d warnings, want 1", got)
+		}
+	})
+
+	t.Run("Merged errors and warnings", func(t *testing.T) {
+		result := NewValidationResult()
+		result.AddWarning("/components/0", "some_warning", "some warning")
+
+		other := NewValidationResult()
+		other.AddError("/commands/1/exec/component", CodeInvalidExecComponent, "some error")
+		result.Merge(other)
+		result.Merge(nil)
+
+		err := result.ToError()
+		if err == nil {
+			t.Fatalf("expected an error, didn't get one")
+		}
+		want := "invalid devfile. errors :\n- warning /components/0: some warning\n- error /commands/1/exec/component: some error\n"
+		if err.Error() != want {
+			t.Errorf("got: '%s', want: '%s'", err.Error(), want)
+		}
+	})
+}
+
+func TestAttachPositions(t *testing.T) {
+
+	positions := map[string]Position{
+		"/components/0":           {Line: 3, Column: 5},
+		"/components/0/container": {Line: 3, Column: 7},
+	}
+	lookup := func(path string) (Position, bool) {
+		position, ok := positions[path]
+		return position, ok
+	}
+
+	result := NewValidationResult()
+	result.AddError("/components/0/container", "exact", "exact position")
+	result.AddError("/components/0/container/image", "ancestor", "nearest ancestor position")
+	result.AddError("/commands/0", "unknown", "no position")
+	result.AttachPositions(lookup)
+
+	want := []ValidationError{
+		{Path: "/components/0/container", Code: "exact", Severity: SeverityError, Message: "exact position", Position: &Position{Line: 3, Column: 7}},
+		{Path: "/components/0/container/image", Code: "ancestor", Severity: SeverityError, Message: "nearest ancestor position", Position: &Position{Line: 3, Column: 7}},
+		{Path: "/commands/0", Code: "unknown", Severity: SeverityError, Message: "no position"},
+	}
+	if !reflect.DeepEqual(result.Errors, want) {
+		t.Errorf("got: '%v', want: '%v'", result.Errors, want)
+	}
+
+	if got, want := result.Errors[1].String(), "/components/0/container/image (line 3, column 7): nearest ancestor position"; got != want {
+		t.Errorf("got: '%s', want: '%s'", got, want)
+	}
+}
+
+func TestJSONPointer(t *testing.T) {
+
+	tests := []struct {
+		name   string
+		tokens []interface{}
+		want   string
+	}{
+		{
+			name: "No tokens",
+			want: "",
+		},
+		{
+			name:   "String and index tokens",
+			tokens: []interface{}{"components", 2, "container", "memoryLimit"},
+			want:   "/components/2/container/memoryLimit",
+		},
+		{
+			name:   "Escaped tokens",
+			tokens: []interface{}{"attributes", "a/b~c"},
+			want:   "/attributes/a~1b~0c",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := JSONPointer(tt.tokens...); got != tt.want {
+				t.Errorf("got: '%s', want: '%s'", got, tt.want)
+			}
+		})
+	}
+}
@@ -9,36 +9,97 @@ import (
 	v100 "github.com/devfile/parser/pkg/devfile/parser/data/1.0.0"
 	v200 "github.com/devfile/parser/pkg/devfile/parser/data/2.0.0"
 	v210 "github.com/devfile/parser/pkg/devfile/parser/data/2.1.0"
+	v220 "github.com/devfile/parser/pkg/devfile/parser/data/2.2.0"
 )
 
+// devfileSections is implemented by the devfile data of all the versions, including the
+// versions registered outside of the parser
+type devfileSections interface {
+	GetComponents() []common.DevfileComponent
+	GetCommands() []common.DevfileCommand
+	GetEvents() common.DevfileEvents
+}
+
 // ValidateDevfileData validates whether sections of devfile are odo compatible
+// and whether the references between the devfile sections are valid.
+// The returned error is a *ValidationResult listing all the problems found.
 func ValidateDevfileData(data interface{}) error {
+	return ValidateDevfile(data).ToError()
+}
+
+// ValidationOptions relaxes the validation of the devfile data
+type ValidationOptions struct {
+	// SkipContainerCheck accepts devfiles without container component, which odo requires
+	SkipContainerCheck bool
+
+	// UniqueMountPaths rejects containers mounting different volumes to the same path
+	UniqueMountPaths bool
+}
+
+// ValidateDevfile validates the devfile data and returns a report of all the problems found
+func ValidateDevfile(data interface{}) *ValidationResult {
+	return ValidateDevfileWithOptions(data, ValidationOptions{})
+}
+
+// ValidateDevfileWithOptions validates the devfile data with the given options and returns a report of all the problems found
+func ValidateDevfileWithOptions(data interface{}, options ValidationOptions) *ValidationResult {
 	var components []common.DevfileComponent
+	var commands []common.DevfileCommand
+	var events common.DevfileEvents
+	var schemaVersion string
+
+	// devfile 1.0.0 declares volumes inline in the containers, not as volume components
+	validateVolumes := true
+
+	if d, ok := data.(devfileSections); ok {
+		components = d.GetComponents()
+		commands = d.GetCommands()
+		events = d.GetEvents()
+	}
 
 	typeData := reflect.TypeOf(data)
 
 	if typeData == reflect.TypeOf(&v100.Devfile100{}) {
-		d := data.(*v100.Devfile100)
-		components = d.GetComponents()
+		validateVolumes = false
 	}
 
 	if typeData == reflect.TypeOf(&v200.Devfile200{}) {
-		d := data.(*v200.Devfile200)
-		components = d.GetComponents()
+		schemaVersion = "2.0.0"
 	}
 
 	if typeData == reflect.TypeOf(&v210.Devfile210{}) {
-		d := data.(*v210.Devfile210)
-		components = d.GetComponents()
+		schemaVersion = "2.1.0"
 	}
 
+	if typeData == reflect.TypeOf(&v220.Devfile220{}) {
+		schemaVersion = "2.2.0"
+	}
+
+	result := NewValidationResult()
+
 	// Validate Components
-	if err := ValidateComponents(components); err != nil {
-		return err
+	result.Merge(validateComponents(components, !options.SkipContainerCheck))
+
+	// Validate the fields against the schema version
+	result.Merge(ValidateVersionFields(schemaVersion, components, commands))
+
+	// Validate Commands
+	result.Merge(ValidateCommands(commands, components))
+
+	// Validate Events
+	result.Merge(ValidateEvents(events, commands))
+
+	// Validate Volume Mounts
+	if validateVolumes {
+		result.Merge(validateVolumeMounts(components, options.UniqueMountPaths))
+		result.Merge(ValidateVolumeSizes(components))
+	}
+
+	if result.HasErrors() {
+		return result
 	}
 
 	// Successful
 	klog.V(4).Info("Successfully validated devfile sections")
-	return nil
-
+	return result
 }
@@ -0,0 +1,87 @@
+package validate
+
+import (
+	"fmt"
+
+	"github.com/devfile/parser/pkg/devfile/parser/data/common"
+)
+
+// Errors
+var (
+	ErrorUnsupportedDockerfile    = "dockerfile component '%s' is not supported in devfile %s, use schemaVersion 2.1.0 or later"
+	ErrorMissingEndpointConfigure = "endpoint '%s' of component '%s' requires a configuration in devfile %s"
+	ErrorUnsupportedField         = "%s is not supported in devfile %s, use schemaVersion 2.2.0 or later"
+)
+
+// ValidateVersionFields validates that the components and commands only contain the fields defined by the
+// devfile schema version. The common model carries the fields of all the versions, so an edited devfile can
+// contain a field which can't be written in its version.
+func ValidateVersionFields(schemaVersion string, components []common.DevfileComponent, commands []common.DevfileCommand) *ValidationResult {
+	result := NewValidationResult()
+
+	if schemaVersion != "2.0.0" && schemaVersion != "2.1.0" {
+		return result
+	}
+
+	for i, component := range components {
+		if schemaVersion == "2.0.0" && component.Dockerfile != nil {
+			result.AddError(JSONPointer("components", i, "dockerfile"), CodeUnsupportedField, fmt.Sprintf(ErrorUnsupportedDockerfile, component.Dockerfile.Name, schemaVersion))
+		}
+		if component.Attributes != nil {
+			result.AddError(JSONPointer("components", i, "attributes"), CodeUnsupportedField, fmt.Sprintf(ErrorUnsupportedField, "component attributes", schemaVersion))
+		}
+		if component.Image != nil {
+			result.AddError(JSONPointer("components", i, "image"), CodeUnsupportedField, fmt.Sprintf(ErrorUnsupportedField, fmt.Sprintf("image component '%s'", component.Image.Name), schemaVersion))
+		}
+		if component.Container != nil {
+			validateContainerVersionFields(schemaVersion, i, component.Container, result)
+		}
+	}
+
+	for i, command := range commands {
+		if command.Apply != nil {
+			result.AddError(JSONPointer("commands", i, "apply"), CodeUnsupportedField, fmt.Sprintf(ErrorUnsupportedField, fmt.Sprintf("apply command '%s'", command.Apply.Id), schemaVersion))
+		}
+		if group := command.GetGroup(); group != nil && group.Kind == common.DeployCommandGroupType {
+			result.AddError(JSONPointer("commands", i, getCommandKey(command), "group", "kind"), CodeUnsupportedField, fmt.Sprintf(ErrorUnsupportedField, "deploy command group", schemaVersion))
+		}
+	}
+
+	return result
+}
+
+// versionField is a field which is only defined by some devfile schema versions
+type versionField struct {
+	name string
+	set  bool
+}
+
+// validateContainerVersionFields validates that the container only contains the fields defined by the devfile schema version
+func validateContainerVersionFields(schemaVersion string, index int, container *common.Container, result *ValidationResult) {
+	for _, f := range []versionField{
+		{"annotation", container.Annotation != nil},
+		{"cpuLimit", container.CpuLimit != ""},
+		{"cpuRequest", container.CpuRequest != ""},
+		{"memoryRequest", container.MemoryRequest != ""},
+	} {
+		if f.set {
+			result.AddError(JSONPointer("components", index, "container", f.name), CodeUnsupportedField, fmt.Sprintf(ErrorUnsupportedField, fmt.Sprintf("container field '%s'", f.name), schemaVersion))
+		}
+	}
+
+	for j, endpoint := range container.Endpoints {
+		if schemaVersion == "2.1.0" && endpoint.Configuration == nil {
+			result.AddError(JSONPointer("components", index, "container", "endpoints", j), CodeMissingField, fmt.Sprintf(ErrorMissingEndpointConfigure, endpoint.Name, container.Name, schemaVersion))
+		}
+		for _, f := range []versionField{
+			{"exposure", endpoint.Exposure != ""},
+			{"path", endpoint.Path != ""},
+			{"protocol", endpoint.Protocol != ""},
+			{"secure", endpoint.Secure},
+		} {
+			if f.set {
+				result.AddError(JSONPointer("components", index, "container", "endpoints", j, f.name), CodeUnsupportedField, fmt.Sprintf(ErrorUnsupportedField, fmt.Sprintf("endpoint field '%s'", f.name), schemaVersion))
+			}
+		}
+	}
+}
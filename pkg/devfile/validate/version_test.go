@@ -0,0 +1,84 @@
+package validate
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/devfile/parser/pkg/devfile/parser/data/common"
+)
+
+func TestValidateVersionFields(t *testing.T) {
+
+	dockerfile := common.DevfileComponent{Dockerfile: &common.Dockerfile{Name: "build", DockerfileLocation: "Dockerfile"}}
+	container := common.DevfileComponent{Container: &common.Container{Name: "runtime", Endpoints: []common.Endpoint{{Name: "http", TargetPort: 8080}}}}
+	configured := common.DevfileComponent{Container: &common.Container{Name: "runtime", Endpoints: []common.Endpoint{{Name: "http", TargetPort: 8080, Configuration: &common.Configuration{}}}}}
+
+	tests := []struct {
+		name          string
+		schemaVersion string
+		components    []common.DevfileComponent
+		commands      []common.DevfileCommand
+		want          []ValidationError
+	}{
+		{
+			name:          "Dockerfile component in devfile 2.1.0",
+			schemaVersion: "2.1.0",
+			components:    []common.DevfileComponent{configured, dockerfile},
+		},
+		{
+			name:          "Dockerfile component in devfile 2.0.0",
+			schemaVersion: "2.0.0",
+			components:    []common.DevfileComponent{container, dockerfile},
+			want: []ValidationError{
+				{Path: "/components/1/dockerfile", Code: CodeUnsupportedField, Severity: SeverityError, Message: fmt.Sprintf(ErrorUnsupportedDockerfile, "build", "2.0.0")},
+			},
+		},
+		{
+			name:          "Endpoint without configuration in devfile 2.1.0",
+			schemaVersion: "2.1.0",
+			components:    []common.DevfileComponent{container},
+			want: []ValidationError{
+				{Path: "/components/0/container/endpoints/0", Code: CodeMissingField, Severity: SeverityError, Message: fmt.Sprintf(ErrorMissingEndpointConfigure, "http", "runtime", "2.1.0")},
+			},
+		},
+		{
+			name:          "Devfile 2.2.0 constructs in devfile 2.1.0",
+			schemaVersion: "2.1.0",
+			components: []common.DevfileComponent{
+				{Image: &common.Image{Name: "image", ImageName: "nodejs-app"}},
+				{Container: &common.Container{Name: "runtime", CpuLimit: "1", Endpoints: []common.Endpoint{{Name: "http", TargetPort: 8080, Configuration: &common.Configuration{}, Exposure: common.InternalEndpointExposure}}}},
+			},
+			commands: []common.DevfileCommand{
+				{Apply: &common.Apply{Id: "deploy", Component: "image", Group: &common.Group{Kind: common.DeployCommandGroupType}}},
+			},
+			want: []ValidationError{
+				{Path: "/components/0/image", Code: CodeUnsupportedField, Severity: SeverityError, Message: fmt.Sprintf(ErrorUnsupportedField, "image component 'image'", "2.1.0")},
+				{Path: "/components/1/container/cpuLimit", Code: CodeUnsupportedField, Severity: SeverityError, Message: fmt.Sprintf(ErrorUnsupportedField, "container field 'cpuLimit'", "2.1.0")},
+				{Path: "/components/1/container/endpoints/0/exposure", Code: CodeUnsupportedField, Severity: SeverityError, Message: fmt.Sprintf(ErrorUnsupportedField, "endpoint field 'exposure'", "2.1.0")},
+				{Path: "/commands/0/apply", Code: CodeUnsupportedField, Severity: SeverityError, Message: fmt.Sprintf(ErrorUnsupportedField, "apply command 'deploy'", "2.1.0")},
+				{Path: "/commands/0/apply/group/kind", Code: CodeUnsupportedField, Severity: SeverityError, Message: fmt.Sprintf(ErrorUnsupportedField, "deploy command group", "2.1.0")},
+			},
+		},
+		{
+			name:          "Devfile 2.2.0 constructs in devfile 2.2.0",
+			schemaVersion: "2.2.0",
+			components: []common.DevfileComponent{
+				{Image: &common.Image{Name: "image", ImageName: "nodejs-app"}},
+				{Container: &common.Container{Name: "runtime", CpuLimit: "1", Endpoints: []common.Endpoint{{Name: "http", TargetPort: 8080}}}},
+			},
+			commands: []common.DevfileCommand{
+				{Apply: &common.Apply{Id: "deploy", Component: "image", Group: &common.Group{Kind: common.DeployCommandGroupType}}},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ValidateVersionFields(tt.schemaVersion, tt.components, tt.commands)
+			if !reflect.DeepEqual(got.Errors, tt.want) {
+				t.Errorf("got: '%v', want: '%v'", got.Errors, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,98 @@
+package validate
+
+import (
+	"fmt"
+	"path"
+
+	"github.com/devfile/parser/pkg/devfile/parser/data/common"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+// Errors
+var (
+	ErrorInvalidVolumeMount       = "container '%s' mounts volume '%s' which is not a volume component"
+	ErrorConflictingMountPath     = "container '%s' mounts volumes '%s' and '%s' to the same path '%s'"
+	ErrorConflictingContainerPath = "containers '%s' and '%s' mount different volumes '%s' and '%s' to the same path '%s'"
+	ErrorInvalidVolumeSize        = "volume '%s' has an invalid size '%s', expected a positive quantity such as '1Gi'"
+)
+
+// ValidateVolumeMounts validates that every container volume mount references a volume component
+// and that the volumes of a container are mounted to different paths
+func ValidateVolumeMounts(components []common.DevfileComponent) *ValidationResult {
+	return validateVolumeMounts(components, false)
+}
+
+// validateVolumeMounts validates the container volume mounts, also requiring the containers to mount
+// different volumes to different paths if requested
+func validateVolumeMounts(components []common.DevfileComponent, uniqueMountPaths bool) *ValidationResult {
+	result := NewValidationResult()
+
+	volumes := make(map[string]bool)
+	for _, component := range components {
+		if component.Volume != nil {
+			volumes[component.Volume.Name] = true
+		}
+	}
+
+	// mountedPath is the first volume mounted to a path by a container
+	type mountedPath struct {
+		container string
+		volume    string
+	}
+	mountedPaths := make(map[string]mountedPath)
+
+	for i, component := range components {
+		if component.Container == nil {
+			continue
+		}
+		containerPaths := make(map[string]string)
+		for j, volumeMount := range component.Container.VolumeMounts {
+			if !volumes[volumeMount.Name] {
+				result.AddError(JSONPointer("components", i, "container", "volumeMounts", j, "name"), CodeInvalidVolumeMount, fmt.Sprintf(ErrorInvalidVolumeMount, component.Container.Name, volumeMount.Name))
+			}
+
+			mountPath := getVolumeMountPath(volumeMount)
+			pointer := JSONPointer("components", i, "container", "volumeMounts", j, "path")
+			if volume, ok := containerPaths[mountPath]; ok {
+				result.AddError(pointer, CodeConflictingMountPath, fmt.Sprintf(ErrorConflictingMountPath, component.Container.Name, volume, volumeMount.Name, mountPath))
+				continue
+			}
+			containerPaths[mountPath] = volumeMount.Name
+
+			if !uniqueMountPaths {
+				continue
+			}
+			if mounted, ok := mountedPaths[mountPath]; !ok {
+				mountedPaths[mountPath] = mountedPath{container: component.Container.Name, volume: volumeMount.Name}
+			} else if mounted.volume != volumeMount.Name {
+				result.AddError(pointer, CodeConflictingMountPath, fmt.Sprintf(ErrorConflictingContainerPath, mounted.container, component.Container.Name, mounted.volume, volumeMount.Name, mountPath))
+			}
+		}
+	}
+
+	return result
+}
+
+// ValidateVolumeSizes validates that the size of every volume component is a positive Kubernetes quantity
+func ValidateVolumeSizes(components []common.DevfileComponent) *ValidationResult {
+	result := NewValidationResult()
+
+	for i, component := range components {
+		if component.Volume == nil || component.Volume.Size == "" {
+			continue
+		}
+		if quantity, err := resource.ParseQuantity(component.Volume.Size); err != nil || quantity.Sign() <= 0 {
+			result.AddError(JSONPointer("components", i, "volume", "size"), CodeInvalidVolumeSize, fmt.Sprintf(ErrorInvalidVolumeSize, component.Volume.Name, component.Volume.Size))
+		}
+	}
+
+	return result
+}
+
+// getVolumeMountPath returns the path where the volume is mounted, "/<volume name>" if omitted
+func getVolumeMountPath(volumeMount common.VolumeMount) string {
+	if volumeMount.Path == "" {
+		return path.Join("/", volumeMount.Name)
+	}
+	return path.Clean(volumeMount.Path)
+}
@@ -0,0 +1,119 @@
+package validate
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/devfile/parser/pkg/devfile/parser/data/common"
+)
+
+func TestValidateVolumeMounts(t *testing.T) {
+
+	tests := []struct {
+		name             string
+		components       []common.DevfileComponent
+		uniqueMountPaths bool
+		want             []ValidationError
+	}{
+		{
+			name: "Volume mount referencing a volume component",
+			components: []common.DevfileComponent{
+				{Container: &common.Container{Name: "runtime", VolumeMounts: []common.VolumeMount{{Name: "cache", Path: "/cache"}}}},
+				{Volume: &common.Volume{Name: "cache"}},
+			},
+		},
+		{
+			name: "Volume mount referencing a missing volume component",
+			components: []common.DevfileComponent{
+				{Container: &common.Container{Name: "runtime", VolumeMounts: []common.VolumeMount{{Name: "cache", Path: "/cache"}}}},
+			},
+			want: []ValidationError{
+				{Path: "/components/0/container/volumeMounts/0/name", Code: CodeInvalidVolumeMount, Severity: SeverityError, Message: fmt.Sprintf(ErrorInvalidVolumeMount, "runtime", "cache")},
+			},
+		},
+		{
+			name: "Volumes mounted to the same path in a container",
+			components: []common.DevfileComponent{
+				{Container: &common.Container{Name: "runtime", VolumeMounts: []common.VolumeMount{{Name: "cache"}, {Name: "data", Path: "/cache/"}}}},
+				{Volume: &common.Volume{Name: "cache"}},
+				{Volume: &common.Volume{Name: "data"}},
+			},
+			want: []ValidationError{
+				{Path: "/components/0/container/volumeMounts/1/path", Code: CodeConflictingMountPath, Severity: SeverityError, Message: fmt.Sprintf(ErrorConflictingMountPath, "runtime", "cache", "data", "/cache")},
+			},
+		},
+		{
+			name: "Different volumes mounted to the same path by two containers",
+			components: []common.DevfileComponent{
+				{Container: &common.Container{Name: "runtime", VolumeMounts: []common.VolumeMount{{Name: "cache", Path: "/data"}}}},
+				{Container: &common.Container{Name: "tools", VolumeMounts: []common.VolumeMount{{Name: "data"}, {Name: "cache", Path: "/cache"}}}},
+				{Volume: &common.Volume{Name: "cache"}},
+				{Volume: &common.Volume{Name: "data"}},
+			},
+		},
+		{
+			name: "Different volumes mounted to the same path by two containers with unique mount paths",
+			components: []common.DevfileComponent{
+				{Container: &common.Container{Name: "runtime", VolumeMounts: []common.VolumeMount{{Name: "cache", Path: "/data"}}}},
+				{Container: &common.Container{Name: "tools", VolumeMounts: []common.VolumeMount{{Name: "data"}, {Name: "cache", Path: "/data"}}}},
+				{Volume: &common.Volume{Name: "cache"}},
+				{Volume: &common.Volume{Name: "data"}},
+			},
+			uniqueMountPaths: true,
+			want: []ValidationError{
+				{Path: "/components/1/container/volumeMounts/0/path", Code: CodeConflictingMountPath, Severity: SeverityError, Message: fmt.Sprintf(ErrorConflictingContainerPath, "runtime", "tools", "cache", "data", "/data")},
+				{Path: "/components/1/container/volumeMounts/1/path", Code: CodeConflictingMountPath, Severity: SeverityError, Message: fmt.Sprintf(ErrorConflictingMountPath, "tools", "data", "cache", "/data")},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := validateVolumeMounts(tt.components, tt.uniqueMountPaths)
+			if !reflect.DeepEqual(got.Errors, tt.want) {
+				t.Errorf("got: '%v', want: '%v'", got.Errors, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidateVolumeSizes(t *testing.T) {
+
+	tests := []struct {
+		name string
+		size string
+		want []ValidationError
+	}{
+		{
+			name: "Volume without size",
+		},
+		{
+			name: "Volume with a valid size",
+			size: "512Mi",
+		},
+		{
+			name: "Volume with an invalid size",
+			size: "1 GB",
+			want: []ValidationError{
+				{Path: "/components/0/volume/size", Code: CodeInvalidVolumeSize, Severity: SeverityError, Message: fmt.Sprintf(ErrorInvalidVolumeSize, "cache", "1 GB")},
+			},
+		},
+		{
+			name: "Volume with a negative size",
+			size: "-1Gi",
+			want: []ValidationError{
+				{Path: "/components/0/volume/size", Code: CodeInvalidVolumeSize, Severity: SeverityError, Message: fmt.Sprintf(ErrorInvalidVolumeSize, "cache", "-1Gi")},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ValidateVolumeSizes([]common.DevfileComponent{{Volume: &common.Volume{Name: "cache", Size: tt.size}}})
+			if !reflect.DeepEqual(got.Errors, tt.want) {
+				t.Errorf("got: '%v', want: '%v'", got.Errors, tt.want)
+			}
+		})
+	}
+}
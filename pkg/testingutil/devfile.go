@@ -84,6 +84,94 @@ func (d TestDevfileData) GetCommands() []versionsCommon.DevfileCommand {
 
 }
 
+// SetMetadata is a mock function to set the metadata of a devfile
+func (d *TestDevfileData) SetMetadata(metadata versionsCommon.DevfileMetadata) error {
+	return nil
+}
+
+// AddComponents is a mock function to add components to a devfile
+func (d *TestDevfileData) AddComponents(components []versionsCommon.DevfileComponent) error {
+	d.Components = append(d.Components, components...)
+	return nil
+}
+
+// UpdateComponent is a mock function to update a component of a devfile
+func (d *TestDevfileData) UpdateComponent(component versionsCommon.DevfileComponent) error {
+	for i := range d.Components {
+		if d.Components[i].GetName() == component.GetName() {
+			d.Components[i] = component
+		}
+	}
+	return nil
+}
+
+// DeleteComponent is a mock function to delete a component of a devfile
+func (d *TestDevfileData) DeleteComponent(name string) error {
+	for i := range d.Components {
+		if d.Components[i].GetName() == name {
+			d.Components = append(d.Components[:i], d.Components[i+1:]...)
+			break
+		}
+	}
+	return nil
+}
+
+// AddCommands is a mock function to add exec commands to a devfile
+func (d *TestDevfileData) AddCommands(commands []versionsCommon.DevfileCommand) error {
+	for _, command := range commands {
+		if command.Exec != nil {
+			d.ExecCommands = append(d.ExecCommands, *command.Exec)
+		}
+	}
+	return nil
+}
+
+// UpdateCommand is a mock function to update an exec command of a devfile
+func (d *TestDevfileData) UpdateCommand(command versionsCommon.DevfileCommand) error {
+	for i := range d.ExecCommands {
+		if command.Exec != nil && d.ExecCommands[i].Id == command.Exec.Id {
+			d.ExecCommands[i] = *command.Exec
+		}
+	}
+	return nil
+}
+
+// DeleteCommand is a mock function to delete an exec command of a devfile
+func (d *TestDevfileData) DeleteCommand(id string) error {
+	for i := range d.ExecCommands {
+		if d.ExecCommands[i].Id == id {
+			d.ExecCommands = append(d.ExecCommands[:i], d.ExecCommands[i+1:]...)
+			break
+		}
+	}
+	return nil
+}
+
+// AddProjects is a mock function to add projects to a devfile
+func (d *TestDevfileData) AddProjects(projects []versionsCommon.DevfileProject) error {
+	return nil
+}
+
+// UpdateProject is a mock function to update a project of a devfile
+func (d *TestDevfileData) UpdateProject(project versionsCommon.DevfileProject) error {
+	return nil
+}
+
+// DeleteProject is a mock function to delete a project of a devfile
+func (d *TestDevfileData) DeleteProject(name string) error {
+	return nil
+}
+
+// AddEvents is a mock function to add events to a devfile
+func (d *TestDevfileData) AddEvents(events versionsCommon.DevfileEvents) error {
+	return nil
+}
+
+// UpdateEvents is a mock function to update the events of a devfile
+func (d *TestDevfileData) UpdateEvents(events versionsCommon.DevfileEvents) error {
+	return nil
+}
+
 // Validate is a mock validation that always validates without error
 func (d TestDevfileData) Validate() error {
 	return nil
@@ -95,6 +183,7 @@ func GetFakeComponent(name string) versionsCommon.DevfileComponent {
 	memoryLimit := "128Mi"
 	volumeName := "myvolume1"
 	volumePath := "/my/volume/mount/path1"
+	mountSources := true
 
 	return versionsCommon.DevfileComponent{
 		Container: &versionsCommon.Container{
@@ -106,7 +195,7 @@ func GetFakeComponent(name string) versionsCommon.DevfileComponent {
 				Name: volumeName,
 				Path: volumePath,
 			}},
-			MountSources: true,
+			MountSources: &mountSources,
 		}}
 
 }